@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
 
-	"github.com/biryanim/wb_tech_calendar/internal/api/middleware"
-
-	"github.com/biryanim/wb_tech_calendar/internal/service/calendar"
-	"github.com/gin-gonic/gin"
-
-	calendarImpl "github.com/biryanim/wb_tech_calendar/internal/api/calendar"
+	grpcServer "github.com/biryanim/wb_tech_calendar/internal/api/grpc/server"
+	"github.com/biryanim/wb_tech_calendar/internal/api/router"
 	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/service/calendar"
+	"github.com/biryanim/wb_tech_calendar/internal/service/clock"
+	"github.com/biryanim/wb_tech_calendar/internal/service/holiday"
+	"github.com/biryanim/wb_tech_calendar/internal/service/idempotency"
+	"github.com/biryanim/wb_tech_calendar/internal/service/stream"
+	"github.com/biryanim/wb_tech_calendar/internal/service/webhook"
 )
 
 const (
@@ -27,20 +31,62 @@ func main() {
 		log.Fatalf("load http config: %v", err)
 	}
 
-	r := gin.New()
-	r.Use(gin.Recovery())
-	r.Use(middleware.LoggerMiddleware())
+	grpcConfig, err := config.NewGRPCConfig()
+	if err != nil {
+		log.Fatalf("load grpc config: %v", err)
+	}
+
+	webhookConfig, err := config.NewWebhookConfig()
+	if err != nil {
+		log.Fatalf("load webhook config: %v", err)
+	}
 
-	calendarService := calendar.New()
-	calendarImpl := calendarImpl.New(calendarService)
+	idempotencyConfig, err := config.NewIdempotencyConfig()
+	if err != nil {
+		log.Fatalf("load idempotency config: %v", err)
+	}
+
+	holidayConfig, err := config.NewHolidayConfig()
+	if err != nil {
+		log.Fatalf("load holiday config: %v", err)
+	}
 
-	r.POST("/create_event", calendarImpl.CreateEvent)
-	r.POST("/update_event", calendarImpl.UpdateEvent)
-	r.POST("/delete_event", calendarImpl.DeleteEvent)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	r.GET("/events_for_day", calendarImpl.GetEventsForDay)
-	r.GET("/events_for_week", calendarImpl.GetEventsForWeek)
-	r.GET("/events_for_month", calendarImpl.GetEventsForMonth)
+	clk := clock.New()
+
+	webhookService, err := webhook.New(webhookConfig, clk)
+	if err != nil {
+		log.Fatalf("init webhook service: %v", err)
+	}
+	go webhookService.Run(ctx)
+
+	streamService := stream.New()
+
+	holidayService, err := holiday.New(holidayConfig)
+	if err != nil {
+		log.Fatalf("init holiday service: %v", err)
+	}
+
+	calendarService := calendar.New(clk, webhookService, streamService)
+	idempotencyService := idempotency.New(idempotencyConfig, clk)
+	r, err := router.New(calendarService, holidayService, webhookService, streamService, idempotencyService)
+	if err != nil {
+		log.Fatalf("init router: %v", err)
+	}
+
+	grpcListener, err := net.Listen("tcp", grpcConfig.Address())
+	if err != nil {
+		log.Fatalf("listen grpc: %v", err)
+	}
+	grpcSrv := grpcServer.New(grpcConfig, calendarService, streamService)
+	go func() {
+		if err := grpcSrv.Serve(grpcListener); err != nil {
+			log.Fatalf("serve grpc: %v", err)
+		}
+	}()
+	defer grpcSrv.GracefulStop()
 
 	if err = r.Run(httpConfig.Address()); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,48 @@
+package calendar
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// GetWorkingHoursOverlap handles GET /api/v1/working_hours/overlap, returning the working time of each
+// requested user and the part they all share, with times in the requested time zone.
+func (i *Implementation) GetWorkingHoursOverlap(c *gin.Context) {
+	var q dto.OverlapQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	userIDs, from, to, err := converter.FromOverlapQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.GetWorkingHoursOverlap(c.Request.Context(), userIDs, from, to)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToWorkingHoursOverlapResp(res, from.Location()))
+}
+
+// eventWarnings returns the warnings about a created event for its response. They are advisory,
+// so failing to work them out does not fail the request.
+func (i *Implementation) eventWarnings(ctx context.Context, event *model.Event) []*dto.Warning {
+	warnings, err := i.calendarService.CheckEvent(ctx, event)
+	if err != nil {
+		return nil
+	}
+
+	return converter.ToWarningsResp(warnings)
+}
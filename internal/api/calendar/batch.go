@@ -0,0 +1,81 @@
+package calendar
+
+import (
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// batchStatuses are the statuses of successful batch operations.
+var batchStatuses = map[model.BatchOpType]int{
+	model.BatchCreate: http.StatusCreated,
+	model.BatchUpdate: http.StatusOK,
+	model.BatchDelete: http.StatusNoContent,
+}
+
+// BatchEvents handles POST /api/v1/events/batch, applying creates, updates and deletes as one unit.
+// It answers 200 with a result per operation; unless continue_on_error is set, either every
+// operation is applied or none is.
+func (i *Implementation) BatchEvents(c *gin.Context) {
+	var req dto.BatchRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	results := make([]model.BatchResult, len(req.Operations))
+	ops := make([]model.BatchOp, 0, len(req.Operations))
+	// positions maps the operations passed to the service back to the request.
+	positions := make([]int, 0, len(req.Operations))
+	failed := false
+	for n := range req.Operations {
+		op, err := converter.FromBatchOperation(&req.Operations[n])
+		if err != nil {
+			results[n].Err = err
+			failed = true
+			continue
+		}
+		ops = append(ops, op)
+		positions = append(positions, n)
+	}
+
+	if failed && !req.ContinueOnError {
+		for _, n := range positions {
+			results[n].Err = model.ErrBatchAborted
+		}
+	} else {
+		applied, err := i.calendarService.ApplyBatch(c.Request.Context(), ops, req.ContinueOnError)
+		if err != nil {
+			problem.Write(c, err)
+			return
+		}
+		for k, n := range positions {
+			results[n] = applied[k]
+		}
+	}
+
+	resp := &dto.BatchResponse{Results: make([]*dto.BatchResult, 0, len(results))}
+	for n, res := range results {
+		item := &dto.BatchResult{Index: n}
+		if res.Err != nil {
+			item.Error = newProblem(res.Err)
+			item.Error.Instance = c.Request.URL.Path
+			item.Status = item.Error.Status
+		} else {
+			opType := model.BatchOpType(req.Operations[n].Op)
+			item.Status = batchStatuses[opType]
+			item.Event = converter.ToEventResp(res.Event)
+			if opType == model.BatchCreate {
+				item.Event.Warnings = i.eventWarnings(c.Request.Context(), res.Event)
+			}
+		}
+		resp.Results = append(resp.Results, item)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
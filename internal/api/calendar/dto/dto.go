@@ -1,30 +1,494 @@
 package dto
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+)
+
 // Event represents a calendar event in API responses.
+// Range queries set ContinuesBefore and ContinuesAfter on events that start before the range or end after it.
 type Event struct {
-	ID     int    `json:"id"`
-	UserID int    `json:"user_id"`
-	Date   string `json:"date"`
-	Title  string `json:"title"`
+	ID              string   `json:"id"`
+	LegacyID        int      `json:"legacy_id"`
+	UserID          int      `json:"user_id"`
+	Date            string   `json:"date"`
+	End             string   `json:"end,omitempty"`
+	AllDay          bool     `json:"all_day"`
+	ContinuesBefore bool     `json:"continues_before,omitempty"`
+	ContinuesAfter  bool     `json:"continues_after,omitempty"`
+	Title           string   `json:"title"`
+	Version         int      `json:"version"`
+	Tags            []string `json:"tags"`
+	Description     string   `json:"description"`
+	Location        string   `json:"location"`
+	URL             string   `json:"url"`
+	Status          string   `json:"status"`
+	Transparency    string   `json:"transparency"`
+	Visibility      string   `json:"visibility"`
+	// Warnings are only set on the response of the request that created the event.
+	Warnings []*Warning `json:"warnings,omitempty"`
+}
+
+// EventID is an event ID in a request body: a ULID string or, until clients have moved to ULIDs,
+// a legacy integer ID given as a JSON number or string.
+type EventID string
+
+// UnmarshalJSON accepts a JSON string or an integer. Any other value makes the request malformed.
+func (id *EventID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*id = EventID(s)
+		return nil
+	}
+
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("event id must be a string or an integer, got %s", data)
+	}
+	*id = EventID(strconv.FormatInt(n, 10))
+
+	return nil
+}
+
+// Warning represents a problem with a request that did not stop it.
+type Warning struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
 }
 
 // CreateEventRequest represents the payload for creating a new calendar event.
+// Date and the optional exclusive End are dates or RFC 3339 date-times; all-day events take dates only.
+// Empty status, transparency and visibility default to confirmed, busy and public.
 type CreateEventRequest struct {
-	UserID int    `json:"user_id" binding:"required"`
-	Date   string `json:"date" binding:"required"`
-	Title  string `json:"title" binding:"required"`
+	UserID       int      `json:"user_id" binding:"required"`
+	Date         string   `json:"date" binding:"required"`
+	End          string   `json:"end"`
+	AllDay       bool     `json:"all_day"`
+	Title        string   `json:"title" binding:"required"`
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
 }
 
-// UpdateEventRequest represents the payload for updating an existing calendar event.
+// UpdateEventRequest represents the payload for replacing an existing calendar event.
+// Partial updates go through a merge patch of EventFields instead.
 type UpdateEventRequest struct {
-	ID     int    `json:"id" binding:"required"`
-	UserID int    `json:"user_id" binding:"required"`
-	Date   string `json:"date"`
-	Title  string `json:"title"`
+	ID           EventID  `json:"id" binding:"required,eventid"`
+	UserID       int      `json:"user_id" binding:"required"`
+	Date         string   `json:"date" binding:"required"`
+	End          string   `json:"end"`
+	AllDay       bool     `json:"all_day"`
+	Title        string   `json:"title" binding:"required"`
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
+}
+
+// EventFields represents the client-writable fields of an event.
+// It is the body of a full replacement and the document a JSON Merge Patch is applied to,
+// so every new writable field belongs here.
+type EventFields struct {
+	Date         string   `json:"date" binding:"required"`
+	End          string   `json:"end"`
+	AllDay       bool     `json:"all_day"`
+	Title        string   `json:"title" binding:"required"`
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
 }
 
 // DeleteEventRequest represents the payload for deleting a calendar event.
 type DeleteEventRequest struct {
-	ID     int `json:"id" binding:"required"`
-	UserID int `json:"user_id" binding:"required"`
+	ID     EventID `json:"id" binding:"required"`
+	UserID int     `json:"user_id" binding:"required"`
+}
+
+// Tag represents an event tag in API responses.
+type Tag struct {
+	UserID   int    `json:"user_id"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color,omitempty"`
+}
+
+// CreateTagRequest represents the payload for creating a tag.
+type CreateTagRequest struct {
+	UserID   int    `json:"user_id" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Category string `json:"category"`
+	Color    string `json:"color"`
+}
+
+// TagFields represents the client-writable fields of a tag; a PUT replaces all of them.
+type TagFields struct {
+	Name     string `json:"name" binding:"required"`
+	Category string `json:"category"`
+	Color    string `json:"color"`
+}
+
+// Template represents an event template in API responses. Duration is a Go duration such as "1h30m",
+// empty for instants and one-day all-day events.
+type Template struct {
+	ID           int      `json:"id"`
+	UserID       int      `json:"user_id"`
+	Name         string   `json:"name"`
+	Title        string   `json:"title"`
+	Duration     string   `json:"duration"`
+	AllDay       bool     `json:"all_day"`
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
+}
+
+// CreateTemplateRequest represents the payload for saving an event template.
+type CreateTemplateRequest struct {
+	UserID       int      `json:"user_id" binding:"required"`
+	Name         string   `json:"name" binding:"required"`
+	Title        string   `json:"title"`
+	Duration     string   `json:"duration"`
+	AllDay       bool     `json:"all_day"`
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
+}
+
+// TemplateFields represents the client-writable fields of an event template; a PUT replaces all of them.
+type TemplateFields struct {
+	Name         string   `json:"name" binding:"required"`
+	Title        string   `json:"title"`
+	Duration     string   `json:"duration"`
+	AllDay       bool     `json:"all_day"`
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
+}
+
+// CreateFromTemplateRequest represents the payload for creating an event from a template.
+// Date is the start, a date or an RFC 3339 date-time; all-day templates take dates only.
+// Overrides is a JSON Merge Patch of EventFields applied to the event made from the template.
+type CreateFromTemplateRequest struct {
+	Date      string          `json:"date" binding:"required"`
+	Overrides json.RawMessage `json:"overrides"`
+}
+
+// QuickEventRequest represents the payload for reading an event from free-form Russian or English text,
+// such as "lunch with Ivan tomorrow 13:00 for 1h". Dates in the text are read in time_zone, the user's
+// time zone by default. Without create the event is only returned as a draft to confirm.
+type QuickEventRequest struct {
+	UserID   int    `json:"user_id" binding:"required"`
+	Text     string `json:"text" binding:"required"`
+	TimeZone string `json:"time_zone" binding:"omitempty,timezone"`
+	Create   bool   `json:"create"`
+}
+
+// EventDraft represents an event read from text that has not been created. Event can be sent to
+// POST /api/v1/events as is; its times are in time_zone. Recurrence is the RRULE the text asked for,
+// which events do not support yet.
+type EventDraft struct {
+	Event      *CreateEventRequest `json:"event"`
+	TimeZone   string              `json:"time_zone"`
+	Recurrence string              `json:"recurrence,omitempty"`
+	Warnings   []*Warning          `json:"warnings,omitempty"`
+}
+
+// Settings represents the calendar settings of a user in API responses.
+type Settings struct {
+	UserID       int             `json:"user_id"`
+	WeekStart    string          `json:"week_start"`
+	Country      string          `json:"country,omitempty"`
+	TimeZone     string          `json:"time_zone"`
+	WorkingHours []*WorkingHours `json:"working_hours"`
+	OutOfOffice  []*OutOfOffice  `json:"out_of_office"`
+}
+
+// SettingsFields represents the client-writable settings of a user; a PUT replaces all of them.
+type SettingsFields struct {
+	WeekStart    string          `json:"week_start" binding:"required,weekday"`
+	Country      string          `json:"country"`
+	TimeZone     string          `json:"time_zone" binding:"omitempty,timezone"`
+	WorkingHours []*WorkingHours `json:"working_hours" binding:"max=14,dive"`
+	OutOfOffice  []*OutOfOffice  `json:"out_of_office" binding:"max=100,dive"`
+}
+
+// WorkingHours represents the hours a user works on a weekday as HH:MM times in the user's time zone.
+// The end is exclusive; 24:00 ends at midnight.
+type WorkingHours struct {
+	Weekday string `json:"weekday" binding:"required,weekday"`
+	Start   string `json:"start" binding:"required,timeofday"`
+	End     string `json:"end" binding:"required,timeofday"`
+}
+
+// OutOfOffice represents a period a user is away. Start and the exclusive End are dates, taken as
+// midnight in the user's time zone, or RFC 3339 date-times.
+type OutOfOffice struct {
+	Start string `json:"start" binding:"required"`
+	End   string `json:"end" binding:"required"`
+	Note  string `json:"note,omitempty"`
+}
+
+// WorkingHoursOverlap represents the working time of several users and the part they share in API responses.
+type WorkingHoursOverlap struct {
+	Users   []*UserWorkingTime `json:"users"`
+	Overlap []*Interval        `json:"overlap"`
+}
+
+// UserWorkingTime represents the working time of one user within the requested range.
+type UserWorkingTime struct {
+	UserID   int         `json:"user_id"`
+	TimeZone string      `json:"time_zone"`
+	Working  []*Interval `json:"working"`
+}
+
+// Interval represents the time from Start to the exclusive End as RFC 3339 date-times.
+type Interval struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// MonthGrid represents a month laid out as six weeks of seven days in API responses.
+type MonthGrid struct {
+	Month     string       `json:"month"`
+	WeekStart string       `json:"week_start"`
+	Weeks     [][]*GridDay `json:"weeks"`
+}
+
+// GridDay represents a single day of a MonthGrid.
+type GridDay struct {
+	Date        string   `json:"date"`
+	InMonth     bool     `json:"in_month"`
+	Weekend     bool     `json:"weekend"`
+	Holiday     bool     `json:"holiday"`
+	HolidayName string   `json:"holiday_name,omitempty"`
+	Workday     bool     `json:"workday"`
+	Events      []*Event `json:"events"`
+	Overflow    int      `json:"overflow"`
+}
+
+// WeekView represents a calendar week laid out day by day in API responses.
+type WeekView struct {
+	Start     string     `json:"start"`
+	WeekStart string     `json:"week_start"`
+	Days      []*WeekDay `json:"days"`
+}
+
+// WeekDay represents a single day of a WeekView.
+type WeekDay struct {
+	Date        string   `json:"date"`
+	Weekend     bool     `json:"weekend"`
+	Holiday     bool     `json:"holiday"`
+	HolidayName string   `json:"holiday_name,omitempty"`
+	Workday     bool     `json:"workday"`
+	Events      []*Event `json:"events"`
+}
+
+// PeriodStats represents the event counts of a single period, starting on the day Start.
+type PeriodStats struct {
+	Start      string         `json:"start"`
+	Total      int            `json:"total"`
+	Untagged   int            `json:"untagged"`
+	Tags       map[string]int `json:"tags"`
+	Categories map[string]int `json:"categories"`
+}
+
+// SearchHit represents an event found by a search and its relevance score.
+type SearchHit struct {
+	Event *Event  `json:"event"`
+	Score float64 `json:"score"`
+}
+
+// BatchRequest represents a list of event mutations applied in order as one unit.
+// Operations are not validated on binding so that every invalid one gets its own result.
+type BatchRequest struct {
+	ContinueOnError bool             `json:"continue_on_error"`
+	Operations      []BatchOperation `json:"operations" binding:"required,min=1,max=1000"`
+}
+
+// BatchOperation represents a single create, update or delete of a batch.
+// A create takes user_id, date and title, an update additionally id, and a delete id and user_id.
+// A non-zero version must match the stored one, like If-Match does for single writes.
+type BatchOperation struct {
+	Op           string   `json:"op"`
+	ID           EventID  `json:"id"`
+	UserID       int      `json:"user_id"`
+	Date         string   `json:"date"`
+	End          string   `json:"end"`
+	AllDay       bool     `json:"all_day"`
+	Title        string   `json:"title"`
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
+	Version      int      `json:"version"`
+}
+
+// BatchResponse represents the per-operation results of a batch, in request order.
+type BatchResponse struct {
+	Results []*BatchResult `json:"results"`
+}
+
+// BatchResult represents the outcome of a single batch operation.
+// Status is the status the matching single-event request would have answered with.
+type BatchResult struct {
+	Index  int              `json:"index"`
+	Status int              `json:"status"`
+	Event  *Event           `json:"event,omitempty"`
+	Error  *problem.Problem `json:"error,omitempty"`
+}
+
+// SyncResponse represents the changes returned by an incremental sync.
+// LegacyDeleted holds the legacy integer IDs of the deleted events in the order of Deleted.
+type SyncResponse struct {
+	Events        []*Event `json:"events"`
+	Deleted       []string `json:"deleted"`
+	LegacyDeleted []int    `json:"legacy_deleted"`
+	Token         string   `json:"token"`
+}
+
+// UserQuery represents the owner parameter shared by per-user endpoints.
+type UserQuery struct {
+	UserID int `form:"user_id" binding:"required,gt=0"`
+}
+
+// ZoneQuery represents the optional IANA time zone dates are interpreted in; UTC by default.
+type ZoneQuery struct {
+	TimeZone string `form:"tz" binding:"omitempty,timezone"`
+}
+
+// EventURI represents the path parameters addressing a single event by ULID or legacy integer ID.
+type EventURI struct {
+	ID string `uri:"id" binding:"required,eventid"`
+}
+
+// TagQuery represents the comma-separated tag filters of the range endpoints.
+// An event is returned if it has any of tags, or tags is empty, and none of exclude_tags.
+type TagQuery struct {
+	Tags        string `form:"tags"`
+	ExcludeTags string `form:"exclude_tags"`
+}
+
+// DateQuery represents the query parameters of the day, week and month endpoints.
+type DateQuery struct {
+	UserQuery
+	ZoneQuery
+	TagQuery
+	Date string `form:"date" binding:"required,date"`
+}
+
+// WeekQuery represents the query parameters of the week endpoint. The week is given either by a date in it
+// or as an ISO week; week_start overrides the first weekday from the user's settings for a date.
+type WeekQuery struct {
+	UserQuery
+	ZoneQuery
+	TagQuery
+	Date      string `form:"date" binding:"required_without=Week,excluded_with=Week,omitempty,date"`
+	Week      string `form:"week" binding:"omitempty,isoweek"`
+	WeekStart string `form:"week_start" binding:"omitempty,weekday"`
+}
+
+// HolidaysQuery represents the holiday calendar overlaid on a view, by country; the one of the user's
+// settings by default.
+type HolidaysQuery struct {
+	Holidays string `form:"holidays"`
+}
+
+// MonthGridQuery represents the query parameters of the month grid.
+type MonthGridQuery struct {
+	DateQuery
+	HolidaysQuery
+	WeekStart string `form:"week_start" binding:"omitempty,weekday"`
+	MaxEvents int    `form:"max_events" binding:"omitempty,min=1,max=50"`
+}
+
+// WeekViewQuery represents the query parameters of the week view.
+type WeekViewQuery struct {
+	WeekQuery
+	HolidaysQuery
+}
+
+// RangeQuery represents the query parameters of an arbitrary date range. Both ends are inclusive.
+type RangeQuery struct {
+	UserQuery
+	ZoneQuery
+	TagQuery
+	From string `form:"from" binding:"required,date"`
+	To   string `form:"to" binding:"required,date,gtedate=From"`
+}
+
+// OverlapQuery represents the query parameters of the working-hours overlap of several users;
+// user_ids is comma-separated. Both ends of the range are inclusive.
+type OverlapQuery struct {
+	ZoneQuery
+	UserIDs string `form:"user_ids" binding:"required"`
+	From    string `form:"from" binding:"required,date"`
+	To      string `form:"to" binding:"required,date,gtedate=From"`
+}
+
+// StatsQuery represents the query parameters of the per-period event statistics; weekly by default.
+type StatsQuery struct {
+	RangeQuery
+	Period string `form:"period" binding:"omitempty,oneof=day week month"`
+}
+
+// TemplateURI represents the path parameters addressing a single event template.
+type TemplateURI struct {
+	ID int `uri:"id" binding:"required,gt=0"`
+}
+
+// TagURI represents the path parameters addressing a single tag.
+type TagURI struct {
+	Name string `uri:"name" binding:"required"`
+}
+
+// SearchQuery represents the query parameters of a full-text search. Both ends of the
+// optional date range are inclusive, and events overlapping it match.
+type SearchQuery struct {
+	UserQuery
+	ZoneQuery
+	Q     string `form:"q" binding:"required,max=200"`
+	From  string `form:"from" binding:"omitempty,date"`
+	To    string `form:"to" binding:"omitempty,date,gtedate=From"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// SyncQuery represents the query parameters of an incremental sync.
+type SyncQuery struct {
+	UserQuery
+	Token string `form:"token"`
 }
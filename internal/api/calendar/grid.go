@@ -0,0 +1,96 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// GetMonthGrid handles GET /api/v1/views/month, returning the month containing a date as six weeks of days.
+func (i *Implementation) GetMonthGrid(c *gin.Context) {
+	var q dto.MonthGridQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	query, err := converter.FromMonthGridQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if query.Holidays, err = i.holidayCalendar(ctx, query.UserID, converter.FromHolidaysQuery(&q.HolidaysQuery)); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.GetMonthGrid(ctx, query)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToMonthGridResp(res))
+}
+
+// GetWeekView handles GET /api/v1/views/week, returning the calendar week containing a date, or an ISO week,
+// day by day.
+func (i *Implementation) GetWeekView(c *gin.Context) {
+	var q dto.WeekViewQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	query, err := converter.FromWeekViewQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if query.Holidays, err = i.holidayCalendar(ctx, query.UserID, converter.FromHolidaysQuery(&q.HolidaysQuery)); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.GetWeekView(ctx, query)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToWeekViewResp(res))
+}
+
+// holidayCalendar returns the holiday calendar overlaid on a view of the user: the one of country if it is set
+// and the one of the user's settings otherwise. It returns nil if neither names a loaded calendar.
+func (i *Implementation) holidayCalendar(ctx context.Context, userID int, country string) (*model.HolidayCalendar, error) {
+	if len(country) > 0 {
+		cal, err := i.holidayService.GetCalendar(ctx, country)
+		if errors.Is(err, model.ErrHolidayCalendarNotFound) {
+			return nil, model.NewValidationError("holidays", model.ErrUnknownCountry)
+		}
+		return cal, err
+	}
+
+	settings, err := i.calendarService.GetSettings(ctx, userID)
+	if err != nil || len(settings.Country) == 0 {
+		return nil, err
+	}
+
+	cal, err := i.holidayService.GetCalendar(ctx, settings.Country)
+	if errors.Is(err, model.ErrHolidayCalendarNotFound) {
+		return nil, nil
+	}
+	return cal, err
+}
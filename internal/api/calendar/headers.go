@@ -0,0 +1,120 @@
+package calendar
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	etagHeader        = "ETag"
+	ifMatchHeader     = "If-Match"
+	ifNoneMatchHeader = "If-None-Match"
+
+	mergePatchContentType = "application/merge-patch+json"
+)
+
+var errInvalidIfMatch = model.NewValidationError(ifMatchHeader, model.ErrInvalidVersion)
+
+// etag formats an event version as a strong entity tag.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// entityTag is one entity tag of an If-Match or If-None-Match list.
+type entityTag struct {
+	weak   bool
+	opaque string
+}
+
+// parseETags parses an If-Match or If-None-Match value (RFC 9110 §13.1): "*" or a comma-separated list
+// of quoted entity tags, each optionally marked weak with W/. It reports "*" as wildcard and a value that is
+// not such a list as !ok.
+func parseETags(value string) (tags []entityTag, wildcard bool, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "*" {
+		return nil, true, true
+	}
+
+	for len(value) > 0 {
+		value = strings.TrimLeft(value, " \t")
+		if strings.HasPrefix(value, ",") {
+			value = value[1:]
+			continue
+		}
+
+		var tag entityTag
+		if rest, found := strings.CutPrefix(value, "W/"); found {
+			tag.weak, value = true, rest
+		}
+		if !strings.HasPrefix(value, `"`) {
+			return nil, false, false
+		}
+		end := strings.IndexByte(value[1:], '"')
+		if end < 0 {
+			return nil, false, false
+		}
+		tag.opaque, value = value[1:end+1], strings.TrimLeft(value[end+2:], " \t")
+		if len(value) > 0 && value[0] != ',' {
+			return nil, false, false
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, false, len(tags) > 0
+}
+
+// ifNoneMatch reports whether the If-None-Match header matches an event at version.
+// It uses the weak comparison, so W/"3" and a list containing "3" match version 3, and "*" matches any event.
+// A malformed header is ignored.
+func ifNoneMatch(c *gin.Context, version int) bool {
+	tags, wildcard, ok := parseETags(c.GetHeader(ifNoneMatchHeader))
+	if !ok {
+		return false
+	}
+
+	opaque := strconv.Itoa(version)
+	return wildcard || slices.ContainsFunc(tags, func(tag entityTag) bool { return tag.opaque == opaque })
+}
+
+// ifMatchVersion returns the event version required by the If-Match header.
+// A missing header or "*" yields zero, which the service treats as "any version".
+// If-Match uses the strong comparison, so weak tags never match. A list of several tags is resolved
+// against the event's current version, and a list that does not contain it fails with a version conflict.
+func (i *Implementation) ifMatchVersion(c *gin.Context, eventID string, userID int) (int, error) {
+	value := c.GetHeader(ifMatchHeader)
+	if len(strings.TrimSpace(value)) == 0 {
+		return 0, nil
+	}
+
+	tags, wildcard, ok := parseETags(value)
+	if !ok {
+		return 0, errInvalidIfMatch
+	}
+	if wildcard {
+		return 0, nil
+	}
+
+	var versions []int
+	for _, tag := range tags {
+		if version, err := strconv.Atoi(tag.opaque); err == nil && version > 0 && !tag.weak {
+			versions = append(versions, version)
+		}
+	}
+	if len(tags) == 1 && len(versions) == 1 {
+		return versions[0], nil
+	}
+
+	current, err := i.calendarService.GetEvent(c.Request.Context(), eventID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !slices.Contains(versions, current.Version) {
+		return 0, &model.VersionConflictError{Current: current}
+	}
+
+	return current.Version, nil
+}
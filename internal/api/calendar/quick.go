@@ -0,0 +1,58 @@
+package calendar
+
+import (
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// PostQuickEvent handles POST /api/v1/events/quick, reading an event from free-form text. It answers 200
+// with the draft to confirm, or with create set creates the event and answers like PostEvent; a recurrence
+// in the text is then reported as a warning.
+func (i *Implementation) PostQuickEvent(c *gin.Context) {
+	var req dto.QuickEventRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	loc, err := converter.FromQuickEventReq(&req)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	draft, err := i.calendarService.ParseEvent(c.Request.Context(), req.UserID, req.Text, loc)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	if !req.Create {
+		resp := converter.ToEventDraftResp(draft)
+		resp.Warnings = i.eventWarnings(c.Request.Context(), draft.Event)
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	res, err := i.calendarService.CreateEvent(c.Request.Context(), draft.Event)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	resp := converter.ToEventResp(res)
+	resp.Warnings = i.eventWarnings(c.Request.Context(), res)
+	if draft.Recurrence != nil {
+		resp.Warnings = append(resp.Warnings, converter.ToWarningsResp([]*model.Warning{model.NewRecurrenceIgnoredWarning(draft.Recurrence)})...)
+	}
+
+	c.Header("Location", eventsPath+"/"+res.ID)
+	c.Header(etagHeader, etag(res.Version))
+	c.JSON(http.StatusCreated, resp)
+}
@@ -0,0 +1,235 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// eventsPath is the path of the event collection in the v1 API.
+const eventsPath = "/api/v1/events"
+
+// PostEvent handles POST /api/v1/events and answers 201 with the Location of the new event.
+// The event carries warnings such as its owner being out of office.
+func (i *Implementation) PostEvent(c *gin.Context) {
+	var req dto.CreateEventRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	event, err := converter.FromCreateEventReq(&req)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.CreateEvent(c.Request.Context(), event)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	resp := converter.ToEventResp(res)
+	resp.Warnings = i.eventWarnings(c.Request.Context(), res)
+
+	c.Header("Location", eventsPath+"/"+res.ID)
+	c.Header(etagHeader, etag(res.Version))
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetEvent handles GET /api/v1/events/:id. A matching If-None-Match answers 304.
+func (i *Implementation) GetEvent(c *gin.Context) {
+	eventID, userID, ok := eventParams(c)
+	if !ok {
+		return
+	}
+
+	res, err := i.calendarService.GetEvent(c.Request.Context(), eventID, userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header(etagHeader, etag(res.Version))
+	if ifNoneMatch(c, res.Version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToEventResp(res))
+}
+
+// PutEvent handles PUT /api/v1/events/:id, replacing every writable field of the event.
+func (i *Implementation) PutEvent(c *gin.Context) {
+	eventID, userID, ok := eventParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.EventFields
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	version, err := i.ifMatchVersion(c, eventID, userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	event, err := converter.FromEventFields(eventID, userID, &req)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+	event.Version = version
+
+	res, err := i.calendarService.UpdateEvent(c.Request.Context(), event)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header(etagHeader, etag(res.Version))
+	c.JSON(http.StatusOK, converter.ToEventResp(res))
+}
+
+// PatchEvent handles PATCH /api/v1/events/:id, changing only the supplied fields of the event.
+// The body is a JSON Merge Patch (RFC 7396) of the writable event fields; If-Match is honoured.
+func (i *Implementation) PatchEvent(c *gin.Context) {
+	eventID, userID, ok := eventParams(c)
+	if !ok {
+		return
+	}
+
+	switch c.ContentType() {
+	case mergePatchContentType, gin.MIMEJSON:
+	default:
+		problem.Write(c, fmt.Errorf("%w: expected %s", model.ErrUnsupported, mergePatchContentType))
+		return
+	}
+
+	version, err := i.ifMatchVersion(c, eventID, userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		problem.Write(c, fmt.Errorf("%w: %v", model.ErrMalformed, err))
+		return
+	}
+
+	res, err := i.calendarService.PatchEvent(c.Request.Context(), eventID, userID, version, func(event *model.Event) error {
+		return converter.ApplyEventMergePatch(event, patch)
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header(etagHeader, etag(res.Version))
+	c.JSON(http.StatusOK, converter.ToEventResp(res))
+}
+
+// DeleteEventByID handles DELETE /api/v1/events/:id and answers 204.
+func (i *Implementation) DeleteEventByID(c *gin.Context) {
+	eventID, userID, ok := eventParams(c)
+	if !ok {
+		return
+	}
+
+	version, err := i.ifMatchVersion(c, eventID, userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	err = i.calendarService.DeleteEvent(c.Request.Context(), eventID, userID, version)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListEvents handles GET /api/v1/events, returning the user's events between two dates inclusive.
+// The tags and exclude_tags parameters filter them by tag.
+func (i *Implementation) ListEvents(c *gin.Context) {
+	var q dto.RangeQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	userID, from, to, err := converter.FromRangeQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	filter, err := converter.FromTagQuery(&q.TagQuery)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	events, err := i.calendarService.GetEventsInRange(c.Request.Context(), userID, from, to)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToEventSegmentsResp(filter.Apply(events), from, to))
+}
+
+// SearchEvents handles GET /api/v1/events/search, finding the user's events by the words of q.
+// Hits are ranked by relevance and can be limited to a date range.
+func (i *Implementation) SearchEvents(c *gin.Context) {
+	var q dto.SearchQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	query, err := converter.FromSearchQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	hits, err := i.calendarService.SearchEvents(c.Request.Context(), query)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToSearchHitsResp(hits))
+}
+
+// eventParams binds the event ID from the path and the owner from the user_id query parameter.
+func eventParams(c *gin.Context) (string, int, bool) {
+	var uri dto.EventURI
+	if err := request.BindURI(c, &uri); err != nil {
+		problem.Write(c, err)
+		return "", 0, false
+	}
+
+	var q dto.UserQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return "", 0, false
+	}
+
+	return uri.ID, q.UserID, true
+}
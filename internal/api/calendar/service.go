@@ -1,12 +1,14 @@
 package calendar
 
 import (
+	"context"
 	"errors"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
 	"github.com/biryanim/wb_tech_calendar/internal/converter"
 	"github.com/biryanim/wb_tech_calendar/internal/model"
 	"github.com/biryanim/wb_tech_calendar/internal/service"
@@ -16,90 +18,104 @@ import (
 // Implementation represents the HTTP handler implementation for calendar event operations.
 type Implementation struct {
 	calendarService service.CalendarService
+	holidayService  service.HolidayService
 }
 
 // New creates a new instance of Implementation with the provided calendar service.
-func New(calendarService service.CalendarService) *Implementation {
-	return &Implementation{calendarService: calendarService}
-}
-
-// ErrorResponse represents the structure of error responses returned by the API.
-type ErrorResponse struct {
-	Error string `json:"error"`
+// Views are marked with the calendars of the holiday service.
+func New(calendarService service.CalendarService, holidayService service.HolidayService) *Implementation {
+	return &Implementation{calendarService: calendarService, holidayService: holidayService}
 }
 
 // CreateEvent handles POST requests to create a new calendar event.
 func (i *Implementation) CreateEvent(c *gin.Context) {
 	var req dto.CreateEventRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request" + err.Error()})
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
 		return
 	}
 
 	event, err := converter.FromCreateEventReq(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
 	res, err := i.calendarService.CreateEvent(c.Request.Context(), event)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"result": converter.ToEventResp(res)})
+	resp := converter.ToEventResp(res)
+	resp.Warnings = i.eventWarnings(c.Request.Context(), res)
+
+	c.Header(etagHeader, etag(res.Version))
+	c.JSON(http.StatusOK, gin.H{"result": resp})
 }
 
 // UpdateEvent handles POST requests to update an existing calendar event.
+// An If-Match header with the event's ETag makes the update fail with 412 if the event changed meanwhile.
 func (i *Implementation) UpdateEvent(c *gin.Context) {
 	var req dto.UpdateEventRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request" + err.Error()})
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	version, err := i.ifMatchVersion(c, string(req.ID), req.UserID)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
 	updateEvent, err := converter.FromUpdateEventReq(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		problem.Write(c, err)
 		return
 	}
+	updateEvent.Version = version
 
 	res, err := i.calendarService.UpdateEvent(c.Request.Context(), updateEvent)
 	if err != nil {
-		if errors.Is(err, model.ErrEventNotFound) {
-			c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		writeError(c, err)
 		return
 	}
 
+	c.Header(etagHeader, etag(res.Version))
 	c.JSON(http.StatusOK, gin.H{"result": converter.ToEventResp(res)})
 }
 
 // DeleteEvent handles POST requests to delete a calendar event.
+// Like UpdateEvent it honours If-Match.
 func (i *Implementation) DeleteEvent(c *gin.Context) {
 	var req dto.DeleteEventRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request" + err.Error()})
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
 		return
 	}
 
-	if req.ID <= 0 || req.UserID <= 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request"})
+	verr := &model.ValidationError{}
+	if !model.ValidEventID(string(req.ID)) {
+		verr.Add("id", model.ErrInvalidEventID)
+	}
+	if req.UserID <= 0 {
+		verr.Add("user_id", model.ErrInvalidUserID)
+	}
+	if err := verr.OrNil(); err != nil {
+		problem.Write(c, err)
 		return
 	}
 
-	err := i.calendarService.DeleteEvent(c.Request.Context(), req.ID, req.UserID)
+	version, err := i.ifMatchVersion(c, string(req.ID), req.UserID)
 	if err != nil {
-		if errors.Is(err, model.ErrEventNotFound) {
-			c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
-			return
-		}
+		writeError(c, err)
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	err = i.calendarService.DeleteEvent(c.Request.Context(), string(req.ID), req.UserID, version)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
@@ -108,96 +124,135 @@ func (i *Implementation) DeleteEvent(c *gin.Context) {
 
 // GetEventsForDay handles GET requests to retrieve all events for a specific day.
 func (i *Implementation) GetEventsForDay(c *gin.Context) {
-	userIDstr := c.Query("user_id")
-	if len(userIDstr) == 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id"})
+	i.getEventsForDate(c, i.calendarService.GetEventsForDay, model.DayRange)
+}
+
+// GetEventsForWeek handles GET requests to retrieve all events of the calendar week containing a date,
+// or of an ISO week. Weeks start on the week_start parameter or else on the first weekday of the user's settings.
+func (i *Implementation) GetEventsForWeek(c *gin.Context) {
+	var q dto.WeekQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
 		return
 	}
-	userID, err := strconv.Atoi(userIDstr)
+
+	userID, date, weekStart, err := converter.FromWeekQuery(&q)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id" + err.Error()})
+		problem.Write(c, err)
+		return
 	}
 
-	dateStr := c.Query("date")
-	if len(dateStr) == 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid date"})
+	filter, err := converter.FromTagQuery(&q.TagQuery)
+	if err != nil {
+		problem.Write(c, err)
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", dateStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid date" + err.Error()})
+	ctx := c.Request.Context()
+	if weekStart == nil {
+		settings, err := i.calendarService.GetSettings(ctx, userID)
+		if err != nil {
+			problem.Write(c, err)
+			return
+		}
+		weekStart = &settings.WeekStart
 	}
 
-	events, err := i.calendarService.GetEventsForDay(c.Request.Context(), userID, date)
+	from, to := model.WeekRange(date, *weekStart)
+	events, err := i.calendarService.GetEventsInRange(ctx, userID, from, to)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, converter.ToEventsResp(events))
+	c.JSON(http.StatusOK, converter.ToEventSegmentsResp(filter.Apply(events), from, to))
 }
 
-// GetEventsForWeek handles GET requests to retrieve all events for a specific week.
-func (i *Implementation) GetEventsForWeek(c *gin.Context) {
-	userIDstr := c.Query("user_id")
-	if len(userIDstr) == 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id"})
-		return
-	}
-	userID, err := strconv.Atoi(userIDstr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id" + err.Error()})
-	}
+// GetEventsForMonth handles GET requests to retrieve all events for a specific month.
+func (i *Implementation) GetEventsForMonth(c *gin.Context) {
+	i.getEventsForDate(c, i.calendarService.GetEventsForMonth, model.MonthRange)
+}
 
-	dateStr := c.Query("date")
-	if len(dateStr) == 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid date"})
+// Sync handles GET requests for the events changed since a sync token.
+// Without a token every event of the user is returned together with a token for the next call.
+func (i *Implementation) Sync(c *gin.Context) {
+	var q dto.SyncQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", dateStr)
+	since, err := converter.FromSyncToken(q.Token)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid date" + err.Error()})
+		problem.Write(c, err)
+		return
 	}
 
-	events, err := i.calendarService.GetEventsForWeek(c.Request.Context(), userID, date)
+	res, err := i.calendarService.Sync(c.Request.Context(), q.UserID, since)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, converter.ToEventsResp(events))
+	c.JSON(http.StatusOK, converter.ToSyncResp(res))
 }
 
-// GetEventsForMonth handles GET requests to retrieve all events for a specific month.
-func (i *Implementation) GetEventsForMonth(c *gin.Context) {
-	userIDstr := c.Query("user_id")
-	if len(userIDstr) == 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id"})
+type eventsForDateFunc func(ctx context.Context, userID int, date time.Time) ([]*model.Event, error)
+
+// periodRangeFunc returns the range of days fetch looks into for a date.
+type periodRangeFunc func(date time.Time) (time.Time, time.Time)
+
+// getEventsForDate binds a DateQuery and responds with the events fetch returns for it that pass its tag filters.
+// Events reaching out of the period returned by period are marked as continuation segments.
+func (i *Implementation) getEventsForDate(c *gin.Context, fetch eventsForDateFunc, period periodRangeFunc) {
+	var q dto.DateQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
 		return
 	}
-	userID, err := strconv.Atoi(userIDstr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id" + err.Error()})
-	}
 
-	dateStr := c.Query("date")
-	if len(dateStr) == 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid date"})
+	userID, date, err := converter.FromDateQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", dateStr)
+	filter, err := converter.FromTagQuery(&q.TagQuery)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid date" + err.Error()})
+		problem.Write(c, err)
+		return
 	}
 
-	events, err := i.calendarService.GetEventsForMonth(c.Request.Context(), userID, date)
+	events, err := fetch(c.Request.Context(), userID, date)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, converter.ToEventsResp(events))
+	from, to := period(date)
+	c.JSON(http.StatusOK, converter.ToEventSegmentsResp(filter.Apply(events), from, to))
+}
+
+// writeError writes the problem details for an error returned by the calendar service.
+// A version conflict additionally carries the current event and its ETag.
+func writeError(c *gin.Context, err error) {
+	var conflict *model.VersionConflictError
+	if errors.As(err, &conflict) {
+		c.Header(etagHeader, etag(conflict.Current.Version))
+	}
+
+	problem.Render(c, newProblem(err), err)
+}
+
+// newProblem builds the problem details for an error returned by the calendar service,
+// including the current event of a version conflict.
+func newProblem(err error) *problem.Problem {
+	p := problem.New(err)
+
+	var conflict *model.VersionConflictError
+	if errors.As(err, &conflict) {
+		p.Current = converter.ToEventResp(conflict.Current)
+	}
+
+	return p
 }
@@ -0,0 +1,71 @@
+package calendar
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSettings handles GET /api/v1/settings, returning the user's settings or the defaults.
+func (i *Implementation) GetSettings(c *gin.Context) {
+	var q dto.UserQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.GetSettings(c.Request.Context(), q.UserID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToSettingsResp(res))
+}
+
+// PutSettings handles PUT /api/v1/settings, replacing the user's settings.
+// A country must have a loaded holiday calendar.
+func (i *Implementation) PutSettings(c *gin.Context) {
+	var q dto.UserQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	var req dto.SettingsFields
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	settings, err := converter.FromSettingsFields(q.UserID, &req)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if len(settings.Country) > 0 && model.ValidCountry(settings.Country) {
+		if _, err = i.holidayService.GetCalendar(ctx, settings.Country); errors.Is(err, model.ErrHolidayCalendarNotFound) {
+			err = model.NewValidationError("country", model.ErrUnknownCountry)
+		}
+		if err != nil {
+			problem.Write(c, err)
+			return
+		}
+	}
+
+	res, err := i.calendarService.UpdateSettings(ctx, settings)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToSettingsResp(res))
+}
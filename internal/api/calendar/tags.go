@@ -0,0 +1,123 @@
+package calendar
+
+import (
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// ListTags handles GET /api/v1/tags, returning the user's tags ordered by name.
+func (i *Implementation) ListTags(c *gin.Context) {
+	var q dto.UserQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	tags, err := i.calendarService.GetTags(c.Request.Context(), q.UserID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTagsResp(tags))
+}
+
+// PostTag handles POST /api/v1/tags and answers 201.
+func (i *Implementation) PostTag(c *gin.Context) {
+	var req dto.CreateTagRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.CreateTag(c.Request.Context(), converter.FromCreateTagReq(&req))
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.ToTagResp(res))
+}
+
+// PutTag handles PUT /api/v1/tags/:name, replacing the tag. A new name is applied to every event carrying the tag.
+func (i *Implementation) PutTag(c *gin.Context) {
+	name, userID, ok := tagParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.TagFields
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.UpdateTag(c.Request.Context(), name, converter.FromTagFields(userID, &req))
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTagResp(res))
+}
+
+// DeleteTag handles DELETE /api/v1/tags/:name, removing the tag from the user's events, and answers 204.
+func (i *Implementation) DeleteTag(c *gin.Context) {
+	name, userID, ok := tagParams(c)
+	if !ok {
+		return
+	}
+
+	if err := i.calendarService.DeleteTag(c.Request.Context(), userID, name); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEventStats handles GET /api/v1/events/stats, counting the user's events per period, tag and category.
+func (i *Implementation) GetEventStats(c *gin.Context) {
+	var q dto.StatsQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	query, err := converter.FromStatsQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	stats, err := i.calendarService.GetTagStats(c.Request.Context(), query)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToPeriodStatsResp(stats))
+}
+
+// tagParams binds the tag name from the path and the owner from the user_id query parameter.
+func tagParams(c *gin.Context) (string, int, bool) {
+	var uri dto.TagURI
+	if err := request.BindURI(c, &uri); err != nil {
+		problem.Write(c, err)
+		return "", 0, false
+	}
+
+	var q dto.UserQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return "", 0, false
+	}
+
+	return model.NormalizeTag(uri.Name), q.UserID, true
+}
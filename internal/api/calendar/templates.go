@@ -0,0 +1,169 @@
+package calendar
+
+import (
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// ListTemplates handles GET /api/v1/templates, returning the user's event templates ordered by ID.
+func (i *Implementation) ListTemplates(c *gin.Context) {
+	var q dto.UserQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	templates, err := i.calendarService.GetTemplates(c.Request.Context(), q.UserID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTemplatesResp(templates))
+}
+
+// PostTemplate handles POST /api/v1/templates and answers 201.
+func (i *Implementation) PostTemplate(c *gin.Context) {
+	var req dto.CreateTemplateRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	template, err := converter.FromCreateTemplateReq(&req)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.CreateTemplate(c.Request.Context(), template)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.ToTemplateResp(res))
+}
+
+// GetTemplate handles GET /api/v1/templates/:id.
+func (i *Implementation) GetTemplate(c *gin.Context) {
+	templateID, userID, ok := templateParams(c)
+	if !ok {
+		return
+	}
+
+	res, err := i.calendarService.GetTemplate(c.Request.Context(), templateID, userID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTemplateResp(res))
+}
+
+// PutTemplate handles PUT /api/v1/templates/:id, replacing every writable field of the template.
+func (i *Implementation) PutTemplate(c *gin.Context) {
+	templateID, userID, ok := templateParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.TemplateFields
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	template, err := converter.FromTemplateFields(templateID, userID, &req)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.calendarService.UpdateTemplate(c.Request.Context(), template)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTemplateResp(res))
+}
+
+// DeleteTemplate handles DELETE /api/v1/templates/:id and answers 204.
+func (i *Implementation) DeleteTemplate(c *gin.Context) {
+	templateID, userID, ok := templateParams(c)
+	if !ok {
+		return
+	}
+
+	if err := i.calendarService.DeleteTemplate(c.Request.Context(), templateID, userID); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PostTemplateEvent handles POST /api/v1/templates/:id/events, creating an event from the template
+// at the requested start with the overrides applied. It answers like PostEvent.
+func (i *Implementation) PostTemplateEvent(c *gin.Context) {
+	templateID, userID, ok := templateParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateFromTemplateRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	start, err := converter.FromCreateFromTemplateReq(&req)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	var override func(event *model.Event) error
+	if len(req.Overrides) > 0 {
+		override = func(event *model.Event) error {
+			return converter.ApplyEventMergePatch(event, req.Overrides)
+		}
+	}
+
+	res, err := i.calendarService.CreateEventFromTemplate(c.Request.Context(), templateID, userID, start, override)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	resp := converter.ToEventResp(res)
+	resp.Warnings = i.eventWarnings(c.Request.Context(), res)
+
+	c.Header("Location", eventsPath+"/"+res.ID)
+	c.Header(etagHeader, etag(res.Version))
+	c.JSON(http.StatusCreated, resp)
+}
+
+// templateParams binds the template ID from the path and the owner from the user_id query parameter.
+func templateParams(c *gin.Context) (int, int, bool) {
+	var uri dto.TemplateURI
+	if err := request.BindURI(c, &uri); err != nil {
+		problem.Write(c, err)
+		return 0, 0, false
+	}
+
+	var q dto.UserQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return 0, 0, false
+	}
+
+	return uri.ID, q.UserID, true
+}
@@ -0,0 +1,75 @@
+package gql
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+const internalCode = "internal_error"
+
+// resolverError is the GraphQL counterpart of the HTTP problem details: the message of a domain error
+// and its stable code in the extensions. Other errors are reported as internal without their message.
+type resolverError struct {
+	message    string
+	extensions map[string]any
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+// Extensions implements gqlerrors.ExtendedError.
+func (e *resolverError) Extensions() map[string]any {
+	return e.extensions
+}
+
+// toError converts an error returned by the calendar service or a converter into a resolver error.
+// Field names are reported in the camelCase of the schema.
+func toError(err error) error {
+	domainErr, ok := model.AsError(err)
+	if !ok {
+		log.Printf("graphql: %v", err)
+		return &resolverError{
+			message:    "internal server error",
+			extensions: map[string]any{"code": internalCode},
+		}
+	}
+
+	extensions := map[string]any{"code": domainErr.Code}
+
+	var verr *model.ValidationError
+	if errors.As(err, &verr) {
+		extensions["code"] = "validation_failed"
+		fields := make([]map[string]any, 0, len(verr.Fields))
+		for _, f := range verr.Fields {
+			fields = append(fields, map[string]any{
+				"field":  camelCase(f.Field),
+				"code":   f.Err.Code,
+				"detail": f.Err.Message,
+			})
+		}
+		extensions["errors"] = fields
+	}
+
+	var conflict *model.VersionConflictError
+	if errors.As(err, &conflict) {
+		extensions["currentVersion"] = conflict.Current.Version
+	}
+
+	return &resolverError{message: model.PublicMessage(err), extensions: extensions}
+}
+
+// camelCase turns a snake_case request field name into its GraphQL argument name.
+func camelCase(field string) string {
+	parts := strings.Split(field, "_")
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) > 0 {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,59 @@
+package gql
+
+import (
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+)
+
+// Path is the route of the GraphQL endpoint.
+const Path = "/graphql"
+
+// Request represents a GraphQL request sent as a JSON body.
+type Request struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Implementation represents the HTTP handler serving the GraphQL schema.
+type Implementation struct {
+	calendarService service.CalendarService
+	schema          graphql.Schema
+}
+
+// New creates a new instance of Implementation with the schema built over the provided calendar service.
+// Free/busy days are marked with the calendars of the holiday service.
+func New(calendarService service.CalendarService, holidayService service.HolidayService) (*Implementation, error) {
+	schema, err := NewSchema(calendarService, holidayService)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Implementation{calendarService: calendarService, schema: schema}, nil
+}
+
+// Query handles POST requests executing a GraphQL query or mutation.
+// Errors inside the operation are reported in the GraphQL errors list with a 200 status.
+func (i *Implementation) Query(c *gin.Context) {
+	var req Request
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	ctx := withLoader(c.Request.Context(), newEventsLoader(i.calendarService))
+	res := graphql.Do(graphql.Params{
+		Schema:         i.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        ctx,
+	})
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,107 @@
+package gql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+)
+
+type loaderKey struct{}
+
+// rangeKey identifies the range argument shared by the event lookups of a batch.
+type rangeKey struct {
+	from, to int64
+}
+
+type rangeBatch struct {
+	from, to time.Time
+	pending  map[int]struct{}
+	loaded   map[int][]*model.Event
+	// err is the error of the last fetch; it is reported to every user of that fetch.
+	err error
+}
+
+// eventsLoader batches the per-user range lookups of one GraphQL request.
+// Resolvers register the users they need and return thunks; the executor resolves every sibling field
+// before calling the first thunk, which then fetches all pending users of its range in a single call.
+type eventsLoader struct {
+	mu              sync.Mutex
+	calendarService service.CalendarService
+	batches         map[rangeKey]*rangeBatch
+}
+
+func newEventsLoader(calendarService service.CalendarService) *eventsLoader {
+	return &eventsLoader{
+		calendarService: calendarService,
+		batches:         make(map[rangeKey]*rangeBatch),
+	}
+}
+
+func withLoader(ctx context.Context, l *eventsLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func loaderFrom(ctx context.Context) *eventsLoader {
+	return ctx.Value(loaderKey{}).(*eventsLoader)
+}
+
+// load registers the user for the next batch of [from, to) and returns a thunk yielding its events.
+func (l *eventsLoader) load(ctx context.Context, userID int, from, to time.Time) func() ([]*model.Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := rangeKey{from: from.UnixNano(), to: to.UnixNano()}
+	batch, ok := l.batches[key]
+	if !ok {
+		batch = &rangeBatch{
+			from:    from,
+			to:      to,
+			pending: make(map[int]struct{}),
+			loaded:  make(map[int][]*model.Event),
+		}
+		l.batches[key] = batch
+	}
+	if _, ok = batch.loaded[userID]; !ok {
+		batch.pending[userID] = struct{}{}
+	}
+
+	return func() ([]*model.Event, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.dispatch(ctx, batch)
+
+		events, ok := batch.loaded[userID]
+		if !ok {
+			return nil, batch.err
+		}
+
+		return events, nil
+	}
+}
+
+// dispatch fetches every pending user of the batch at once.
+func (l *eventsLoader) dispatch(ctx context.Context, batch *rangeBatch) {
+	if len(batch.pending) == 0 {
+		return
+	}
+
+	userIDs := make([]int, 0, len(batch.pending))
+	for userID := range batch.pending {
+		userIDs = append(userIDs, userID)
+	}
+	clear(batch.pending)
+
+	events, err := l.calendarService.GetEventsInRangeForUsers(ctx, userIDs, batch.from, batch.to)
+	batch.err = err
+	if err != nil {
+		return
+	}
+
+	for _, userID := range userIDs {
+		batch.loaded[userID] = events[userID]
+	}
+}
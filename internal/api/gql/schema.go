@@ -0,0 +1,658 @@
+package gql
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/graphql-go/graphql"
+)
+
+// userCalendar is the source object of the Calendar type: the calendar of one user as seen by viewerID.
+// Zero viewerID is an unknown viewer, who sees the calendar like any user other than its owner.
+type userCalendar struct {
+	userID   int
+	viewerID int
+}
+
+// createdEvent is the source object of the Event returned by createEvent, which also carries the warnings about it.
+type createdEvent struct {
+	*model.Event
+	warnings []*model.Warning
+}
+
+// freeBusyDay is the source object of the FreeBusyDay type.
+type freeBusyDay struct {
+	Date        string          `json:"date"`
+	Busy        bool            `json:"busy"`
+	EventCount  int             `json:"eventCount"`
+	Holiday     bool            `json:"holiday"`
+	HolidayName *string         `json:"holidayName"`
+	Workday     bool            `json:"workday"`
+	OutOfOffice bool            `json:"outOfOffice"`
+	Free        []*dto.Interval `json:"free"`
+}
+
+var eventType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Event",
+	Fields: graphql.Fields{
+		"id": eventField(graphql.ID, func(e *model.Event) any { return e.ID }),
+		"legacyId": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Int),
+			Description: "The integer ID the event had before ULIDs, accepted as its id until clients have moved to ULIDs.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return sourceEvent(p).LegacyID, nil
+			},
+		},
+		"userId": eventField(graphql.Int, func(e *model.Event) any { return e.UserID }),
+		"date":   eventField(graphql.String, func(e *model.Event) any { return e.Date.Format(time.RFC3339) }),
+		"end": &graphql.Field{
+			Type:        graphql.String,
+			Description: "The exclusive end; for all-day events the day after the last one.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				if end := sourceEvent(p).End; !end.IsZero() {
+					return end.Format(time.RFC3339), nil
+				}
+				return nil, nil
+			},
+		},
+		"allDay":  eventField(graphql.Boolean, func(e *model.Event) any { return e.AllDay }),
+		"title":   eventField(graphql.String, func(e *model.Event) any { return e.Title }),
+		"version": eventField(graphql.Int, func(e *model.Event) any { return e.Version }),
+		"tags": eventField(graphql.NewList(graphql.NewNonNull(graphql.String)), func(e *model.Event) any {
+			if e.Tags == nil {
+				return []string{}
+			}
+			return e.Tags
+		}),
+		"description":  eventField(graphql.String, func(e *model.Event) any { return e.Description }),
+		"location":     eventField(graphql.String, func(e *model.Event) any { return e.Location }),
+		"url":          eventField(graphql.String, func(e *model.Event) any { return e.URL }),
+		"status":       eventField(graphql.String, func(e *model.Event) any { return e.Status }),
+		"transparency": eventField(graphql.String, func(e *model.Event) any { return e.Transparency }),
+		"visibility":   eventField(graphql.String, func(e *model.Event) any { return e.Visibility }),
+		"warnings": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(warningType))),
+			Description: "Problems that did not stop the event from being created, such as an out-of-office period " +
+				"of its owner it takes up. Only createEvent sets them, as the HTTP API does on create responses.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				if created, ok := p.Source.(*createdEvent); ok && created.warnings != nil {
+					return created.warnings, nil
+				}
+				return []*model.Warning{}, nil
+			},
+		},
+	},
+})
+
+var warningType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Warning",
+	Description: "An advisory about a request that did not stop it.",
+	Fields: graphql.Fields{
+		"code": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.Warning).Code, nil
+			},
+		},
+		"detail": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.Warning).Message, nil
+			},
+		},
+	},
+})
+
+var intervalType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Interval",
+	Description: "The time from start to the exclusive end, as RFC 3339 date-times.",
+	Fields: graphql.Fields{
+		"start": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"end":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var freeBusyDayType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "FreeBusyDay",
+	Description: "Whether the user has events on a day, whether the day is worked and when the user is free.",
+	Fields: graphql.Fields{
+		"date": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"busy": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "The day has events taking up time, or none of it is within the user's working hours.",
+		},
+		"eventCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"holiday": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "A day off in the holiday calendar.",
+		},
+		"holidayName": &graphql.Field{
+			Type:        graphql.String,
+			Description: "The holiday calendar entry of the day, also set for a weekend day that is worked.",
+		},
+		"workday": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"outOfOffice": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "The user is out of office for some of the day.",
+		},
+		"free": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(intervalType))),
+			Description: "The time of the day within the user's working hours, out of office and of events taking up time excluded.",
+		},
+	},
+})
+
+// rangeArgs are the arguments of every range query. Both days are inclusive, formatted as YYYY-MM-DD
+// and interpreted in the optional IANA time zone tz, UTC by default. Events are kept if they have
+// any of tags, or tags is omitted, and none of excludeTags.
+var rangeArgs = graphql.FieldConfigArgument{
+	"from":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+	"to":          &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+	"tz":          &graphql.ArgumentConfig{Type: graphql.String},
+	"tags":        &graphql.ArgumentConfig{Type: tagListType},
+	"excludeTags": &graphql.ArgumentConfig{Type: tagListType},
+}
+
+var tagListType = graphql.NewList(graphql.NewNonNull(graphql.String))
+
+// freeBusyArgs are rangeArgs and the country of the holiday calendar to mark, the one of the user's
+// settings by default.
+var freeBusyArgs = withArgs(graphql.FieldConfigArgument{
+	"holidays": &graphql.ArgumentConfig{Type: graphql.String},
+}, rangeArgs)
+
+// detailArgs are the optional fields of the event mutations besides date, title and tags.
+var detailArgs = graphql.FieldConfigArgument{
+	"end":          &graphql.ArgumentConfig{Type: graphql.String},
+	"allDay":       &graphql.ArgumentConfig{Type: graphql.Boolean},
+	"description":  &graphql.ArgumentConfig{Type: graphql.String},
+	"location":     &graphql.ArgumentConfig{Type: graphql.String},
+	"url":          &graphql.ArgumentConfig{Type: graphql.String},
+	"status":       &graphql.ArgumentConfig{Type: graphql.String},
+	"transparency": &graphql.ArgumentConfig{Type: graphql.String},
+	"visibility":   &graphql.ArgumentConfig{Type: graphql.String},
+}
+
+func eventField(typ graphql.Output, value func(e *model.Event) any) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(typ),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return value(sourceEvent(p)), nil
+		},
+	}
+}
+
+// sourceEvent returns the event an Event field is resolved on.
+func sourceEvent(p graphql.ResolveParams) *model.Event {
+	if created, ok := p.Source.(*createdEvent); ok {
+		return created.Event
+	}
+
+	return p.Source.(*model.Event)
+}
+
+// NewSchema builds the GraphQL schema over the calendar service and the holiday calendars.
+func NewSchema(calendarService service.CalendarService, holidayService service.HolidayService) (graphql.Schema, error) {
+	r := &resolver{calendarService: calendarService, holidayService: holidayService}
+
+	calendarType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Calendar",
+		Description: "The calendar of one user.",
+		Fields: graphql.Fields{
+			"userId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*userCalendar).userID, nil
+				},
+			},
+			"events": &graphql.Field{
+				Type:    eventListType(),
+				Args:    rangeArgs,
+				Resolve: r.calendarEvents,
+			},
+			"freeBusy": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(freeBusyDayType))),
+				Description: "Availability of every day of the range, which may span at most 1000 days.",
+				Args:        freeBusyArgs,
+				Resolve:     r.calendarFreeBusy,
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"event": &graphql.Field{
+				Type: eventType,
+				Args: graphql.FieldConfigArgument{
+					"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"userId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: r.event,
+			},
+			"events": &graphql.Field{
+				Type:    eventListType(),
+				Args:    withUserID(rangeArgs),
+				Resolve: r.events,
+			},
+			"calendar": &graphql.Field{
+				Type:        graphql.NewNonNull(calendarType),
+				Description: "The calendar of userId. Unless viewerId is userId, private and confidential events show only what they share.",
+				Args: graphql.FieldConfigArgument{
+					"userId":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"viewerId": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: r.calendar,
+			},
+			"calendars": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(calendarType))),
+				Description: "The calendars of userIds as seen by viewerId, like calendar.",
+				Args: graphql.FieldConfigArgument{
+					"userIds": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int))),
+					},
+					"viewerId": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: r.calendars,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createEvent": &graphql.Field{
+				Type: graphql.NewNonNull(eventType),
+				Args: withDetails(graphql.FieldConfigArgument{
+					"userId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"date":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"title":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"tags":   &graphql.ArgumentConfig{Type: tagListType},
+				}),
+				Resolve: r.createEvent,
+			},
+			"updateEvent": &graphql.Field{
+				Type:        graphql.NewNonNull(eventType),
+				Description: "Changes the given fields of an event. A version fails the update if the event has changed since.",
+				Args: withDetails(graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"userId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"date":    &graphql.ArgumentConfig{Type: graphql.String},
+					"title":   &graphql.ArgumentConfig{Type: graphql.String},
+					"tags":    &graphql.ArgumentConfig{Type: tagListType},
+					"version": &graphql.ArgumentConfig{Type: graphql.Int},
+				}),
+				Resolve: r.updateEvent,
+			},
+			"deleteEvent": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"userId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"version": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: r.deleteEvent,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func eventListType() graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(eventType)))
+}
+
+func withUserID(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	result := graphql.FieldConfigArgument{
+		"userId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+	}
+	for name, arg := range args {
+		result[name] = arg
+	}
+
+	return result
+}
+
+func withDetails(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	return withArgs(args, detailArgs)
+}
+
+// withArgs adds the arguments of extra to args.
+func withArgs(args, extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	for name, arg := range extra {
+		args[name] = arg
+	}
+
+	return args
+}
+
+type resolver struct {
+	calendarService service.CalendarService
+	holidayService  service.HolidayService
+}
+
+func (r *resolver) event(p graphql.ResolveParams) (any, error) {
+	eventID, userID, err := eventIDs(p.Args)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	event, err := r.calendarService.GetEvent(p.Context, eventID, userID)
+	if err != nil {
+		if errors.Is(err, model.ErrEventNotFound) {
+			return nil, nil
+		}
+		return nil, toError(err)
+	}
+
+	return event, nil
+}
+
+func (r *resolver) events(p graphql.ResolveParams) (any, error) {
+	userID, err := userIDArg(p.Args)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	return r.loadRange(p, userID, userID)
+}
+
+func (r *resolver) calendar(p graphql.ResolveParams) (any, error) {
+	userID, err := userIDArg(p.Args)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	viewerID, _ := p.Args["viewerId"].(int)
+	return &userCalendar{userID: userID, viewerID: viewerID}, nil
+}
+
+func (r *resolver) calendars(p graphql.ResolveParams) (any, error) {
+	ids := p.Args["userIds"].([]any)
+	viewerID, _ := p.Args["viewerId"].(int)
+	result := make([]*userCalendar, 0, len(ids))
+	for _, id := range ids {
+		userID := id.(int)
+		if userID <= 0 {
+			return nil, toError(model.NewValidationError("user_ids", model.ErrInvalidUserID))
+		}
+		result = append(result, &userCalendar{userID: userID, viewerID: viewerID})
+	}
+
+	return result, nil
+}
+
+func (r *resolver) calendarEvents(p graphql.ResolveParams) (any, error) {
+	cal := p.Source.(*userCalendar)
+	return r.loadRange(p, cal.userID, cal.viewerID)
+}
+
+func (r *resolver) calendarFreeBusy(p graphql.ResolveParams) (any, error) {
+	q := rangeQuery(p.Args, p.Source.(*userCalendar).userID)
+	_, from, to, err := converter.FromRangeQuery(q)
+	if err != nil {
+		return nil, toError(err)
+	}
+	if err = model.CheckRangeDays(from, to); err != nil {
+		return nil, toError(err)
+	}
+	filter, err := converter.FromTagQuery(&q.TagQuery)
+	if err != nil {
+		return nil, toError(err)
+	}
+	viewerID := p.Source.(*userCalendar).viewerID
+	country, _ := p.Args["holidays"].(string)
+	holidays, err := r.holidayCalendar(p, q.UserID, strings.ToLower(country))
+	if err != nil {
+		return nil, toError(err)
+	}
+	settings, err := r.calendarService.GetSettings(p.Context, q.UserID)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	load := loaderFrom(p.Context).load(p.Context, q.UserID, from, to)
+	return func() (any, error) {
+		events, err := load()
+		if err != nil {
+			return nil, toError(err)
+		}
+		events = filter.Apply(visibleTo(events, q.UserID, viewerID))
+
+		var days []*freeBusyDay
+		for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+			next := day.AddDate(0, 0, 1)
+			working := settings.WorkingTime(day, next)
+			count := 0
+			var taken []model.Interval
+			for _, event := range events {
+				if event.Blocks() && event.Overlaps(day, next) {
+					count++
+					start, end := event.Span(day.Location())
+					taken = append(taken, model.Interval{Start: start, End: end})
+				}
+			}
+			fb := &freeBusyDay{
+				Date:        day.Format(time.DateOnly),
+				Busy:        count > 0 || len(working) == 0,
+				EventCount:  count,
+				Workday:     holidays.IsWorkday(day),
+				OutOfOffice: len(settings.OutOfOfficeDuring(day, next)) > 0,
+				Free:        converter.ToIntervalsResp(model.SubtractIntervals(working, taken), day.Location()),
+			}
+			if holiday, ok := holidays.Holiday(day); ok {
+				fb.Holiday = holiday.Kind == model.HolidayDayOff
+				fb.HolidayName = &holiday.Name
+			}
+			days = append(days, fb)
+		}
+
+		return days, nil
+	}, nil
+}
+
+// holidayCalendar returns the holiday calendar of country if it is set and the one of the user's settings
+// otherwise. It returns nil if neither names a loaded calendar.
+func (r *resolver) holidayCalendar(p graphql.ResolveParams, userID int, country string) (*model.HolidayCalendar, error) {
+	if len(country) > 0 {
+		cal, err := r.holidayService.GetCalendar(p.Context, country)
+		if errors.Is(err, model.ErrHolidayCalendarNotFound) {
+			return nil, model.NewValidationError("holidays", model.ErrUnknownCountry)
+		}
+		return cal, err
+	}
+
+	settings, err := r.calendarService.GetSettings(p.Context, userID)
+	if err != nil || len(settings.Country) == 0 {
+		return nil, err
+	}
+
+	cal, err := r.holidayService.GetCalendar(p.Context, settings.Country)
+	if errors.Is(err, model.ErrHolidayCalendarNotFound) {
+		return nil, nil
+	}
+	return cal, err
+}
+
+// loadRange resolves the events of a user in the range arguments through the request's loader,
+// as viewerID sees them. Tag filters apply to what the viewer sees, so hidden tags cannot be probed.
+func (r *resolver) loadRange(p graphql.ResolveParams, userID, viewerID int) (any, error) {
+	q := rangeQuery(p.Args, userID)
+	_, from, to, err := converter.FromRangeQuery(q)
+	if err != nil {
+		return nil, toError(err)
+	}
+	filter, err := converter.FromTagQuery(&q.TagQuery)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	load := loaderFrom(p.Context).load(p.Context, userID, from, to)
+	return func() (any, error) {
+		events, err := load()
+		if err != nil {
+			return nil, toError(err)
+		}
+
+		return filter.Apply(visibleTo(events, userID, viewerID)), nil
+	}, nil
+}
+
+// visibleTo returns the events of userID as viewerID sees them: in full for the owner and redacted
+// to what their visibility shares for anyone else, including an unknown viewer.
+func visibleTo(events []*model.Event, userID, viewerID int) []*model.Event {
+	if viewerID == userID {
+		return events
+	}
+
+	redacted := make([]*model.Event, 0, len(events))
+	for _, event := range events {
+		redacted = append(redacted, event.Redacted())
+	}
+
+	return redacted
+}
+
+func (r *resolver) createEvent(p graphql.ResolveParams) (any, error) {
+	req := &dto.CreateEventRequest{
+		UserID: p.Args["userId"].(int),
+		Date:   p.Args["date"].(string),
+		Title:  p.Args["title"].(string),
+		Tags:   stringsArg(p.Args, "tags"),
+	}
+	req.End, _ = p.Args["end"].(string)
+	req.AllDay, _ = p.Args["allDay"].(bool)
+	req.Description, _ = p.Args["description"].(string)
+	req.Location, _ = p.Args["location"].(string)
+	req.URL, _ = p.Args["url"].(string)
+	req.Status, _ = p.Args["status"].(string)
+	req.Transparency, _ = p.Args["transparency"].(string)
+	req.Visibility, _ = p.Args["visibility"].(string)
+
+	event, err := converter.FromCreateEventReq(req)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	res, err := r.calendarService.CreateEvent(p.Context, event)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	// Warnings are advisory, so failing to work them out does not fail the mutation.
+	warnings, _ := r.calendarService.CheckEvent(p.Context, res)
+	return &createdEvent{Event: res, warnings: warnings}, nil
+}
+
+func (r *resolver) updateEvent(p graphql.ResolveParams) (any, error) {
+	eventID, userID, err := eventIDs(p.Args)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	version, _ := p.Args["version"].(int)
+	res, err := r.calendarService.PatchEvent(p.Context, eventID, userID, version, func(event *model.Event) error {
+		fields := converter.ToEventFields(event)
+		for name, field := range map[string]*string{
+			"date":         &fields.Date,
+			"end":          &fields.End,
+			"title":        &fields.Title,
+			"description":  &fields.Description,
+			"location":     &fields.Location,
+			"url":          &fields.URL,
+			"status":       &fields.Status,
+			"transparency": &fields.Transparency,
+			"visibility":   &fields.Visibility,
+		} {
+			if value, ok := p.Args[name].(string); ok {
+				*field = value
+			}
+		}
+		if allDay, ok := p.Args["allDay"].(bool); ok {
+			fields.AllDay = allDay
+		}
+		if _, ok := p.Args["tags"]; ok {
+			fields.Tags = stringsArg(p.Args, "tags")
+		}
+
+		return converter.ApplyEventFields(event, fields)
+	})
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	return res, nil
+}
+
+func (r *resolver) deleteEvent(p graphql.ResolveParams) (any, error) {
+	eventID, userID, err := eventIDs(p.Args)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	version, _ := p.Args["version"].(int)
+	if err = r.calendarService.DeleteEvent(p.Context, eventID, userID, version); err != nil {
+		return nil, toError(err)
+	}
+
+	return true, nil
+}
+
+func rangeQuery(args map[string]any, userID int) *dto.RangeQuery {
+	q := &dto.RangeQuery{
+		UserQuery: dto.UserQuery{UserID: userID},
+		From:      args["from"].(string),
+		To:        args["to"].(string),
+	}
+	q.TimeZone, _ = args["tz"].(string)
+	q.Tags = strings.Join(stringsArg(args, "tags"), ",")
+	q.ExcludeTags = strings.Join(stringsArg(args, "excludeTags"), ",")
+
+	return q
+}
+
+// stringsArg returns the list argument name, nil if it is omitted.
+func stringsArg(args map[string]any, name string) []string {
+	values, _ := args[name].([]any)
+	if values == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.(string))
+	}
+
+	return result
+}
+
+func userIDArg(args map[string]any) (int, error) {
+	userID := args["userId"].(int)
+	if userID <= 0 {
+		return 0, model.NewValidationError("user_id", model.ErrInvalidUserID)
+	}
+
+	return userID, nil
+}
+
+// eventIDs reads the event and owner IDs of an operation on a single event. The event ID is a ULID
+// or a legacy integer ID, which the ID scalar takes as a string or an integer.
+func eventIDs(args map[string]any) (string, int, error) {
+	eventID, userID := args["id"].(string), args["userId"].(int)
+
+	verr := &model.ValidationError{}
+	if !model.ValidEventID(eventID) {
+		verr.Add("id", model.ErrInvalidEventID)
+	}
+	if userID <= 0 {
+		verr.Add("user_id", model.ErrInvalidUserID)
+	}
+
+	return eventID, userID, verr.OrNil()
+}
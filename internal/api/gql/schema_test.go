@@ -0,0 +1,444 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/biryanim/wb_tech_calendar/internal/service/calendar"
+	"github.com/biryanim/wb_tech_calendar/internal/service/clock"
+	"github.com/biryanim/wb_tech_calendar/internal/service/holiday"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingService counts the range lookups that reach the calendar service.
+type countingService struct {
+	service.CalendarService
+	batches int
+}
+
+func (s *countingService) GetEventsInRangeForUsers(ctx context.Context, userIDs []int, from, to time.Time) (map[int][]*model.Event, error) {
+	s.batches++
+	return s.CalendarService.GetEventsInRangeForUsers(ctx, userIDs, from, to)
+}
+
+func newService(t *testing.T) *countingService {
+	t.Helper()
+
+	s := &countingService{CalendarService: calendar.New(clock.New())}
+	for userID := 1; userID <= 3; userID++ {
+		for _, date := range []string{"2025-10-01", "2025-10-03", "2025-11-01"} {
+			day, err := time.Parse(time.DateOnly, date)
+			require.NoError(t, err)
+			_, err = s.CreateEvent(context.Background(), &model.Event{UserID: userID, Date: day, Title: date})
+			require.NoError(t, err)
+		}
+	}
+
+	return s
+}
+
+// newHolidays loads the sample holiday calendars of the holiday service.
+func newHolidays(t *testing.T) service.HolidayService {
+	t.Helper()
+
+	s, err := holiday.New(&config.HolidayConfig{Dir: "../../service/holiday/testdata"})
+	require.NoError(t, err)
+
+	return s
+}
+
+func execute(t *testing.T, s service.CalendarService, query string) *graphql.Result {
+	t.Helper()
+
+	schema, err := NewSchema(s, newHolidays(t))
+	require.NoError(t, err)
+
+	return graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		Context:       withLoader(context.Background(), newEventsLoader(s)),
+	})
+}
+
+func decode(t *testing.T, res *graphql.Result, out any) {
+	t.Helper()
+
+	require.Empty(t, res.Errors)
+	data, err := json.Marshal(res.Data)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, out))
+}
+
+func TestCalendars_BatchesLookups(t *testing.T) {
+	s := newService(t)
+
+	res := execute(t, s, `{
+		calendars(userIds: [1, 2, 3]) {
+			userId
+			events(from: "2025-10-01", to: "2025-10-31") { title }
+			freeBusy(from: "2025-10-01", to: "2025-10-31") { date busy eventCount }
+		}
+	}`)
+
+	var data struct {
+		Calendars []struct {
+			UserID int `json:"userId"`
+			Events []struct {
+				Title string `json:"title"`
+			} `json:"events"`
+			FreeBusy []freeBusyDay `json:"freeBusy"`
+		} `json:"calendars"`
+	}
+	decode(t, res, &data)
+
+	assert.Equal(t, 1, s.batches)
+	require.Len(t, data.Calendars, 3)
+	for _, cal := range data.Calendars {
+		assert.Len(t, cal.Events, 2)
+		require.Len(t, cal.FreeBusy, 31)
+		assert.Equal(t, freeBusyDay{Date: "2025-10-01", Busy: true, EventCount: 1}, cal.FreeBusy[0])
+		assert.Equal(t, freeBusyDay{Date: "2025-10-02"}, cal.FreeBusy[1])
+	}
+}
+
+func TestEvents(t *testing.T) {
+	s := newService(t)
+
+	res := execute(t, s, `{
+		october: events(userId: 1, from: "2025-10-01", to: "2025-10-31") { title }
+		november: events(userId: 1, from: "2025-11-01", to: "2025-11-30", tz: "Europe/Moscow") { title }
+		event(id: 1, userId: 1) { id legacyId title version }
+		missing: event(id: 1, userId: 2) { id }
+	}`)
+
+	var data struct {
+		October  []map[string]any `json:"october"`
+		November []map[string]any `json:"november"`
+		Event    map[string]any   `json:"event"`
+		Missing  map[string]any   `json:"missing"`
+	}
+	decode(t, res, &data)
+
+	assert.Len(t, data.October, 2)
+	assert.Len(t, data.November, 1)
+	assert.Regexp(t, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`, data.Event["id"])
+	assert.Equal(t, 1.0, data.Event["legacyId"])
+	assert.Equal(t, "2025-10-01", data.Event["title"])
+	assert.Nil(t, data.Missing)
+	assert.Equal(t, 2, s.batches)
+}
+
+func TestMutations(t *testing.T) {
+	s := newService(t)
+
+	res := execute(t, s, `mutation {
+		created: createEvent(userId: 7, date: "2025-12-01", title: "standup") { id legacyId version }
+		updated: updateEvent(id: 10, userId: 7, title: "retro", version: 1) { title date version }
+	}`)
+
+	var data struct {
+		Created map[string]any `json:"created"`
+		Updated map[string]any `json:"updated"`
+	}
+	decode(t, res, &data)
+
+	assert.Equal(t, 10.0, data.Created["legacyId"])
+	assert.Equal(t, 1.0, data.Created["version"])
+	assert.Equal(t, map[string]any{"title": "retro", "date": "2025-12-01T00:00:00Z", "version": 2.0}, data.Updated)
+
+	res = execute(t, s, `mutation { deleteEvent(id: 10, userId: 7, version: 1) }`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "version_conflict", res.Errors[0].Extensions["code"])
+	assert.Equal(t, 2, res.Errors[0].Extensions["currentVersion"])
+
+	res = execute(t, s, fmt.Sprintf(`mutation { deleteEvent(id: %q, userId: 7, version: 2) }`, data.Created["id"]))
+	assert.Empty(t, res.Errors)
+}
+
+func TestCreateEventWarnings(t *testing.T) {
+	s := newService(t)
+	_, err := s.UpdateSettings(context.Background(), &model.UserSettings{
+		UserID:    7,
+		WeekStart: time.Monday,
+		OutOfOffice: []model.OutOfOffice{{
+			Start: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC),
+		}},
+	})
+	require.NoError(t, err)
+
+	res := execute(t, s, `mutation {
+		away: createEvent(userId: 7, date: "2025-12-01T10:00:00Z", title: "standup") { title warnings { code detail } }
+		back: createEvent(userId: 7, date: "2025-12-02T10:00:00Z", title: "retro") { warnings { code } }
+	}`)
+
+	var data struct {
+		Away struct {
+			Title    string              `json:"title"`
+			Warnings []map[string]string `json:"warnings"`
+		} `json:"away"`
+		Back struct {
+			Warnings []map[string]string `json:"warnings"`
+		} `json:"back"`
+	}
+	decode(t, res, &data)
+
+	assert.Equal(t, "standup", data.Away.Title)
+	require.Len(t, data.Away.Warnings, 1)
+	assert.Equal(t, model.WarningOutOfOffice, data.Away.Warnings[0]["code"])
+	assert.NotEmpty(t, data.Away.Warnings[0]["detail"])
+	assert.Equal(t, []map[string]string{}, data.Back.Warnings)
+}
+
+func TestValidationErrors(t *testing.T) {
+	s := newService(t)
+
+	res := execute(t, s, `mutation { createEvent(userId: 0, date: "2025-12-01", title: "") { id } }`)
+	require.Len(t, res.Errors, 1)
+	ext := res.Errors[0].Extensions
+	assert.Equal(t, "validation_failed", ext["code"])
+
+	var fields []string
+	for _, f := range ext["errors"].([]map[string]any) {
+		fields = append(fields, f["field"].(string))
+	}
+	assert.ElementsMatch(t, []string{"userId", "title"}, fields)
+
+	res = execute(t, s, `{ events(userId: 1, from: "2025-10-31", to: "2025-10-01") { id } }`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "validation_failed", res.Errors[0].Extensions["code"])
+
+	res = execute(t, s, `{ event(id: "standup", userId: 1) { id } }`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "validation_failed", res.Errors[0].Extensions["code"])
+}
+
+func TestTags(t *testing.T) {
+	s := newService(t)
+
+	res := execute(t, s, `mutation {
+		created: createEvent(userId: 7, date: "2025-12-01", title: "standup", tags: ["Work", "daily"]) { tags }
+		updated: updateEvent(id: 10, userId: 7, title: "retro") { title tags }
+	}`)
+
+	var data struct {
+		Created map[string]any `json:"created"`
+		Updated map[string]any `json:"updated"`
+	}
+	decode(t, res, &data)
+
+	assert.Equal(t, []any{"daily", "work"}, data.Created["tags"])
+	assert.Equal(t, []any{"daily", "work"}, data.Updated["tags"])
+
+	res = execute(t, s, `{
+		work: events(userId: 7, from: "2025-12-01", to: "2025-12-31", tags: ["work"]) { id }
+		other: events(userId: 7, from: "2025-12-01", to: "2025-12-31", excludeTags: ["daily"]) { id }
+		untagged: event(id: 1, userId: 1) { tags }
+	}`)
+
+	var filtered struct {
+		Work     []map[string]any `json:"work"`
+		Other    []map[string]any `json:"other"`
+		Untagged map[string]any   `json:"untagged"`
+	}
+	decode(t, res, &filtered)
+
+	assert.Len(t, filtered.Work, 1)
+	assert.Empty(t, filtered.Other)
+	assert.Equal(t, []any{}, filtered.Untagged["tags"])
+}
+
+func TestSharedCalendar(t *testing.T) {
+	s := newService(t)
+
+	res := execute(t, s, `mutation {
+		private: createEvent(userId: 7, date: "2025-12-01", title: "doctor", location: "clinic", visibility: "private", tags: ["health"]) { id }
+		free: createEvent(userId: 7, date: "2025-12-02", title: "focus", transparency: "free", visibility: "confidential", location: "home") { id }
+	}`)
+	require.Empty(t, res.Errors)
+
+	query := `query ($viewer: Int, $tags: [String!]) {
+		calendar(userId: 7, viewerId: $viewer) {
+			events(from: "2025-12-01", to: "2025-12-02", tags: $tags) { title location visibility }
+			freeBusy(from: "2025-12-01", to: "2025-12-02", tags: $tags) { busy eventCount }
+		}
+	}`
+
+	var data struct {
+		Calendar struct {
+			Events   []map[string]any `json:"events"`
+			FreeBusy []freeBusyDay    `json:"freeBusy"`
+		} `json:"calendar"`
+	}
+
+	schema, err := NewSchema(s, newHolidays(t))
+	require.NoError(t, err)
+	do := func(variables map[string]any) {
+		t.Helper()
+		decode(t, graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: variables,
+			Context:        withLoader(context.Background(), newEventsLoader(s)),
+		}), &data)
+	}
+
+	do(map[string]any{"viewer": 7})
+	assert.Equal(t, []map[string]any{
+		{"title": "doctor", "location": "clinic", "visibility": "private"},
+		{"title": "focus", "location": "home", "visibility": "confidential"},
+	}, data.Calendar.Events)
+	assert.Equal(t, []freeBusyDay{{Busy: true, EventCount: 1}, {Busy: false}}, data.Calendar.FreeBusy)
+
+	do(map[string]any{"viewer": 7, "tags": []any{"health"}})
+	assert.Len(t, data.Calendar.Events, 1)
+	assert.Equal(t, 1, data.Calendar.FreeBusy[0].EventCount)
+
+	// Other users and callers who do not say who they are see the same redacted calendar.
+	for _, variables := range []map[string]any{{"viewer": 8}, {}} {
+		do(variables)
+		assert.Equal(t, []map[string]any{
+			{"title": "", "location": "", "visibility": "private"},
+			{"title": "focus", "location": "", "visibility": "confidential"},
+		}, data.Calendar.Events)
+		assert.Equal(t, 1, data.Calendar.FreeBusy[0].EventCount)
+
+		// Hidden tags cannot be probed with a tag filter.
+		variables["tags"] = []any{"health"}
+		do(variables)
+		assert.Empty(t, data.Calendar.Events)
+		assert.Equal(t, 0, data.Calendar.FreeBusy[0].EventCount)
+	}
+}
+
+func TestFreeBusy_RangeTooLarge(t *testing.T) {
+	res := execute(t, newService(t), `{
+		calendar(userId: 7) { freeBusy(from: "2000-01-01", to: "2030-12-31") { busy } }
+	}`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "validation_failed", res.Errors[0].Extensions["code"])
+	assert.Contains(t, res.Errors[0].Extensions["errors"], map[string]any{
+		"field": "to", "code": "range_too_large", "detail": model.ErrRangeTooLarge.Message,
+	})
+}
+
+func TestFreeBusy_MultiDay(t *testing.T) {
+	s := newService(t)
+
+	res := execute(t, s, `mutation {
+		createEvent(userId: 7, date: "2025-12-01", end: "2025-12-03", allDay: true, title: "offsite") { end allDay }
+	}`)
+	var created struct {
+		CreateEvent map[string]any `json:"createEvent"`
+	}
+	decode(t, res, &created)
+	assert.Equal(t, map[string]any{"end": "2025-12-03T00:00:00Z", "allDay": true}, created.CreateEvent)
+
+	res = execute(t, s, `{
+		calendar(userId: 7) { freeBusy(from: "2025-12-01", to: "2025-12-03", tz: "America/New_York") { busy } }
+	}`)
+	var data struct {
+		Calendar struct {
+			FreeBusy []freeBusyDay `json:"freeBusy"`
+		} `json:"calendar"`
+	}
+	decode(t, res, &data)
+	assert.Equal(t, []freeBusyDay{{Busy: true}, {Busy: true}, {Busy: false}}, data.Calendar.FreeBusy)
+}
+
+func TestFreeBusy_Holidays(t *testing.T) {
+	s := newService(t)
+	_, err := s.UpdateSettings(context.Background(), &model.UserSettings{UserID: 9, WeekStart: time.Monday, Country: "ru"})
+	require.NoError(t, err)
+
+	holidayName := func(name string) *string { return &name }
+	query := func(args string) *graphql.Result {
+		return execute(t, s, `{
+			calendar(userId: 9) { freeBusy(from: "2025-10-31", to: "2025-11-04"`+args+`) { date holiday holidayName workday } }
+		}`)
+	}
+	freeBusy := func(res *graphql.Result) []freeBusyDay {
+		var data struct {
+			Calendar struct {
+				FreeBusy []freeBusyDay `json:"freeBusy"`
+			} `json:"calendar"`
+		}
+		decode(t, res, &data)
+		return data.Calendar.FreeBusy
+	}
+
+	assert.Equal(t, []freeBusyDay{
+		{Date: "2025-10-31", Workday: true},
+		{Date: "2025-11-01", HolidayName: holidayName("Рабочая суббота, перенос с 3 ноября"), Workday: true},
+		{Date: "2025-11-02", Holiday: true, HolidayName: holidayName("День народного единства")},
+		{Date: "2025-11-03", Holiday: true, HolidayName: holidayName("День народного единства")},
+		{Date: "2025-11-04", Holiday: true, HolidayName: holidayName("День народного единства")},
+	}, freeBusy(query("")))
+
+	res := query(`, holidays: "DE"`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "validation_failed", res.Errors[0].Extensions["code"])
+}
+
+func TestFreeBusy_WorkingHours(t *testing.T) {
+	s := newService(t)
+	ctx := context.Background()
+	_, err := s.UpdateSettings(ctx, &model.UserSettings{
+		UserID:       10,
+		WeekStart:    time.Monday,
+		TimeZone:     "Europe/Moscow",
+		WorkingHours: []model.WorkingHours{{Weekday: time.Monday, Start: 9 * time.Hour, End: 18 * time.Hour}},
+		OutOfOffice: []model.OutOfOffice{{
+			Start: time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2026, 10, 27, 0, 0, 0, 0, time.UTC),
+		}},
+	})
+	require.NoError(t, err)
+	_, err = s.CreateEvent(ctx, &model.Event{
+		UserID: 10,
+		Title:  "review",
+		Date:   time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+		End:    time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	res := execute(t, s, `{
+		calendar(userId: 10) {
+			freeBusy(from: "2026-10-19", to: "2026-10-20", tz: "Europe/Moscow") { date busy outOfOffice free { start end } }
+		}
+	}`)
+	var data struct {
+		Calendar struct {
+			FreeBusy []freeBusyDay `json:"freeBusy"`
+		} `json:"calendar"`
+	}
+	decode(t, res, &data)
+	assert.Equal(t, []freeBusyDay{
+		{Date: "2026-10-19", Busy: true, Free: []*dto.Interval{
+			{Start: "2026-10-19T09:00:00+03:00", End: "2026-10-19T11:00:00+03:00"},
+			{Start: "2026-10-19T12:00:00+03:00", End: "2026-10-19T18:00:00+03:00"},
+		}},
+		// Nobody works on Tuesday.
+		{Date: "2026-10-20", Busy: true, Free: []*dto.Interval{}},
+	}, data.Calendar.FreeBusy)
+
+	res = execute(t, s, `{
+		calendar(userId: 10) { freeBusy(from: "2026-10-26", to: "2026-10-26") { busy outOfOffice free { start } } }
+	}`)
+	var away struct {
+		Calendar struct {
+			FreeBusy []freeBusyDay `json:"freeBusy"`
+		} `json:"calendar"`
+	}
+	decode(t, res, &away)
+	assert.Equal(t, []freeBusyDay{{Busy: true, OutOfOffice: true, Free: []*dto.Interval{}}}, away.Calendar.FreeBusy)
+}
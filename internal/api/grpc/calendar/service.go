@@ -0,0 +1,249 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	calendarv1 "github.com/biryanim/wb_tech_calendar/pkg/pb/calendar/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// errSubscriberFellBehind ends a change feed whose client could not keep up; it may resume from its last message.
+var errSubscriberFellBehind = status.Error(codes.Unavailable, "subscriber fell behind")
+
+// Implementation represents the gRPC handler implementation of CalendarService.
+// Handlers return domain errors; the error interceptor turns them into statuses.
+type Implementation struct {
+	calendarv1.UnimplementedCalendarServiceServer
+
+	calendarService service.CalendarService
+	streamService   service.StreamService
+}
+
+// New creates a new instance of Implementation backed by the same services as the HTTP API.
+func New(calendarService service.CalendarService, streamService service.StreamService) *Implementation {
+	return &Implementation{
+		calendarService: calendarService,
+		streamService:   streamService,
+	}
+}
+
+// CreateEvent creates a new calendar event. Like the HTTP API it returns the warnings about the event with it.
+func (i *Implementation) CreateEvent(ctx context.Context, req *calendarv1.CreateEventRequest) (*calendarv1.Event, error) {
+	event, err := converter.FromCreateEventPb(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.calendarService.CreateEvent(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := converter.ToEventPb(res)
+	// Warnings are advisory, so failing to work them out does not fail the call.
+	if warnings, err := i.calendarService.CheckEvent(ctx, res); err == nil {
+		resp.Warnings = converter.ToWarningsPb(warnings)
+	}
+
+	return resp, nil
+}
+
+// GetEvent returns a single event owned by the user.
+func (i *Implementation) GetEvent(ctx context.Context, req *calendarv1.GetEventRequest) (*calendarv1.Event, error) {
+	eventID, userID, err := converter.FromEventIDsPb(req.GetId(), req.GetLegacyId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.calendarService.GetEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToEventPb(res), nil
+}
+
+// UpdateEvent replaces every writable field of an event.
+func (i *Implementation) UpdateEvent(ctx context.Context, req *calendarv1.UpdateEventRequest) (*calendarv1.Event, error) {
+	event, err := converter.FromUpdateEventPb(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.calendarService.UpdateEvent(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToEventPb(res), nil
+}
+
+// PatchEvent changes the fields of an event listed in the update mask.
+func (i *Implementation) PatchEvent(ctx context.Context, req *calendarv1.PatchEventRequest) (*calendarv1.Event, error) {
+	eventID, userID, err := converter.FromEventIDsPb(req.GetId(), req.GetLegacyId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.calendarService.PatchEvent(ctx, eventID, userID, int(req.GetVersion()), func(event *model.Event) error {
+		return converter.ApplyEventFieldMask(event, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToEventPb(res), nil
+}
+
+// DeleteEvent removes a calendar event.
+func (i *Implementation) DeleteEvent(ctx context.Context, req *calendarv1.DeleteEventRequest) (*emptypb.Empty, error) {
+	eventID, userID, err := converter.FromEventIDsPb(req.GetId(), req.GetLegacyId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.calendarService.DeleteEvent(ctx, eventID, userID, int(req.GetVersion()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListEventsForDay streams the events of a day.
+func (i *Implementation) ListEventsForDay(req *calendarv1.DateRequest, stream grpc.ServerStreamingServer[calendarv1.Event]) error {
+	return i.listEventsForDate(req, stream, i.calendarService.GetEventsForDay, model.DayRange)
+}
+
+// ListEventsForWeek streams the events of the calendar week containing a date.
+// Weeks start on the first weekday of the user's settings.
+func (i *Implementation) ListEventsForWeek(req *calendarv1.DateRequest, stream grpc.ServerStreamingServer[calendarv1.Event]) error {
+	settings, err := i.calendarService.GetSettings(stream.Context(), int(req.GetUserId()))
+	if err != nil {
+		return err
+	}
+
+	return i.listEventsForDate(req, stream, i.calendarService.GetEventsForWeek, func(date time.Time) (time.Time, time.Time) {
+		return model.WeekRange(date, settings.WeekStart)
+	})
+}
+
+// ListEventsForMonth streams the events of the month containing a date.
+func (i *Implementation) ListEventsForMonth(req *calendarv1.DateRequest, stream grpc.ServerStreamingServer[calendarv1.Event]) error {
+	return i.listEventsForDate(req, stream, i.calendarService.GetEventsForMonth, model.MonthRange)
+}
+
+// ListEventsInRange streams the events between two days inclusive.
+func (i *Implementation) ListEventsInRange(req *calendarv1.RangeRequest, stream grpc.ServerStreamingServer[calendarv1.Event]) error {
+	userID, from, to, err := converter.FromRangeRequestPb(req)
+	if err != nil {
+		return err
+	}
+
+	filter, err := converter.FromTagFilterPb(req.GetTags(), req.GetExcludeTags())
+	if err != nil {
+		return err
+	}
+
+	events, err := i.calendarService.GetEventsInRange(stream.Context(), userID, from, to)
+	if err != nil {
+		return err
+	}
+
+	return sendEvents(stream, filter.Apply(events), from, to)
+}
+
+// Sync returns the events changed since a sync token.
+func (i *Implementation) Sync(ctx context.Context, req *calendarv1.SyncRequest) (*calendarv1.SyncResponse, error) {
+	userID, err := converter.FromUserIDPb(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	since, err := converter.FromSyncToken(req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.calendarService.Sync(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToSyncPb(res), nil
+}
+
+// WatchChanges streams the changes of a user's events until the client goes away.
+// A client that falls too far behind gets the stream closed and resumes from its last message ID.
+func (i *Implementation) WatchChanges(req *calendarv1.WatchChangesRequest, stream grpc.ServerStreamingServer[calendarv1.ChangeMessage]) error {
+	userID, err := converter.FromUserIDPb(req.GetUserId())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	messages := i.streamService.Subscribe(ctx, userID, req.GetLastId())
+	// Send the headers right away so clients know the subscription is live before the first change.
+	if err = stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return errSubscriberFellBehind
+			}
+
+			if err = stream.Send(converter.ToChangeMessagePb(&msg)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+type eventsForDateFunc func(ctx context.Context, userID int, date time.Time) ([]*model.Event, error)
+
+// periodRangeFunc returns the range of days fetch looks into for a date.
+type periodRangeFunc func(date time.Time) (time.Time, time.Time)
+
+func (i *Implementation) listEventsForDate(req *calendarv1.DateRequest, stream grpc.ServerStreamingServer[calendarv1.Event], fetch eventsForDateFunc, period periodRangeFunc) error {
+	userID, date, err := converter.FromDateRequestPb(req)
+	if err != nil {
+		return err
+	}
+
+	filter, err := converter.FromTagFilterPb(req.GetTags(), req.GetExcludeTags())
+	if err != nil {
+		return err
+	}
+
+	events, err := fetch(stream.Context(), userID, date)
+	if err != nil {
+		return err
+	}
+
+	from, to := period(date)
+	return sendEvents(stream, filter.Apply(events), from, to)
+}
+
+// sendEvents streams the events of the range [from, to), marking those reaching out of it as continuation segments.
+func sendEvents(stream grpc.ServerStreamingServer[calendarv1.Event], events []*model.Event, from, to time.Time) error {
+	for _, event := range events {
+		if err := stream.Send(converter.ToEventSegmentPb(event, from, to)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+	bearerPrefix     = "Bearer "
+)
+
+var errUnauthenticated = status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+
+// AuthUnaryInterceptor rejects unary calls without an "authorization: Bearer <token>" metadata entry
+// holding one of tokens.
+func AuthUnaryInterceptor(tokens []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !authorized(ctx, tokens) {
+			return nil, errUnauthenticated
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor is the streaming counterpart of AuthUnaryInterceptor.
+func AuthStreamInterceptor(tokens []string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !authorized(ss.Context(), tokens) {
+			return errUnauthenticated
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func authorized(ctx context.Context, tokens []string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, value := range md.Get(authorizationKey) {
+		token, ok := strings.CutPrefix(value, bearerPrefix)
+		if !ok {
+			continue
+		}
+
+		for _, allowed := range tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(allowed)) == 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the ErrorInfo domain of every domain error.
+const errorDomain = "wb-calendar"
+
+var kindCodes = map[model.ErrorKind]codes.Code{
+	model.KindInvalid:       codes.InvalidArgument,
+	model.KindNotFound:      codes.NotFound,
+	model.KindConflict:      codes.AlreadyExists,
+	model.KindPrecondition:  codes.Aborted,
+	model.KindGone:          codes.FailedPrecondition,
+	model.KindUnsupported:   codes.InvalidArgument,
+	model.KindUnprocessable: codes.FailedPrecondition,
+}
+
+// ErrorsUnaryInterceptor converts the domain errors returned by unary handlers into gRPC statuses.
+func ErrorsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, Status(info.FullMethod, err).Err()
+		}
+
+		return resp, nil
+	}
+}
+
+// ErrorsStreamInterceptor converts the domain errors returned by streaming handlers into gRPC statuses.
+func ErrorsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			return Status(info.FullMethod, err).Err()
+		}
+
+		return nil
+	}
+}
+
+// Status builds the gRPC status for an error, mirroring the HTTP problem details.
+// Domain errors carry an ErrorInfo with their stable code and validation errors a BadRequest with every field;
+// other errors are reported as internal without exposing their message.
+func Status(method string, err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	domainErr, ok := model.AsError(err)
+	if !ok {
+		return internal(method, err)
+	}
+
+	code, ok := kindCodes[domainErr.Kind]
+	if !ok {
+		return internal(method, err)
+	}
+
+	info := &errdetails.ErrorInfo{Reason: domainErr.Code, Domain: errorDomain}
+	var conflict *model.VersionConflictError
+	if errors.As(err, &conflict) {
+		info.Metadata = map[string]string{"current_version": strconv.Itoa(conflict.Current.Version)}
+	}
+
+	details := []protoadapt.MessageV1{info}
+	var verr *model.ValidationError
+	if errors.As(err, &verr) {
+		info.Reason = "validation_failed"
+		badRequest := &errdetails.BadRequest{}
+		for _, f := range verr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Reason:      f.Err.Code,
+				Description: f.Err.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	st := status.New(code, model.PublicMessage(err))
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+
+	return st
+}
+
+func internal(method string, err error) *status.Status {
+	log.Printf("%s: %v", method, err)
+	return status.New(codes.Internal, "internal server error")
+}
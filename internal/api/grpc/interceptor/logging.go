@@ -0,0 +1,49 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// LoggingUnaryInterceptor logs every unary call with its status code and duration.
+func LoggingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		logCall(ctx, info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// LoggingStreamInterceptor logs every streaming call once it ends.
+func LoggingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		logCall(ss.Context(), info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+func logCall(ctx context.Context, method string, err error, duration time.Duration) {
+	addr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	fmt.Printf("[%s]\tgRPC\t%s\t%s = Code: %s - Duration: %v\n",
+		time.Now().Format("2006-01-02 15:04:05"),
+		method,
+		addr,
+		status.Code(err),
+		duration)
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"log"
+
+	grpcCalendar "github.com/biryanim/wb_tech_calendar/internal/api/grpc/calendar"
+	"github.com/biryanim/wb_tech_calendar/internal/api/grpc/interceptor"
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	calendarv1 "github.com/biryanim/wb_tech_calendar/pkg/pb/calendar/v1"
+	"google.golang.org/grpc"
+)
+
+// New creates the gRPC server with CalendarService registered.
+// Calls are logged, then authenticated, and domain errors are converted to statuses closest to the handler.
+func New(cfg *config.GRPCConfig, calendarService service.CalendarService, streamService service.StreamService) *grpc.Server {
+	unary := []grpc.UnaryServerInterceptor{interceptor.LoggingUnaryInterceptor()}
+	stream := []grpc.StreamServerInterceptor{interceptor.LoggingStreamInterceptor()}
+
+	if len(cfg.AuthTokens) > 0 {
+		unary = append(unary, interceptor.AuthUnaryInterceptor(cfg.AuthTokens))
+		stream = append(stream, interceptor.AuthStreamInterceptor(cfg.AuthTokens))
+	} else {
+		log.Printf("gRPC authentication is disabled: GRPC_AUTH_TOKENS is not set")
+	}
+
+	unary = append(unary, interceptor.ErrorsUnaryInterceptor())
+	stream = append(stream, interceptor.ErrorsStreamInterceptor())
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	calendarv1.RegisterCalendarServiceServer(srv, grpcCalendar.New(calendarService, streamService))
+
+	return srv
+}
@@ -0,0 +1,251 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/biryanim/wb_tech_calendar/internal/service/calendar"
+	"github.com/biryanim/wb_tech_calendar/internal/service/clock"
+	"github.com/biryanim/wb_tech_calendar/internal/service/stream"
+	calendarv1 "github.com/biryanim/wb_tech_calendar/pkg/pb/calendar/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const token = "secret"
+
+func newClient(t *testing.T) calendarv1.CalendarServiceClient {
+	t.Helper()
+
+	client, _ := newServer(t)
+	return client
+}
+
+// newServer serves a new calendar and returns a client of the server together with the calendar.
+func newServer(t *testing.T) (calendarv1.CalendarServiceClient, service.CalendarService) {
+	t.Helper()
+
+	streamService := stream.New()
+	calendarService := calendar.New(clock.New(), streamService)
+	srv := New(&config.GRPCConfig{AuthTokens: []string{token}}, calendarService, streamService)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return calendarv1.NewCalendarServiceClient(conn), calendarService
+}
+
+func authContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestAuth(t *testing.T) {
+	client := newClient(t)
+	req := &calendarv1.GetEventRequest{LegacyId: 1, UserId: 1}
+
+	_, err := client.GetEvent(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong")
+	_, err = client.GetEvent(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	events, err := client.ListEventsForDay(context.Background(), &calendarv1.DateRequest{UserId: 1, Date: "2025-10-01"})
+	require.NoError(t, err)
+	_, err = events.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.GetEvent(authContext(t), req)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestEventLifecycle(t *testing.T) {
+	client := newClient(t)
+	ctx := authContext(t)
+
+	created, err := client.CreateEvent(ctx, &calendarv1.CreateEventRequest{UserId: 1, Date: "2025-10-01", Title: "standup"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), created.GetLegacyId())
+	assert.Equal(t, int64(1), created.GetVersion())
+
+	got, err := client.GetEvent(ctx, &calendarv1.GetEventRequest{Id: created.GetId(), UserId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "standup", got.GetTitle())
+
+	_, err = client.GetEvent(ctx, &calendarv1.GetEventRequest{Id: created.GetId(), UserId: 2})
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, model.ErrEventNotFound.Message, st.Message())
+
+	updated, err := client.UpdateEvent(ctx, &calendarv1.UpdateEventRequest{
+		Id: created.GetId(), UserId: 1, Date: "2025-10-02", Title: "retro", Version: created.GetVersion(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.GetVersion())
+
+	patched, err := client.PatchEvent(ctx, &calendarv1.PatchEventRequest{
+		LegacyId: created.GetLegacyId(), UserId: 1, Title: "demo", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "demo", patched.GetTitle())
+	assert.Equal(t, updated.GetDate().AsTime(), patched.GetDate().AsTime())
+
+	_, err = client.DeleteEvent(ctx, &calendarv1.DeleteEventRequest{Id: created.GetId(), UserId: 1, Version: created.GetVersion()})
+	st = status.Convert(err)
+	require.Equal(t, codes.Aborted, st.Code())
+	info := detail[*errdetails.ErrorInfo](t, st)
+	assert.Equal(t, "version_conflict", info.GetReason())
+	assert.Equal(t, "3", info.GetMetadata()["current_version"])
+
+	_, err = client.DeleteEvent(ctx, &calendarv1.DeleteEventRequest{Id: created.GetId(), UserId: 1, Version: patched.GetVersion()})
+	require.NoError(t, err)
+
+	_, err = client.GetEvent(ctx, &calendarv1.GetEventRequest{Id: created.GetId(), UserId: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCreateEventWarnings(t *testing.T) {
+	client, calendarService := newServer(t)
+	ctx := authContext(t)
+
+	_, err := calendarService.UpdateSettings(ctx, &model.UserSettings{
+		UserID:    1,
+		WeekStart: time.Monday,
+		OutOfOffice: []model.OutOfOffice{{
+			Start: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC),
+			Note:  "vacation",
+		}},
+	})
+	require.NoError(t, err)
+
+	created, err := client.CreateEvent(ctx, &calendarv1.CreateEventRequest{UserId: 1, Date: "2025-10-01", Title: "standup"})
+	require.NoError(t, err)
+	require.Len(t, created.GetWarnings(), 1)
+	assert.Equal(t, model.WarningOutOfOffice, created.GetWarnings()[0].GetCode())
+	assert.Contains(t, created.GetWarnings()[0].GetDetail(), "vacation")
+
+	got, err := client.GetEvent(ctx, &calendarv1.GetEventRequest{Id: created.GetId(), UserId: 1})
+	require.NoError(t, err)
+	assert.Empty(t, got.GetWarnings())
+}
+
+func TestValidation(t *testing.T) {
+	client := newClient(t)
+
+	_, err := client.CreateEvent(authContext(t), &calendarv1.CreateEventRequest{Date: "2025-10-01"})
+	st := status.Convert(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	badRequest := detail[*errdetails.BadRequest](t, st)
+	var fields []string
+	for _, v := range badRequest.GetFieldViolations() {
+		fields = append(fields, v.GetField())
+	}
+	assert.ElementsMatch(t, []string{"user_id", "title"}, fields)
+
+	stream, err := client.ListEventsInRange(authContext(t), &calendarv1.RangeRequest{UserId: 1, From: "2025-10-02", To: "2025-10-01"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListEventsInRange(t *testing.T) {
+	client := newClient(t)
+	ctx := authContext(t)
+
+	for _, date := range []string{"2025-10-01", "2025-10-15", "2025-11-01"} {
+		_, err := client.CreateEvent(ctx, &calendarv1.CreateEventRequest{UserId: 1, Date: date, Title: date})
+		require.NoError(t, err)
+	}
+
+	stream, err := client.ListEventsInRange(ctx, &calendarv1.RangeRequest{UserId: 1, From: "2025-10-01", To: "2025-10-31"})
+	require.NoError(t, err)
+
+	var titles []string
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		titles = append(titles, event.GetTitle())
+	}
+	assert.ElementsMatch(t, []string{"2025-10-01", "2025-10-15"}, titles)
+}
+
+func TestSyncAndWatchChanges(t *testing.T) {
+	client := newClient(t)
+	ctx := authContext(t)
+
+	full, err := client.Sync(ctx, &calendarv1.SyncRequest{UserId: 1})
+	require.NoError(t, err)
+	assert.Empty(t, full.GetEvents())
+
+	watch, err := client.WatchChanges(ctx, &calendarv1.WatchChangesRequest{UserId: 1})
+	require.NoError(t, err)
+
+	// The server sends the headers once the subscription is registered.
+	_, err = watch.Header()
+	require.NoError(t, err)
+
+	_, err = client.CreateEvent(ctx, &calendarv1.CreateEventRequest{UserId: 1, Date: "2025-10-01", Title: "standup"})
+	require.NoError(t, err)
+
+	msg, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, calendarv1.ChangeType_CHANGE_TYPE_CREATED, msg.GetType())
+	assert.Equal(t, "standup", msg.GetEvent().GetTitle())
+
+	delta, err := client.Sync(ctx, &calendarv1.SyncRequest{UserId: 1, Token: full.GetToken()})
+	require.NoError(t, err)
+	assert.NotEmpty(t, delta.GetEvents())
+
+	_, err = client.Sync(ctx, &calendarv1.SyncRequest{UserId: 1, Token: "bogus"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func detail[T any](t *testing.T, st *status.Status) T {
+	t.Helper()
+
+	for _, d := range st.Details() {
+		if v, ok := d.(T); ok {
+			return v
+		}
+	}
+
+	var zero T
+	require.FailNow(t, "status detail not found", "%T in %v", zero, st.Details())
+	return zero
+}
@@ -0,0 +1,37 @@
+package dto
+
+// Calendar represents a loaded holiday calendar in API responses.
+type Calendar struct {
+	Country string `json:"country"`
+	Years   []int  `json:"years"`
+}
+
+// Holiday represents a day of a holiday calendar in API responses.
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// Workday represents the result of moving a date by working days.
+type Workday struct {
+	Date string `json:"date"`
+}
+
+// CountryURI represents the path parameters addressing a holiday calendar.
+type CountryURI struct {
+	Country string `uri:"country" binding:"required"`
+}
+
+// RangeQuery represents the query parameters of the days of a holiday calendar. Both ends are inclusive.
+type RangeQuery struct {
+	From string `form:"from" binding:"required,date"`
+	To   string `form:"to" binding:"required,date,gtedate=From"`
+}
+
+// WorkdaysQuery represents the query parameters moving a date by a number of working days;
+// a negative number moves it back.
+type WorkdaysQuery struct {
+	Date string `form:"date" binding:"required,date"`
+	Days int    `form:"days" binding:"min=-1000,max=1000"`
+}
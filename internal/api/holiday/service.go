@@ -0,0 +1,97 @@
+package holiday
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/holiday/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Implementation represents the HTTP handler implementation for the read-only holiday calendars.
+type Implementation struct {
+	holidayService service.HolidayService
+}
+
+// New creates a new instance of Implementation with the provided holiday service.
+func New(holidayService service.HolidayService) *Implementation {
+	return &Implementation{holidayService: holidayService}
+}
+
+// ListCalendars handles GET /api/v1/holidays, returning the loaded calendars ordered by country.
+func (i *Implementation) ListCalendars(c *gin.Context) {
+	calendars, err := i.holidayService.GetCalendars(c.Request.Context())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToHolidayCalendarsResp(calendars))
+}
+
+// ListHolidays handles GET /api/v1/holidays/:country, returning the holidays and worked weekend days
+// of a range in date order.
+func (i *Implementation) ListHolidays(c *gin.Context) {
+	cal, ok := i.calendar(c)
+	if !ok {
+		return
+	}
+
+	var q dto.RangeQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	from, to, err := converter.FromHolidayRangeQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToHolidaysResp(cal.Holidays(from, to)))
+}
+
+// AddWorkdays handles GET /api/v1/holidays/:country/workdays, moving a date by a number of working days.
+func (i *Implementation) AddWorkdays(c *gin.Context) {
+	cal, ok := i.calendar(c)
+	if !ok {
+		return
+	}
+
+	var q dto.WorkdaysQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	date, days, err := converter.FromWorkdaysQuery(&q)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToWorkdayResp(cal.AddWorkdays(date, days)))
+}
+
+// calendar binds the country from the path and looks up its calendar.
+func (i *Implementation) calendar(c *gin.Context) (*model.HolidayCalendar, bool) {
+	var uri dto.CountryURI
+	if err := request.BindURI(c, &uri); err != nil {
+		problem.Write(c, err)
+		return nil, false
+	}
+
+	cal, err := i.holidayService.GetCalendar(c.Request.Context(), strings.ToLower(uri.Country))
+	if err != nil {
+		problem.Write(c, err)
+		return nil, false
+	}
+
+	return cal, true
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeprecatedMiddleware returns a Gin middleware handler that marks a route as deprecated.
+// It sets the Deprecation and Link headers pointing to the successor route and logs every call.
+func DeprecatedMiddleware(successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+
+		log.Printf("deprecated route %s %s called by %s, use %s",
+			c.Request.Method, c.FullPath(), c.ClientIP(), successor)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the idempotency key of a write.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed for a retried request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// responseRecorder copies everything a handler writes so that it can be stored.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware returns a Gin middleware handler that makes write requests with an
+// Idempotency-Key header safe to retry. The first response to a key is stored and replayed for
+// every retry with the same method, URL and body; a different request reusing the key answers 422
+// and a retry arriving while the first request is still running answers 409.
+// Keys are scoped to the method, path and users of the request, so clients acting for different
+// users never see each other's responses. Client errors are stored and replayed like successes;
+// server errors are not stored, so such requests can be retried with the same key.
+func IdempotencyMiddleware(idempotencyService service.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if len(key) == 0 || !isWrite(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			problem.Write(c, model.NewValidationError(IdempotencyKeyHeader, model.ErrInvalidIdempotencyKey))
+			return
+		}
+
+		body, err := c.GetRawData()
+		if err != nil {
+			problem.Write(c, fmt.Errorf("%w: %v", model.ErrMalformed, err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		key = keyScope(c.Request, body) + "\n" + key
+		stored, err := idempotencyService.Begin(ctx, key, fingerprint(c.Request, body))
+		if err != nil {
+			if problem.Status(err) == http.StatusConflict {
+				c.Header("Retry-After", "1")
+			}
+			problem.Write(c, err)
+			return
+		}
+
+		if stored != nil {
+			replay(c, stored)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		completed := false
+		defer func() {
+			if !completed {
+				idempotencyService.Release(ctx, key)
+			}
+		}()
+
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		idempotencyService.Complete(ctx, key, &model.StoredResponse{
+			Status: recorder.Status(),
+			Header: recorder.Header().Clone(),
+			Body:   bytes.Clone(recorder.body.Bytes()),
+		})
+		completed = true
+	}
+}
+
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// keyScope returns the namespace of the request's idempotency key: its method, its path and the users
+// it acts for, taken from the user_id query parameter, the user_id of a JSON body and the user_id of its
+// batch operations. The scope never contains a line break, so it cannot run into the key.
+func keyScope(r *http.Request, body []byte) string {
+	users := r.URL.Query()["user_id"]
+
+	var payload struct {
+		UserID     json.RawMessage `json:"user_id"`
+		Operations []struct {
+			UserID json.RawMessage `json:"user_id"`
+		} `json:"operations"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		if len(payload.UserID) > 0 {
+			users = append(users, string(payload.UserID))
+		}
+		for _, op := range payload.Operations {
+			if len(op.UserID) > 0 {
+				users = append(users, string(op.UserID))
+			}
+		}
+	}
+	slices.Sort(users)
+
+	return strconv.Quote(fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, strings.Join(slices.Compact(users), ",")))
+}
+
+// fingerprint identifies a request by its method, URL and body.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.RequestURI())
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replay writes a stored response.
+func replay(c *gin.Context, stored *model.StoredResponse) {
+	header := c.Writer.Header()
+	for name, values := range stored.Header {
+		header[name] = values
+	}
+	header.Set(IdempotentReplayedHeader, "true")
+
+	c.Writer.WriteHeader(stored.Status)
+	_, _ = c.Writer.Write(stored.Body)
+	c.Abort()
+}
@@ -0,0 +1,32 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SpecPath is the route the OpenAPI document is served at.
+const SpecPath = "/openapi.json"
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed swagger.html
+var swaggerUI []byte
+
+// Spec returns the OpenAPI 3 document describing the HTTP API.
+func Spec() []byte {
+	return spec
+}
+
+// ServeSpec handles GET requests for the OpenAPI document.
+func ServeSpec(c *gin.Context) {
+	c.Data(http.StatusOK, gin.MIMEJSON, spec)
+}
+
+// ServeUI handles GET requests for the Swagger UI page rendering the document at SpecPath.
+func ServeUI(c *gin.Context) {
+	c.Data(http.StatusOK, gin.MIMEHTML, swaggerUI)
+}
@@ -0,0 +1,109 @@
+package problem
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of problem details responses.
+const ContentType = "application/problem+json"
+
+const (
+	typePrefix   = "urn:wb-calendar:problem:"
+	internalCode = "internal_error"
+)
+
+// Problem represents an RFC 7807 problem details response.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	Errors   []FieldProblem `json:"errors,omitempty"`
+	// Current carries the current state of a resource when a conditional write fails.
+	Current any `json:"current,omitempty"`
+}
+
+// FieldProblem describes a single invalid request field.
+type FieldProblem struct {
+	Field  string `json:"field"`
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+var kindStatuses = map[model.ErrorKind]int{
+	model.KindInvalid:       http.StatusBadRequest,
+	model.KindNotFound:      http.StatusNotFound,
+	model.KindConflict:      http.StatusConflict,
+	model.KindPrecondition:  http.StatusPreconditionFailed,
+	model.KindGone:          http.StatusGone,
+	model.KindUnsupported:   http.StatusUnsupportedMediaType,
+	model.KindUnprocessable: http.StatusUnprocessableEntity,
+}
+
+// Status returns the HTTP status an error maps to.
+func Status(err error) int {
+	if domainErr, ok := model.AsError(err); ok {
+		if status, ok := kindStatuses[domainErr.Kind]; ok {
+			return status
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+// New builds the problem details for an error. Errors outside the domain model
+// are reported as internal errors without exposing their message, and not-found errors
+// carry only the message of their sentinel.
+func New(err error) *Problem {
+	status := Status(err)
+	p := &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	domainErr, ok := model.AsError(err)
+	if !ok || status == http.StatusInternalServerError {
+		p.Type = typePrefix + internalCode
+		p.Code = internalCode
+		p.Detail = "internal server error"
+		return p
+	}
+
+	p.Type = typePrefix + domainErr.Code
+	p.Code = domainErr.Code
+	p.Detail = model.PublicMessage(err)
+
+	var verr *model.ValidationError
+	if errors.As(err, &verr) {
+		p.Type = typePrefix + "validation_failed"
+		p.Code = "validation_failed"
+		for _, f := range verr.Fields {
+			p.Errors = append(p.Errors, FieldProblem{Field: f.Field, Code: f.Err.Code, Detail: f.Err.Message})
+		}
+	}
+
+	return p
+}
+
+// Write writes the problem details for err as the response.
+func Write(c *gin.Context, err error) {
+	Render(c, New(err), err)
+}
+
+// Render writes a prepared problem as the response. err is logged for internal errors.
+func Render(c *gin.Context, p *Problem, err error) {
+	if p.Status == http.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+
+	p.Instance = c.Request.URL.Path
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
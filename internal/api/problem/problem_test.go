@@ -0,0 +1,46 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, Status(model.ErrEventNotFound))
+	assert.Equal(t, http.StatusNotFound, Status(fmt.Errorf("user id not match: %w", model.ErrEventNotFound)))
+	assert.Equal(t, http.StatusBadRequest, Status(model.NewValidationError("date", model.ErrInvalidDate)))
+	assert.Equal(t, http.StatusPreconditionFailed, Status(&model.VersionConflictError{Current: &model.Event{}}))
+	assert.Equal(t, http.StatusGone, Status(model.ErrSyncTokenExpired))
+	assert.Equal(t, http.StatusUnprocessableEntity, Status(model.ErrIdempotencyKeyReused))
+	assert.Equal(t, http.StatusInternalServerError, Status(errors.New("disk full")))
+}
+
+func TestNew(t *testing.T) {
+	p := New(model.ErrEventNotFound)
+	assert.Equal(t, "event_not_found", p.Code)
+	assert.Equal(t, typePrefix+"event_not_found", p.Type)
+	assert.Equal(t, "Not Found", p.Title)
+
+	p = New(fmt.Errorf("user id not match: %w", model.ErrEventNotFound))
+	assert.Equal(t, "event_not_found", p.Code)
+	assert.Equal(t, model.ErrEventNotFound.Message, p.Detail)
+
+	verr := &model.ValidationError{}
+	verr.Add("title", model.ErrEmptyTitle)
+	verr.Add("date", model.ErrInvalidDate)
+	p = New(verr)
+	assert.Equal(t, http.StatusBadRequest, p.Status)
+	assert.Equal(t, "validation_failed", p.Code)
+	require.Len(t, p.Errors, 2)
+	assert.Equal(t, FieldProblem{Field: "title", Code: "empty_title", Detail: "empty title"}, p.Errors[0])
+
+	p = New(errors.New("disk full"))
+	assert.Equal(t, internalCode, p.Code)
+	assert.NotContains(t, p.Detail, "disk full")
+}
@@ -0,0 +1,188 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// DateLayout is the layout of date-only request parameters and of the dates converters write into responses.
+const DateLayout = "2006-01-02"
+
+// tagErrors maps validation tags to the domain error reported for a failing field.
+var tagErrors = map[string]*model.Error{
+	"required":         model.ErrRequired,
+	"required_without": model.ErrRequired,
+	"excluded_with":    model.ErrExclusive,
+	"date":             model.ErrInvalidDate,
+	"timezone":         model.ErrInvalidTimezone,
+	"gtedate":          model.ErrInvalidRange,
+	"isoweek":          model.ErrInvalidWeek,
+	"weekday":          model.ErrInvalidWeekday,
+	"timeofday":        model.ErrInvalidTimeOfDay,
+	"eventid":          model.ErrInvalidEventID,
+}
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(fieldName)
+	_ = v.RegisterValidation("date", validateDate)
+	_ = v.RegisterValidation("gtedate", validateGteDate)
+	_ = v.RegisterValidation("isoweek", validateISOWeek)
+	_ = v.RegisterValidation("weekday", validateWeekday)
+	_ = v.RegisterValidation("timeofday", validateTimeOfDay)
+	_ = v.RegisterValidation("eventid", validateEventID)
+}
+
+// BindQuery binds and validates the query parameters of the request into obj.
+// The returned error is a domain error with per-field details.
+func BindQuery(c *gin.Context, obj any) error {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			if field, ok := queryKey(c, numErr.Num); ok {
+				return model.NewValidationError(field, model.ErrInvalidValue)
+			}
+		}
+		return BindError(err)
+	}
+
+	return nil
+}
+
+// BindURI binds and validates the path parameters of the request into obj.
+func BindURI(c *gin.Context, obj any) error {
+	if err := c.ShouldBindUri(obj); err != nil {
+		return BindError(err)
+	}
+
+	return nil
+}
+
+// BindJSON binds and validates the JSON body of the request into obj.
+func BindJSON(c *gin.Context, obj any) error {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		return BindError(err)
+	}
+
+	return nil
+}
+
+// BindError converts an error returned by gin binding into a domain error with per-field details.
+func BindError(err error) error {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		result := &model.ValidationError{}
+		for _, fe := range verrs {
+			domainErr, ok := tagErrors[fe.Tag()]
+			if !ok {
+				domainErr = model.ErrInvalidValue
+			}
+			result.Add(fe.Field(), domainErr)
+		}
+		return result
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return model.NewValidationError(typeErr.Field, model.ErrInvalidValue)
+	}
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return fmt.Errorf("%w: %q is not a number", model.ErrInvalidValue, numErr.Num)
+	}
+
+	return fmt.Errorf("%w: %v", model.ErrMalformed, err)
+}
+
+// queryKey finds the query parameter holding value, since gin does not report which one failed to parse.
+func queryKey(c *gin.Context, value string) (string, bool) {
+	for key, values := range c.Request.URL.Query() {
+		for _, v := range values {
+			if v == value {
+				return key, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// validateDate checks that a string field holds a date in DateLayout.
+func validateDate(fl validator.FieldLevel) bool {
+	_, err := time.Parse(DateLayout, fl.Field().String())
+	return err == nil
+}
+
+// validateEventID checks that a string field holds a ULID or a legacy integer event ID.
+func validateEventID(fl validator.FieldLevel) bool {
+	return model.ValidEventID(fl.Field().String())
+}
+
+// validateGteDate checks that a date field is not before the date field named by the parameter.
+// An empty parameter field is left to its own validation.
+func validateGteDate(fl validator.FieldLevel) bool {
+	other, _, _, ok := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !ok || other.Kind() != reflect.String || len(other.String()) == 0 {
+		return true
+	}
+
+	from, err := time.Parse(DateLayout, other.String())
+	if err != nil {
+		return true
+	}
+
+	to, err := time.Parse(DateLayout, fl.Field().String())
+	if err != nil {
+		return false
+	}
+
+	return !to.Before(from)
+}
+
+// validateISOWeek checks that a string field holds an ISO week such as 2026-W42.
+func validateISOWeek(fl validator.FieldLevel) bool {
+	_, err := model.ParseISOWeek(fl.Field().String(), time.UTC)
+	return err == nil
+}
+
+// validateWeekday checks that a string field holds a lower-case day name such as monday.
+func validateWeekday(fl validator.FieldLevel) bool {
+	_, err := model.ParseWeekday(fl.Field().String())
+	return err == nil
+}
+
+// validateTimeOfDay checks that a string field holds a wall-clock time such as 09:30.
+func validateTimeOfDay(fl validator.FieldLevel) bool {
+	_, err := model.ParseTimeOfDay(fl.Field().String())
+	return err == nil
+}
+
+// fieldName reports struct fields by their JSON, form or URI name.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if len(name) > 0 {
+			return name
+		}
+	}
+
+	return field.Name
+}
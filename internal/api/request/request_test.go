@@ -0,0 +1,83 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testQuery struct {
+	UserID   int    `form:"user_id" binding:"required,gt=0"`
+	From     string `form:"from" binding:"required,date"`
+	To       string `form:"to" binding:"omitempty,date,gtedate=From"`
+	TimeZone string `form:"tz" binding:"omitempty,timezone"`
+}
+
+func bindQuery(t *testing.T, query string) error {
+	t.Helper()
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+
+	var q testQuery
+	return BindQuery(c, &q)
+}
+
+func fields(t *testing.T, err error) map[string]*model.Error {
+	t.Helper()
+
+	var verr *model.ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	result := make(map[string]*model.Error)
+	for _, f := range verr.Fields {
+		result[f.Field] = f.Err
+	}
+
+	return result
+}
+
+func TestBindQuery(t *testing.T) {
+	assert.NoError(t, bindQuery(t, "user_id=1&from=2025-10-01&to=2025-10-31&tz=Europe/Moscow"))
+	assert.NoError(t, bindQuery(t, "user_id=1&from=2025-10-01&to=2025-10-01"))
+
+	assert.Equal(t, map[string]*model.Error{
+		"user_id": model.ErrRequired,
+		"from":    model.ErrRequired,
+	}, fields(t, bindQuery(t, "")))
+
+	assert.Equal(t, map[string]*model.Error{
+		"from": model.ErrInvalidDate,
+		"tz":   model.ErrInvalidTimezone,
+	}, fields(t, bindQuery(t, "user_id=1&from=2025-13-01&tz=Mars/Base")))
+
+	assert.Equal(t, map[string]*model.Error{
+		"to": model.ErrInvalidRange,
+	}, fields(t, bindQuery(t, "user_id=1&from=2025-10-02&to=2025-10-01")))
+
+	assert.Equal(t, map[string]*model.Error{
+		"user_id": model.ErrInvalidValue,
+	}, fields(t, bindQuery(t, "user_id=abc&from=2025-10-01")))
+}
+
+func TestBindError(t *testing.T) {
+	var req struct {
+		UserID int    `json:"user_id" binding:"required"`
+		Title  string `json:"title" binding:"required"`
+	}
+	err := BindError(binding.Validator.ValidateStruct(&req))
+
+	assert.Equal(t, map[string]*model.Error{
+		"user_id": model.ErrRequired,
+		"title":   model.ErrRequired,
+	}, fields(t, err))
+
+	assert.ErrorIs(t, BindError(errors.New("unexpected EOF")), model.ErrMalformed)
+}
@@ -0,0 +1,97 @@
+package router
+
+import (
+	"fmt"
+
+	calendarImpl "github.com/biryanim/wb_tech_calendar/internal/api/calendar"
+	"github.com/biryanim/wb_tech_calendar/internal/api/gql"
+	holidayImpl "github.com/biryanim/wb_tech_calendar/internal/api/holiday"
+	"github.com/biryanim/wb_tech_calendar/internal/api/middleware"
+	"github.com/biryanim/wb_tech_calendar/internal/api/openapi"
+	streamImpl "github.com/biryanim/wb_tech_calendar/internal/api/stream"
+	webhookImpl "github.com/biryanim/wb_tech_calendar/internal/api/webhook"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// DocsPath is the route of the Swagger UI.
+const DocsPath = "/docs"
+
+// New creates the HTTP router with every API route registered.
+// Every route except the documentation itself must be described in the OpenAPI document.
+func New(
+	calendarService service.CalendarService,
+	holidayService service.HolidayService,
+	webhookService service.WebhookService,
+	streamService service.StreamService,
+	idempotencyService service.IdempotencyService,
+) (*gin.Engine, error) {
+	calendarAPI := calendarImpl.New(calendarService, holidayService)
+	holidayAPI := holidayImpl.New(holidayService)
+	webhookAPI := webhookImpl.New(webhookService)
+	streamAPI := streamImpl.New(streamService)
+	graphqlAPI, err := gql.New(calendarService, holidayService)
+	if err != nil {
+		return nil, fmt.Errorf("build graphql schema: %w", err)
+	}
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(middleware.LoggerMiddleware())
+	r.Use(middleware.IdempotencyMiddleware(idempotencyService))
+
+	r.GET(openapi.SpecPath, openapi.ServeSpec)
+	r.GET(DocsPath, openapi.ServeUI)
+
+	v1 := r.Group("/api/v1")
+	v1.GET("/events", calendarAPI.ListEvents)
+	v1.POST("/events", calendarAPI.PostEvent)
+	v1.POST("/events/batch", calendarAPI.BatchEvents)
+	v1.POST("/events/quick", calendarAPI.PostQuickEvent)
+	v1.GET("/events/search", calendarAPI.SearchEvents)
+	v1.GET("/events/stats", calendarAPI.GetEventStats)
+	v1.GET("/events/:id", calendarAPI.GetEvent)
+	v1.PUT("/events/:id", calendarAPI.PutEvent)
+	v1.PATCH("/events/:id", calendarAPI.PatchEvent)
+	v1.DELETE("/events/:id", calendarAPI.DeleteEventByID)
+	v1.GET("/tags", calendarAPI.ListTags)
+	v1.POST("/tags", calendarAPI.PostTag)
+	v1.PUT("/tags/:name", calendarAPI.PutTag)
+	v1.DELETE("/tags/:name", calendarAPI.DeleteTag)
+	v1.GET("/templates", calendarAPI.ListTemplates)
+	v1.POST("/templates", calendarAPI.PostTemplate)
+	v1.GET("/templates/:id", calendarAPI.GetTemplate)
+	v1.PUT("/templates/:id", calendarAPI.PutTemplate)
+	v1.DELETE("/templates/:id", calendarAPI.DeleteTemplate)
+	v1.POST("/templates/:id/events", calendarAPI.PostTemplateEvent)
+	v1.GET("/views/month", calendarAPI.GetMonthGrid)
+	v1.GET("/views/week", calendarAPI.GetWeekView)
+	v1.GET("/settings", calendarAPI.GetSettings)
+	v1.PUT("/settings", calendarAPI.PutSettings)
+	v1.GET("/working_hours/overlap", calendarAPI.GetWorkingHoursOverlap)
+	v1.GET("/holidays", holidayAPI.ListCalendars)
+	v1.GET("/holidays/:country", holidayAPI.ListHolidays)
+	v1.GET("/holidays/:country/workdays", holidayAPI.AddWorkdays)
+
+	deprecated := r.Group("/", middleware.DeprecatedMiddleware("/api/v1/events"))
+	deprecated.POST("/create_event", calendarAPI.CreateEvent)
+	deprecated.POST("/update_event", calendarAPI.UpdateEvent)
+	deprecated.POST("/delete_event", calendarAPI.DeleteEvent)
+
+	r.GET("/events_for_day", calendarAPI.GetEventsForDay)
+	r.GET("/events_for_week", calendarAPI.GetEventsForWeek)
+	r.GET("/events_for_month", calendarAPI.GetEventsForMonth)
+	r.GET("/sync", calendarAPI.Sync)
+
+	r.POST("/create_webhook", webhookAPI.CreateSubscription)
+	r.POST("/delete_webhook", webhookAPI.DeleteSubscription)
+	r.GET("/webhooks", webhookAPI.GetSubscriptions)
+	r.GET("/webhook_deliveries", webhookAPI.GetDeliveries)
+
+	r.GET("/events_stream", streamAPI.StreamSSE)
+	r.GET("/events_ws", streamAPI.StreamWebSocket)
+
+	r.POST(gql.Path, graphqlAPI.Query)
+
+	return r, nil
+}
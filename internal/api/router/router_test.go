@@ -0,0 +1,889 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	calendarDto "github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/gql"
+	holidayDto "github.com/biryanim/wb_tech_calendar/internal/api/holiday/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/openapi"
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	streamDto "github.com/biryanim/wb_tech_calendar/internal/api/stream/dto"
+	webhookDto "github.com/biryanim/wb_tech_calendar/internal/api/webhook/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/service/calendar"
+	"github.com/biryanim/wb_tech_calendar/internal/service/clock"
+	"github.com/biryanim/wb_tech_calendar/internal/service/holiday"
+	"github.com/biryanim/wb_tech_calendar/internal/service/idempotency"
+	"github.com/biryanim/wb_tech_calendar/internal/service/stream"
+	"github.com/biryanim/wb_tech_calendar/internal/service/webhook"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type document struct {
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components struct {
+		Schemas    map[string]schema    `json:"schemas"`
+		Parameters map[string]parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID string                     `json:"operationId"`
+	Parameters  []parameter                `json:"parameters"`
+	Responses   map[string]json.RawMessage `json:"responses"`
+}
+
+type parameter struct {
+	Ref      string `json:"$ref"`
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+type schema struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+	Required   []string                   `json:"required"`
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func loadSpec(t *testing.T) *document {
+	t.Helper()
+
+	var doc document
+	require.NoError(t, json.Unmarshal(openapi.Spec(), &doc))
+
+	return &doc
+}
+
+func (d *document) parameters(op operation) []parameter {
+	result := make([]parameter, 0, len(op.Parameters))
+	for _, p := range op.Parameters {
+		if len(p.Ref) > 0 {
+			p = d.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+		}
+		result = append(result, p)
+	}
+
+	return result
+}
+
+func (d *document) operation(t *testing.T, operationID string) operation {
+	t.Helper()
+
+	for _, item := range d.Paths {
+		for _, op := range item {
+			if op.OperationID == operationID {
+				return op
+			}
+		}
+	}
+
+	require.FailNow(t, "operation not in spec", operationID)
+	return operation{}
+}
+
+func newRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	clk := clock.New()
+	webhookService, err := webhook.New(&config.WebhookConfig{MaxAttempts: 1, LogSize: 10}, clk)
+	require.NoError(t, err)
+	streamService := stream.New()
+	holidayService, err := holiday.New(&config.HolidayConfig{Dir: "../../service/holiday/testdata"})
+	require.NoError(t, err)
+
+	r, err := New(calendar.New(clk, webhookService, streamService), holidayService, webhookService, streamService,
+		idempotency.New(&config.IdempotencyConfig{TTL: time.Hour}, clk))
+	require.NoError(t, err)
+
+	return r
+}
+
+// problemCodes decodes a 400 problem response into the error code of each invalid field.
+func problemCodes(t *testing.T, w *httptest.ResponseRecorder) map[string]string {
+	t.Helper()
+
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	var p problem.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	result := make(map[string]string)
+	for _, fe := range p.Errors {
+		result[fe.Field] = fe.Code
+	}
+
+	return result
+}
+
+func TestRoutesMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+
+	var routes []string
+	for _, route := range newRouter(t).Routes() {
+		if route.Path == openapi.SpecPath || route.Path == DocsPath {
+			continue
+		}
+		routes = append(routes, route.Method+" "+pathParam.ReplaceAllString(route.Path, "{$1}"))
+	}
+
+	var documented []string
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	assert.ElementsMatch(t, routes, documented)
+}
+
+// jsonFields returns the JSON names of the fields of a DTO and which of them are required.
+// Request DTOs require the fields bound as required; response DTOs always send the fields without omitempty.
+func jsonFields(typ reflect.Type, isRequest bool) ([]string, []string) {
+	var names, required []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		names = append(names, name)
+
+		isRequired := !strings.Contains(opts, "omitempty")
+		if isRequest {
+			isRequired = strings.Contains(field.Tag.Get("binding"), "required")
+		}
+		if isRequired {
+			required = append(required, name)
+		}
+	}
+
+	return names, required
+}
+
+func TestSchemasMatchDTOs(t *testing.T) {
+	doc := loadSpec(t)
+
+	tests := []struct {
+		schema    string
+		dto       any
+		isRequest bool
+	}{
+		{"Event", calendarDto.Event{}, false},
+		{"CreateEventRequest", calendarDto.CreateEventRequest{}, true},
+		{"UpdateEventRequest", calendarDto.UpdateEventRequest{}, true},
+		{"DeleteEventRequest", calendarDto.DeleteEventRequest{}, true},
+		{"EventFields", calendarDto.EventFields{}, true},
+		{"BatchRequest", calendarDto.BatchRequest{}, true},
+		{"BatchOperation", calendarDto.BatchOperation{}, true},
+		{"BatchResponse", calendarDto.BatchResponse{}, false},
+		{"BatchResult", calendarDto.BatchResult{}, false},
+		{"SearchHit", calendarDto.SearchHit{}, false},
+		{"Tag", calendarDto.Tag{}, false},
+		{"CreateTagRequest", calendarDto.CreateTagRequest{}, true},
+		{"TagFields", calendarDto.TagFields{}, true},
+		{"Template", calendarDto.Template{}, false},
+		{"CreateTemplateRequest", calendarDto.CreateTemplateRequest{}, true},
+		{"TemplateFields", calendarDto.TemplateFields{}, true},
+		{"CreateFromTemplateRequest", calendarDto.CreateFromTemplateRequest{}, true},
+		{"QuickEventRequest", calendarDto.QuickEventRequest{}, true},
+		{"EventDraft", calendarDto.EventDraft{}, false},
+		{"PeriodStats", calendarDto.PeriodStats{}, false},
+		{"MonthGrid", calendarDto.MonthGrid{}, false},
+		{"GridDay", calendarDto.GridDay{}, false},
+		{"WeekView", calendarDto.WeekView{}, false},
+		{"WeekDay", calendarDto.WeekDay{}, false},
+		{"HolidayCalendar", holidayDto.Calendar{}, false},
+		{"Holiday", holidayDto.Holiday{}, false},
+		{"Workday", holidayDto.Workday{}, false},
+		{"Settings", calendarDto.Settings{}, false},
+		{"SettingsFields", calendarDto.SettingsFields{}, true},
+		{"WorkingHours", calendarDto.WorkingHours{}, true},
+		{"OutOfOffice", calendarDto.OutOfOffice{}, true},
+		{"Warning", calendarDto.Warning{}, false},
+		{"Interval", calendarDto.Interval{}, false},
+		{"UserWorkingTime", calendarDto.UserWorkingTime{}, false},
+		{"WorkingHoursOverlap", calendarDto.WorkingHoursOverlap{}, false},
+		{"SyncResponse", calendarDto.SyncResponse{}, false},
+		{"Subscription", webhookDto.Subscription{}, false},
+		{"CreateSubscriptionRequest", webhookDto.CreateSubscriptionRequest{}, true},
+		{"DeleteSubscriptionRequest", webhookDto.DeleteSubscriptionRequest{}, true},
+		{"Delivery", webhookDto.Delivery{}, false},
+		{"Message", streamDto.Message{}, false},
+		{"Problem", problem.Problem{}, false},
+		{"FieldProblem", problem.FieldProblem{}, false},
+		{"GraphQLRequest", gql.Request{}, true},
+		{"GraphQLResponse", graphql.Result{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schema, func(t *testing.T) {
+			s, ok := doc.Components.Schemas[tt.schema]
+			require.True(t, ok, "schema missing")
+
+			names, required := jsonFields(reflect.TypeOf(tt.dto), tt.isRequest)
+			var properties []string
+			for name := range s.Properties {
+				properties = append(properties, name)
+			}
+			assert.ElementsMatch(t, names, properties)
+			assert.ElementsMatch(t, required, s.Required)
+		})
+	}
+
+	// A merge patch may touch any writable field and requires none.
+	fields := doc.Components.Schemas["EventFields"].Properties
+	patch := doc.Components.Schemas["EventPatch"]
+	assert.Equal(t, len(fields), len(patch.Properties))
+	for name := range fields {
+		assert.Contains(t, patch.Properties, name)
+	}
+	assert.Empty(t, patch.Required)
+}
+
+// queryFields returns the form names of the fields of a query DTO, including embedded ones, and which are required.
+func queryFields(typ reflect.Type) ([]string, []string) {
+	var names, required []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous {
+			n, r := queryFields(field.Type)
+			names = append(names, n...)
+			required = append(required, r...)
+			continue
+		}
+
+		name := field.Tag.Get("form")
+		names = append(names, name)
+		if slices.Contains(strings.Split(field.Tag.Get("binding"), ","), "required") {
+			required = append(required, name)
+		}
+	}
+
+	return names, required
+}
+
+func TestQueryParametersMatchDTOs(t *testing.T) {
+	doc := loadSpec(t)
+
+	queries := map[string]any{
+		"listEvents":              calendarDto.RangeQuery{},
+		"getEvent":                calendarDto.UserQuery{},
+		"replaceEvent":            calendarDto.UserQuery{},
+		"patchEvent":              calendarDto.UserQuery{},
+		"deleteEvent":             calendarDto.UserQuery{},
+		"searchEvents":            calendarDto.SearchQuery{},
+		"getEventStats":           calendarDto.StatsQuery{},
+		"listTags":                calendarDto.UserQuery{},
+		"updateTag":               calendarDto.UserQuery{},
+		"deleteTag":               calendarDto.UserQuery{},
+		"listTemplates":           calendarDto.UserQuery{},
+		"getTemplate":             calendarDto.UserQuery{},
+		"replaceTemplate":         calendarDto.UserQuery{},
+		"deleteTemplate":          calendarDto.UserQuery{},
+		"createEventFromTemplate": calendarDto.UserQuery{},
+		"getMonthGrid":            calendarDto.MonthGridQuery{},
+		"getWeekView":             calendarDto.WeekViewQuery{},
+		"listHolidays":            holidayDto.RangeQuery{},
+		"addWorkdays":             holidayDto.WorkdaysQuery{},
+		"getSettings":             calendarDto.UserQuery{},
+		"updateSettings":          calendarDto.UserQuery{},
+		"getWorkingHoursOverlap":  calendarDto.OverlapQuery{},
+		"getEventsForDay":         calendarDto.DateQuery{},
+		"getEventsForWeek":        calendarDto.WeekQuery{},
+		"getEventsForMonth":       calendarDto.DateQuery{},
+		"sync":                    calendarDto.SyncQuery{},
+		"listWebhooks":            webhookDto.SubscriptionsQuery{},
+		"listWebhookDeliveries":   webhookDto.DeliveriesQuery{},
+		"streamEvents":            streamDto.StreamQuery{},
+		"streamEventsWebSocket":   streamDto.StreamQuery{},
+	}
+
+	for _, item := range doc.Paths {
+		for _, op := range item {
+			t.Run(op.OperationID, func(t *testing.T) {
+				var names, required []string
+				for _, p := range doc.parameters(op) {
+					if p.In != "query" {
+						continue
+					}
+					names = append(names, p.Name)
+					if p.Required {
+						required = append(required, p.Name)
+					}
+				}
+
+				query, ok := queries[op.OperationID]
+				if !ok {
+					assert.Empty(t, names, "query parameters without a DTO")
+					return
+				}
+
+				wantNames, wantRequired := queryFields(reflect.TypeOf(query))
+				assert.ElementsMatch(t, wantNames, names)
+				assert.ElementsMatch(t, wantRequired, required)
+			})
+		}
+	}
+}
+
+func TestResponsesMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+	r := newRouter(t)
+
+	tests := []struct {
+		operationID string
+		method      string
+		target      string
+		body        string
+		header      map[string]string
+	}{
+		{"createEvent", http.MethodPost, "/api/v1/events", `{"user_id":1,"date":"2025-10-01","title":"standup","tags":["Work"]}`, nil},
+		{"getEvent", http.MethodGet, "/api/v1/events/1?user_id=1", "", nil},
+		{"getEvent", http.MethodGet, "/api/v1/events/1?user_id=1", "", map[string]string{"If-None-Match": `"1"`}},
+		{"replaceEvent", http.MethodPut, "/api/v1/events/1?user_id=1", `{"date":"2025-10-02","title":"retro"}`, nil},
+		{"patchEvent", http.MethodPatch, "/api/v1/events/1?user_id=1", `{"title":"demo"}`,
+			map[string]string{"Content-Type": "application/merge-patch+json"}},
+		{"replaceEvent", http.MethodPut, "/api/v1/events/1?user_id=1", `{"date":"2025-10-02","title":"retro"}`,
+			map[string]string{"If-Match": `"1"`}},
+		{"batchEvents", http.MethodPost, "/api/v1/events/batch",
+			`{"operations":[{"op":"create","user_id":1,"date":"2025-10-04","title":"lecture"},{"op":"delete","id":99,"user_id":1}]}`, nil},
+		{"batchEvents", http.MethodPost, "/api/v1/events/batch", `{"operations":[]}`, nil},
+		{"createEventFromText", http.MethodPost, "/api/v1/events/quick", `{"user_id":1,"text":"Demo 2025-10-05 at 15:00 for 45m"}`, nil},
+		{"createEventFromText", http.MethodPost, "/api/v1/events/quick",
+			`{"user_id":1,"text":"Demo 2025-10-05 every week","time_zone":"Europe/Moscow","create":true}`, nil},
+		{"createEventFromText", http.MethodPost, "/api/v1/events/quick", `{"user_id":1,"text":"Read a book"}`, nil},
+		{"listEvents", http.MethodGet, "/api/v1/events?user_id=1&from=2025-10-01&to=2025-10-31", "", nil},
+		{"listEvents", http.MethodGet, "/api/v1/events?user_id=abc", "", nil},
+		{"listEvents", http.MethodGet, "/api/v1/events?user_id=1&from=2025-10-01&to=2025-10-31&tags=work,urgent&exclude_tags=home", "", nil},
+		{"createTag", http.MethodPost, "/api/v1/tags", `{"user_id":1,"name":"work","category":"job","color":"#1E90FF"}`, nil},
+		{"createTag", http.MethodPost, "/api/v1/tags", `{"user_id":1,"name":"work"}`, nil},
+		{"listTags", http.MethodGet, "/api/v1/tags?user_id=1", "", nil},
+		{"updateTag", http.MethodPut, "/api/v1/tags/work?user_id=1", `{"name":"office","category":"job"}`, nil},
+		{"getEventStats", http.MethodGet, "/api/v1/events/stats?user_id=1&from=2025-10-01&to=2025-10-31&period=week", "", nil},
+		{"getEventStats", http.MethodGet, "/api/v1/events/stats?user_id=1&from=2025-10-01&to=2025-10-31&period=year", "", nil},
+		{"deleteTag", http.MethodDelete, "/api/v1/tags/office?user_id=1", "", nil},
+		{"deleteTag", http.MethodDelete, "/api/v1/tags/office?user_id=1", "", nil},
+		{"createTemplate", http.MethodPost, "/api/v1/templates",
+			`{"user_id":1,"name":"Sprint review","title":"Sprint review","duration":"1h","tags":["team"],"visibility":"confidential"}`, nil},
+		{"createTemplate", http.MethodPost, "/api/v1/templates", `{"user_id":1,"name":"Offsite","all_day":true,"duration":"36h"}`, nil},
+		{"listTemplates", http.MethodGet, "/api/v1/templates?user_id=1", "", nil},
+		{"getTemplate", http.MethodGet, "/api/v1/templates/1?user_id=1", "", nil},
+		{"getTemplate", http.MethodGet, "/api/v1/templates/1?user_id=2", "", nil},
+		{"replaceTemplate", http.MethodPut, "/api/v1/templates/1?user_id=1", `{"name":"Sprint review","title":"Review","duration":"45m"}`, nil},
+		{"createEventFromTemplate", http.MethodPost, "/api/v1/templates/1/events?user_id=1",
+			`{"date":"2025-10-03T14:00:00Z","overrides":{"location":"Room 4"}}`, nil},
+		{"createEventFromTemplate", http.MethodPost, "/api/v1/templates/1/events?user_id=1", `{"date":"2025-10-03","overrides":{"title":null}}`, nil},
+		{"deleteTemplate", http.MethodDelete, "/api/v1/templates/1?user_id=1", "", nil},
+		{"deleteTemplate", http.MethodDelete, "/api/v1/templates/1?user_id=1", "", nil},
+		{"searchEvents", http.MethodGet, "/api/v1/events/search?user_id=1&q=stand&from=2025-10-01&limit=5", "", nil},
+		{"searchEvents", http.MethodGet, "/api/v1/events/search?user_id=1", "", nil},
+		{"getEventsForDay", http.MethodGet, "/events_for_day?user_id=1&date=2025-10-02", "", nil},
+		{"getEventsForWeek", http.MethodGet, "/events_for_week?user_id=1&date=2025-10-02", "", nil},
+		{"getEventsForWeek", http.MethodGet, "/events_for_week?user_id=1&week=2025-W40&week_start=sunday", "", nil},
+		{"getEventsForWeek", http.MethodGet, "/events_for_week?user_id=1&week=2025-W60", "", nil},
+		{"getMonthGrid", http.MethodGet, "/api/v1/views/month?user_id=1&date=2025-10-02&week_start=sunday&max_events=2", "", nil},
+		{"getMonthGrid", http.MethodGet, "/api/v1/views/month?user_id=1&date=2025-10-02&max_events=0", "", nil},
+		{"getMonthGrid", http.MethodGet, "/api/v1/views/month?user_id=1&date=2025-10-02&holidays=de", "", nil},
+		{"getWeekView", http.MethodGet, "/api/v1/views/week?user_id=1&date=2025-10-02&holidays=ru", "", nil},
+		{"getWeekView", http.MethodGet, "/api/v1/views/week?user_id=1", "", nil},
+		{"getSettings", http.MethodGet, "/api/v1/settings?user_id=1", "", nil},
+		{"updateSettings", http.MethodPut, "/api/v1/settings?user_id=1", `{"week_start":"sunday"}`, nil},
+		{"updateSettings", http.MethodPut, "/api/v1/settings?user_id=1", `{"week_start":"someday"}`, nil},
+		{"updateSettings", http.MethodPut, "/api/v1/settings?user_id=1", `{"week_start":"monday","country":"de"}`, nil},
+		{"updateSettings", http.MethodPut, "/api/v1/settings?user_id=2",
+			`{"week_start":"monday","time_zone":"Europe/Berlin","working_hours":[{"weekday":"monday","start":"09:00","end":"17:30"}],` +
+				`"out_of_office":[{"start":"2025-10-06","end":"2025-10-08","note":"trip"}]}`, nil},
+		{"updateSettings", http.MethodPut, "/api/v1/settings?user_id=2",
+			`{"week_start":"monday","working_hours":[{"weekday":"monday","start":"9:00","end":"25:00"}]}`, nil},
+		{"getWorkingHoursOverlap", http.MethodGet, "/api/v1/working_hours/overlap?user_ids=1,2&from=2025-10-06&to=2025-10-10", "", nil},
+		{"getWorkingHoursOverlap", http.MethodGet, "/api/v1/working_hours/overlap?user_ids=1,x&from=2025-10-06&to=2025-10-10", "", nil},
+		{"listHolidayCalendars", http.MethodGet, "/api/v1/holidays", "", nil},
+		{"listHolidays", http.MethodGet, "/api/v1/holidays/ru?from=2025-11-01&to=2025-11-30", "", nil},
+		{"listHolidays", http.MethodGet, "/api/v1/holidays/de?from=2025-11-01&to=2025-11-30", "", nil},
+		{"addWorkdays", http.MethodGet, "/api/v1/holidays/ru/workdays?date=2025-10-31&days=1", "", nil},
+		{"addWorkdays", http.MethodGet, "/api/v1/holidays/ru/workdays?date=2025-10-31&days=5000", "", nil},
+		{"getEventsForMonth", http.MethodGet, "/events_for_month?user_id=1&date=2025-10-02", "", nil},
+		{"sync", http.MethodGet, "/sync?user_id=1", "", nil},
+		{"sync", http.MethodGet, "/sync?user_id=1&token=bogus", "", nil},
+		{"createEventLegacy", http.MethodPost, "/create_event", `{"user_id":1,"date":"2025-10-03","title":"1:1"}`, nil},
+		{"updateEventLegacy", http.MethodPost, "/update_event", `{"id":2,"user_id":1,"date":"2025-10-03","title":"1:1"}`, nil},
+		{"deleteEventLegacy", http.MethodPost, "/delete_event", `{"id":2,"user_id":1}`, nil},
+		{"deleteEvent", http.MethodDelete, "/api/v1/events/1?user_id=1", "", nil},
+		{"getEvent", http.MethodGet, "/api/v1/events/1?user_id=1", "", nil},
+		{"createWebhook", http.MethodPost, "/create_webhook", `{"user_id":1,"url":"http://localhost/hook","secret":"s"}`, nil},
+		{"listWebhooks", http.MethodGet, "/webhooks?user_id=1", "", nil},
+		{"listWebhookDeliveries", http.MethodGet, "/webhook_deliveries?user_id=1&status=dead", "", nil},
+		{"deleteWebhook", http.MethodPost, "/delete_webhook", `{"id":1,"user_id":1}`, nil},
+		{"graphQL", http.MethodPost, "/graphql", `{"query":"{ calendar(userId: 1) { userId } }"}`, nil},
+		{"graphQL", http.MethodPost, "/graphql", `{}`, nil},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i)+"_"+tt.operationID, func(t *testing.T) {
+			op := doc.operation(t, tt.operationID)
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if len(tt.body) > 0 {
+				req.Header.Set("Content-Type", gin.MIMEJSON)
+			}
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code >= http.StatusBadRequest {
+				assert.Contains(t, op.Responses, "default")
+				assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+				return
+			}
+			assert.Contains(t, op.Responses, strconv.Itoa(w.Code), w.Body.String())
+		})
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	r := newRouter(t)
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/events", strings.NewReader(body))
+		req.Header.Set("Content-Type", gin.MIMEJSON)
+		req.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	body := `{"user_id":1,"date":"2025-10-01","title":"standup"}`
+	first := send("retry-1", body)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	retry := send("retry-1", body)
+	require.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, first.Header().Get("Location"), retry.Header().Get("Location"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+
+	reused := send("retry-1", `{"user_id":1,"date":"2025-10-01","title":"retro"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Contains(t, reused.Body.String(), "idempotency_key_reused")
+
+	other := send("retry-2", body)
+	require.Equal(t, http.StatusCreated, other.Code)
+	assert.NotEqual(t, first.Header().Get("Location"), other.Header().Get("Location"))
+
+	tooLong := send(strings.Repeat("k", 256), body)
+	assert.Equal(t, http.StatusBadRequest, tooLong.Code)
+
+	// Keys are scoped by user, so another client choosing the same key is not affected.
+	otherUser := send("retry-1", `{"user_id":2,"date":"2025-10-01","title":"retro"}`)
+	require.Equal(t, http.StatusCreated, otherUser.Code, otherUser.Body.String())
+	assert.Empty(t, otherUser.Header().Get("Idempotent-Replayed"))
+
+	// Client errors are stored and replayed too.
+	invalid := send("retry-3", `{"user_id":1,"date":"2025-10-01"}`)
+	require.Equal(t, http.StatusBadRequest, invalid.Code)
+	invalid = send("retry-3", `{"user_id":1,"date":"2025-10-01"}`)
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Equal(t, "true", invalid.Header().Get("Idempotent-Replayed"))
+}
+
+func TestEventIDs(t *testing.T) {
+	r := newRouter(t)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", gin.MIMEJSON)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/events", `{"user_id":1,"date":"2026-10-19T10:00:00Z","title":"standup"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created calendarDto.Event
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Regexp(t, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`, created.ID)
+	assert.Equal(t, 1, created.LegacyID)
+	assert.Equal(t, "/api/v1/events/"+created.ID, w.Header().Get("Location"))
+
+	for _, target := range []string{"/api/v1/events/" + created.ID, "/api/v1/events/1"} {
+		w = do(http.MethodGet, target+"?user_id=1", "")
+		require.Equal(t, http.StatusOK, w.Code, target)
+		var event calendarDto.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &event))
+		assert.Equal(t, created.ID, event.ID)
+	}
+
+	w = do(http.MethodPost, "/update_event", `{"id":1,"user_id":1,"date":"2026-10-19T11:00:00Z","title":"retro"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = do(http.MethodPost, "/update_event", `{"id":"`+created.ID+`","user_id":1,"date":"2026-10-19T11:00:00Z","title":"demo"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"version":3`)
+
+	w = do(http.MethodPost, "/api/v1/events/batch", `{"operations":[
+		{"op":"create","user_id":1,"date":"2026-10-20","title":"lecture"},
+		{"op":"update","id":2,"user_id":1,"date":"2026-10-20","title":"seminar"},
+		{"op":"delete","id":"1","user_id":1}]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var batch calendarDto.BatchResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &batch))
+	require.Len(t, batch.Results, 3)
+	assert.Equal(t, batch.Results[0].Event.ID, batch.Results[1].Event.ID)
+	assert.Equal(t, "seminar", batch.Results[1].Event.Title)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/events/"+created.ID+"?user_id=1", "").Code)
+
+	assert.Equal(t, map[string]string{"id": "invalid_event_id"}, problemCodes(t, do(http.MethodGet, "/api/v1/events/standup?user_id=1", "")))
+	assert.Equal(t, map[string]string{"id": "invalid_event_id"}, problemCodes(t, do(http.MethodGet, "/api/v1/events/0?user_id=1", "")))
+	assert.Equal(t, map[string]string{"id": "invalid_event_id"}, problemCodes(t, do(http.MethodPost, "/delete_event", `{"id":"standup","user_id":1}`)))
+
+	w = do(http.MethodPost, "/delete_event", `{"id":1.5,"user_id":1}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "malformed_request")
+}
+
+func TestConditionalRequests(t *testing.T) {
+	r := newRouter(t)
+
+	do := func(method, target, body string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", gin.MIMEJSON)
+		}
+		for name, value := range header {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/events", `{"user_id":1,"date":"2026-10-19T10:00:00Z","title":"standup"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	target := w.Header().Get("Location") + "?user_id=1"
+	body := `{"date":"2026-10-19T10:00:00Z","title":"retro"}`
+	require.Equal(t, http.StatusOK, do(http.MethodPut, target, body, nil).Code)
+
+	for value, code := range map[string]int{
+		`"2"`:          http.StatusNotModified,
+		`W/"2"`:        http.StatusNotModified,
+		`*`:            http.StatusNotModified,
+		`"1", "2"`:     http.StatusNotModified,
+		`"1",W/"2" , `: http.StatusNotModified,
+		`"1"`:          http.StatusOK,
+		`"1", W/"3"`:   http.StatusOK,
+		`2`:            http.StatusOK,
+		`"2`:           http.StatusOK,
+	} {
+		w = do(http.MethodGet, target, "", map[string]string{"If-None-Match": value})
+		assert.Equal(t, code, w.Code, value)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"), value)
+	}
+
+	for value, code := range map[string]int{
+		`W/"2"`:    http.StatusPreconditionFailed,
+		`"1", "3"`: http.StatusPreconditionFailed,
+		`"v2"`:     http.StatusPreconditionFailed,
+		`2`:        http.StatusBadRequest,
+		`"1" "2"`:  http.StatusBadRequest,
+	} {
+		w = do(http.MethodPut, target, body, map[string]string{"If-Match": value})
+		assert.Equal(t, code, w.Code, value)
+	}
+
+	w = do(http.MethodPut, target, body, map[string]string{"If-Match": `"1", "2"`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	w = do(http.MethodPut, target, body, map[string]string{"If-Match": `*`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = do(http.MethodPut, target, body, map[string]string{"If-Match": `"4"`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = do(http.MethodDelete, target, "", map[string]string{"If-Match": `"1", "3"`})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"current"`)
+	w = do(http.MethodDelete, target, "", map[string]string{"If-Match": `"4", "5"`})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestEventsForWeek(t *testing.T) {
+	r := newRouter(t)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", gin.MIMEJSON)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	titles := func(w *httptest.ResponseRecorder) []string {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var events []calendarDto.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &events))
+		result := make([]string, 0, len(events))
+		for _, event := range events {
+			result = append(result, event.Title)
+		}
+		return result
+	}
+
+	// Sunday the 18th and Monday the 19th of October 2026, which is in ISO week 43.
+	for _, body := range []string{
+		`{"user_id":1,"date":"2026-10-18T10:00:00Z","title":"sunday"}`,
+		`{"user_id":1,"date":"2026-10-19T10:00:00Z","title":"monday"}`,
+	} {
+		require.Equal(t, http.StatusCreated, do(http.MethodPost, "/api/v1/events", body).Code)
+	}
+
+	assert.Equal(t, []string{"monday"}, titles(do(http.MethodGet, "/events_for_week?user_id=1&date=2026-10-21", "")))
+	assert.Equal(t, []string{"sunday", "monday"}, titles(do(http.MethodGet, "/events_for_week?user_id=1&date=2026-10-21&week_start=sunday", "")))
+	assert.Equal(t, []string{"monday"}, titles(do(http.MethodGet, "/events_for_week?user_id=1&week=2026-W43&week_start=sunday", "")))
+	assert.Equal(t, []string{"sunday"}, titles(do(http.MethodGet, "/events_for_week?user_id=1&week=2026-W42", "")))
+
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/v1/settings?user_id=1", `{"week_start":"sunday"}`).Code)
+	assert.Equal(t, []string{"sunday", "monday"}, titles(do(http.MethodGet, "/events_for_week?user_id=1&date=2026-10-21", "")))
+
+	assert.Equal(t, map[string]string{"date": "required"}, problemCodes(t, do(http.MethodGet, "/events_for_week?user_id=1", "")))
+	assert.Equal(t, map[string]string{"date": "mutually_exclusive"},
+		problemCodes(t, do(http.MethodGet, "/events_for_week?user_id=1&date=2026-10-21&week=2026-W43", "")))
+	assert.Equal(t, map[string]string{"week": "invalid_week", "week_start": "invalid_weekday"},
+		problemCodes(t, do(http.MethodGet, "/events_for_week?user_id=1&week=2026-W54&week_start=sun", "")))
+}
+
+func TestHolidays(t *testing.T) {
+	r := newRouter(t)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", gin.MIMEJSON)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	decode := func(w *httptest.ResponseRecorder, out any) {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), out))
+	}
+
+	var calendars []holidayDto.Calendar
+	decode(do(http.MethodGet, "/api/v1/holidays", ""), &calendars)
+	assert.Equal(t, []holidayDto.Calendar{{Country: "ru", Years: []int{2025, 2026}}}, calendars)
+
+	var workday holidayDto.Workday
+	decode(do(http.MethodGet, "/api/v1/holidays/RU/workdays?date=2025-10-31&days=2", ""), &workday)
+	assert.Equal(t, "2025-11-05", workday.Date)
+
+	offDays := func(target string) []string {
+		var view calendarDto.WeekView
+		decode(do(http.MethodGet, target, ""), &view)
+		var result []string
+		for _, day := range view.Days {
+			if !day.Workday {
+				result = append(result, day.Date)
+			}
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"2025-11-08", "2025-11-09"}, offDays("/api/v1/views/week?user_id=1&date=2025-11-05"))
+	assert.Equal(t, []string{"2025-11-03", "2025-11-04", "2025-11-08", "2025-11-09"},
+		offDays("/api/v1/views/week?user_id=1&week=2025-W45&holidays=ru"))
+
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/v1/settings?user_id=1", `{"week_start":"monday","country":"ru"}`).Code)
+	assert.Equal(t, []string{"2025-11-02", "2025-11-03", "2025-11-04"}, offDays("/api/v1/views/week?user_id=1&date=2025-11-01&week_start=thursday"))
+
+	assert.Equal(t, map[string]string{"country": "unknown_country"},
+		problemCodes(t, do(http.MethodPut, "/api/v1/settings?user_id=1", `{"week_start":"monday","country":"de"}`)))
+	assert.Equal(t, map[string]string{"holidays": "unknown_country"}, problemCodes(t, do(http.MethodGet, "/api/v1/views/week?user_id=1&date=2025-11-05&holidays=de", "")))
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/holidays/de?from=2025-11-01&to=2025-11-30", "").Code)
+}
+
+func TestWorkingHours(t *testing.T) {
+	r := newRouter(t)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", gin.MIMEJSON)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPut, "/api/v1/settings?user_id=1", `{"week_start":"monday","time_zone":"Europe/Moscow",
+		"working_hours":[{"weekday":"monday","start":"09:00","end":"18:00"},{"weekday":"tuesday","start":"09:00","end":"18:00"}],
+		"out_of_office":[{"start":"2026-10-20","end":"2026-10-21","note":"conference"}]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var settings calendarDto.Settings
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &settings))
+	assert.Equal(t, "Europe/Moscow", settings.TimeZone)
+	assert.Equal(t, []*calendarDto.OutOfOffice{{Start: "2026-10-20T00:00:00+03:00", End: "2026-10-21T00:00:00+03:00", Note: "conference"}},
+		settings.OutOfOffice)
+
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/v1/settings?user_id=2",
+		`{"week_start":"monday","working_hours":[{"weekday":"monday","start":"08:00","end":"12:00"}]}`).Code)
+
+	created := func(w *httptest.ResponseRecorder) []*calendarDto.Warning {
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var event calendarDto.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &event))
+		return event.Warnings
+	}
+
+	warnings := created(do(http.MethodPost, "/api/v1/events", `{"user_id":1,"date":"2026-10-20T10:00:00+03:00","title":"keynote"}`))
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "out_of_office", warnings[0].Code)
+	assert.Contains(t, warnings[0].Detail, "conference")
+	assert.Empty(t, created(do(http.MethodPost, "/api/v1/events", `{"user_id":1,"date":"2026-10-19T10:00:00+03:00","title":"standup"}`)))
+
+	w = do(http.MethodGet, "/api/v1/events?user_id=1&from=2026-10-20&to=2026-10-20", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "warnings")
+
+	// Monday the 19th: 06:00-15:00 UTC for user 1 and 08:00-12:00 UTC for user 2.
+	// Tuesday is out of office for user 1 and not worked by user 2.
+	w = do(http.MethodGet, "/api/v1/working_hours/overlap?user_ids=1,2&from=2026-10-19&to=2026-10-20", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var overlap calendarDto.WorkingHoursOverlap
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &overlap))
+	assert.Equal(t, []*calendarDto.Interval{{Start: "2026-10-19T08:00:00Z", End: "2026-10-19T12:00:00Z"}}, overlap.Overlap)
+	require.Len(t, overlap.Users, 2)
+	assert.Equal(t, "Europe/Moscow", overlap.Users[0].TimeZone)
+	assert.Equal(t, []*calendarDto.Interval{{Start: "2026-10-19T06:00:00Z", End: "2026-10-19T15:00:00Z"}}, overlap.Users[0].Working)
+
+	assert.Equal(t, map[string]string{"working_hours": "overlapping_working_hours"}, problemCodes(t, do(http.MethodPut, "/api/v1/settings?user_id=1",
+		`{"week_start":"monday","working_hours":[{"weekday":"monday","start":"09:00","end":"13:00"},{"weekday":"monday","start":"12:00","end":"18:00"}]}`)))
+	assert.Equal(t, map[string]string{"end": "invalid_time_of_day", "time_zone": "invalid_timezone"}, problemCodes(t, do(http.MethodPut, "/api/v1/settings?user_id=1",
+		`{"week_start":"monday","time_zone":"Mars/Olympus","working_hours":[{"weekday":"monday","start":"09:00","end":"24:30"}]}`)))
+	assert.Equal(t, map[string]string{"out_of_office": "invalid_out_of_office"}, problemCodes(t, do(http.MethodPut, "/api/v1/settings?user_id=1",
+		`{"week_start":"monday","out_of_office":[{"start":"2026-10-21","end":"2026-10-20"}]}`)))
+	assert.Equal(t, map[string]string{"user_ids": "invalid_user_id"},
+		problemCodes(t, do(http.MethodGet, "/api/v1/working_hours/overlap?user_ids=1,0&from=2026-10-19&to=2026-10-20", "")))
+}
+
+func TestTemplates(t *testing.T) {
+	r := newRouter(t)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", gin.MIMEJSON)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/templates",
+		`{"user_id":1,"name":"Sprint review","title":"Sprint review","duration":"60m","tags":["Team"],"location":"Room 4"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var template calendarDto.Template
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &template))
+	assert.Equal(t, "1h", template.Duration)
+	assert.Equal(t, []string{"team"}, template.Tags)
+
+	target := "/api/v1/templates/" + strconv.Itoa(template.ID) + "/events?user_id=1"
+	w = do(http.MethodPost, target, `{"date":"2026-10-23T15:00:00+03:00","overrides":{"location":"Zoom","tags":["team","demo"]}}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var event calendarDto.Event
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &event))
+	assert.Equal(t, "/api/v1/events/"+event.ID, w.Header().Get("Location"))
+	assert.Equal(t, "Sprint review", event.Title)
+	assert.Equal(t, "Zoom", event.Location)
+	assert.Equal(t, []string{"demo", "team"}, event.Tags)
+	assert.Equal(t, "2026-10-23 12:00:00 +0000 UTC", event.Date)
+	assert.Equal(t, "2026-10-23 13:00:00 +0000 UTC", event.End)
+
+	assert.Equal(t, map[string]string{"title": "empty_title", "url": "invalid_url"},
+		problemCodes(t, do(http.MethodPost, target, `{"date":"2026-10-23","overrides":{"title":null,"url":"ftp://example.com"}}`)))
+	assert.Equal(t, map[string]string{"date": "invalid_date"}, problemCodes(t, do(http.MethodPost, target, `{"date":"next friday"}`)))
+	assert.Equal(t, map[string]string{"duration": "invalid_duration"},
+		problemCodes(t, do(http.MethodPost, "/api/v1/templates", `{"user_id":1,"name":"Offsite","all_day":true,"duration":"12h"}`)))
+
+	w = do(http.MethodPost, "/api/v1/templates/"+strconv.Itoa(template.ID)+"/events?user_id=2", `{"date":"2026-10-23"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestQuickEvent(t *testing.T) {
+	r := newRouter(t)
+
+	do := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/events/quick", strings.NewReader(body))
+		req.Header.Set("Content-Type", gin.MIMEJSON)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(`{"user_id":1,"text":"Демо 2030-05-10 в 15:00 на 45 минут","time_zone":"Europe/Moscow"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var draft calendarDto.EventDraft
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+	assert.Equal(t, "Europe/Moscow", draft.TimeZone)
+	assert.Equal(t, "Демо", draft.Event.Title)
+	assert.Equal(t, "2030-05-10T15:00:00+03:00", draft.Event.Date)
+	assert.Equal(t, "2030-05-10T15:45:00+03:00", draft.Event.End)
+	assert.Empty(t, draft.Recurrence)
+
+	w = do(`{"user_id":1,"text":"Planning 2030-05-13 at 10am every monday","create":true}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var event calendarDto.Event
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &event))
+	assert.Equal(t, "/api/v1/events/"+event.ID, w.Header().Get("Location"))
+	assert.Equal(t, "Planning", event.Title)
+	assert.Equal(t, "2030-05-13 10:00:00 +0000 UTC", event.Date)
+	require.Len(t, event.Warnings, 1)
+	assert.Equal(t, "recurrence_ignored", event.Warnings[0].Code)
+	assert.Contains(t, event.Warnings[0].Detail, "FREQ=WEEKLY;BYDAY=MO")
+
+	w = do(`{"user_id":1,"text":"Offsite 2030-05-20 на 3 дня"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+	assert.True(t, draft.Event.AllDay)
+	assert.Equal(t, "2030-05-20", draft.Event.Date)
+	assert.Equal(t, "2030-05-23", draft.Event.End)
+
+	assert.Equal(t, map[string]string{"text": "date_not_found"}, problemCodes(t, do(`{"user_id":1,"text":"Read a book"}`)))
+	assert.Equal(t, map[string]string{"text": "time_not_found"}, problemCodes(t, do(`{"user_id":1,"text":"Focus for 2 hours"}`)))
+	assert.Equal(t, map[string]string{"title": "empty_title"}, problemCodes(t, do(`{"user_id":1,"text":"2030-05-10 at 9"}`)))
+	assert.Equal(t, map[string]string{"time_zone": "invalid_timezone"},
+		problemCodes(t, do(`{"user_id":1,"text":"Demo 2030-05-10","time_zone":"Mars/Olympus"}`)))
+}
+
+func TestServeSpec(t *testing.T) {
+	r := newRouter(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openapi.SpecPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, string(openapi.Spec()), w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, DocsPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), openapi.SpecPath)
+}
@@ -0,0 +1,20 @@
+package dto
+
+import calendarDto "github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+
+// ResetType is the message type telling a client to reload its data.
+const ResetType = "reset"
+
+// Message represents a single change pushed over SSE or WebSocket.
+type Message struct {
+	ID         int64              `json:"id"`
+	Type       string             `json:"type"`
+	OccurredAt string             `json:"occurred_at,omitempty"`
+	Event      *calendarDto.Event `json:"event,omitempty"`
+}
+
+// StreamQuery represents the query parameters of a change stream subscription.
+type StreamQuery struct {
+	UserID      int   `form:"user_id" binding:"required,gt=0"`
+	LastEventID int64 `form:"last_event_id" binding:"gte=0"`
+}
@@ -0,0 +1,160 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/api/stream/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	lastEventIDHeader = "Last-Event-ID"
+	heartbeatInterval = 15 * time.Second
+	writeTimeout      = 10 * time.Second
+)
+
+// Implementation represents the HTTP handler implementation for the change stream.
+type Implementation struct {
+	streamService service.StreamService
+	upgrader      websocket.Upgrader
+}
+
+// New creates a new instance of Implementation with the provided stream service.
+func New(streamService service.StreamService) *Implementation {
+	return &Implementation{streamService: streamService}
+}
+
+// StreamSSE handles GET requests that subscribe to a user's changes over Server-Sent Events.
+// Clients resume with the standard Last-Event-ID header or the last_event_id query parameter.
+func (i *Implementation) StreamSSE(c *gin.Context) {
+	userID, lastID, ok := streamParams(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	messages := i.streamService.Subscribe(ctx, userID, lastID)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	// Send the headers right away so clients know the subscription is live before the first change.
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case msg, ok := <-messages:
+			if !ok {
+				return false
+			}
+
+			resp := converter.ToMessageResp(&msg)
+			data, err := json.Marshal(resp)
+			if err != nil {
+				return false
+			}
+
+			_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", resp.ID, resp.Type, data)
+			return err == nil
+		}
+	})
+}
+
+// StreamWebSocket handles GET requests that subscribe to a user's changes over a WebSocket.
+// Every change is sent as a JSON text message; the last_event_id query parameter resumes a stream.
+func (i *Implementation) StreamWebSocket(c *gin.Context) {
+	userID, lastID, ok := streamParams(c)
+	if !ok {
+		return
+	}
+
+	conn, err := i.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	// The client never sends data; reading only detects when it goes away.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	messages := i.streamService.Subscribe(ctx, userID, lastID)
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout))
+		case msg, ok := <-messages:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber fell behind"),
+					time.Now().Add(writeTimeout))
+				return
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			err = conn.WriteJSON(converter.ToMessageResp(&msg))
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// streamParams binds the stream query; the standard Last-Event-ID header takes precedence over last_event_id.
+func streamParams(c *gin.Context) (int, int64, bool) {
+	var q dto.StreamQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return 0, 0, false
+	}
+
+	header := c.GetHeader(lastEventIDHeader)
+	if len(header) == 0 {
+		return q.UserID, q.LastEventID, true
+	}
+
+	lastID, err := strconv.ParseInt(header, 10, 64)
+	if err != nil || lastID < 0 {
+		problem.Write(c, model.NewValidationError(lastEventIDHeader, model.ErrInvalidValue))
+		return 0, 0, false
+	}
+
+	return q.UserID, lastID, true
+}
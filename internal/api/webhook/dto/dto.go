@@ -0,0 +1,50 @@
+package dto
+
+// Subscription represents a webhook subscription in API responses.
+type Subscription struct {
+	ID        int      `json:"id"`
+	UserID    int      `json:"user_id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	CreatedAt string   `json:"created_at"`
+}
+
+// CreateSubscriptionRequest represents the payload for registering a webhook.
+type CreateSubscriptionRequest struct {
+	UserID int      `json:"user_id" binding:"required"`
+	URL    string   `json:"url" binding:"required"`
+	Secret string   `json:"secret" binding:"required"`
+	Events []string `json:"events"`
+}
+
+// DeleteSubscriptionRequest represents the payload for removing a webhook.
+type DeleteSubscriptionRequest struct {
+	ID     int `json:"id" binding:"required"`
+	UserID int `json:"user_id" binding:"required"`
+}
+
+// Delivery represents a webhook delivery log entry in API responses.
+type Delivery struct {
+	ID             int    `json:"id"`
+	SubscriptionID int    `json:"subscription_id"`
+	EventID        string `json:"event_id"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+// SubscriptionsQuery represents the query parameters of the subscription list.
+type SubscriptionsQuery struct {
+	UserID int `form:"user_id" binding:"required,gt=0"`
+}
+
+// DeliveriesQuery represents the query parameters of the delivery log.
+type DeliveriesQuery struct {
+	UserID int    `form:"user_id" binding:"required,gt=0"`
+	Status string `form:"status" binding:"omitempty,oneof=pending delivered dead"`
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/problem"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/api/webhook/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/converter"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Implementation represents the HTTP handler implementation for webhook operations.
+type Implementation struct {
+	webhookService service.WebhookService
+}
+
+// New creates a new instance of Implementation with the provided webhook service.
+func New(webhookService service.WebhookService) *Implementation {
+	return &Implementation{webhookService: webhookService}
+}
+
+// CreateSubscription handles POST requests to register a webhook subscription.
+func (i *Implementation) CreateSubscription(c *gin.Context) {
+	var req dto.CreateSubscriptionRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	sub, err := converter.FromCreateSubscriptionReq(&req)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	res, err := i.webhookService.CreateSubscription(c.Request.Context(), sub)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": converter.ToSubscriptionResp(res)})
+}
+
+// DeleteSubscription handles POST requests to remove a webhook subscription.
+func (i *Implementation) DeleteSubscription(c *gin.Context) {
+	var req dto.DeleteSubscriptionRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	err := i.webhookService.DeleteSubscription(c.Request.Context(), req.ID, req.UserID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetSubscriptions handles GET requests to list a user's webhook subscriptions.
+func (i *Implementation) GetSubscriptions(c *gin.Context) {
+	var q dto.SubscriptionsQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	subs, err := i.webhookService.GetSubscriptions(c.Request.Context(), q.UserID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToSubscriptionsResp(subs))
+}
+
+// GetDeliveries handles GET requests to read a user's webhook delivery log.
+// The optional status parameter narrows it down, e.g. status=dead lists the dead letters.
+func (i *Implementation) GetDeliveries(c *gin.Context) {
+	var q dto.DeliveriesQuery
+	if err := request.BindQuery(c, &q); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	deliveries, err := i.webhookService.GetDeliveries(c.Request.Context(), q.UserID, model.DeliveryStatus(q.Status))
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToDeliveriesResp(deliveries))
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"net"
+	"os"
+	"strings"
+)
+
+const (
+	grpcHostEnvName       = "GRPC_HOST"
+	grpcPortEnvName       = "GRPC_PORT"
+	grpcAuthTokensEnvName = "GRPC_AUTH_TOKENS"
+
+	defaultGRPCPort = "9090"
+)
+
+// GRPCConfig holds the configuration values for the gRPC server
+type GRPCConfig struct {
+	Host string
+	Port string
+	// AuthTokens are the bearer tokens accepted from clients; empty disables authentication.
+	AuthTokens []string
+}
+
+// NewGRPCConfig creates a new GRPCConfig instance. The host falls back to HTTP_HOST and the port to 9090.
+func NewGRPCConfig() (*GRPCConfig, error) {
+	cfg := &GRPCConfig{
+		Host: os.Getenv(grpcHostEnvName),
+		Port: os.Getenv(grpcPortEnvName),
+	}
+
+	if len(cfg.Host) == 0 {
+		cfg.Host = os.Getenv(httpHostEnvName)
+	}
+	if len(cfg.Port) == 0 {
+		cfg.Port = defaultGRPCPort
+	}
+
+	for _, token := range strings.Split(os.Getenv(grpcAuthTokensEnvName), ",") {
+		if token = strings.TrimSpace(token); len(token) > 0 {
+			cfg.AuthTokens = append(cfg.AuthTokens, token)
+		}
+	}
+
+	return cfg, nil
+}
+
+// Address returns the full network address in the format "host:port"
+func (cfg *GRPCConfig) Address() string {
+	return net.JoinHostPort(cfg.Host, cfg.Port)
+}
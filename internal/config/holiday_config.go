@@ -0,0 +1,19 @@
+package config
+
+import "os"
+
+const (
+	holidaysDirEnvName = "HOLIDAYS_DIR"
+)
+
+// HolidayConfig holds the configuration values for holiday calendars
+type HolidayConfig struct {
+	// Dir holds a file per country and year named like ru-2026.json or ru-2026.ics;
+	// empty loads no calendars.
+	Dir string
+}
+
+// NewHolidayConfig creates a new HolidayConfig instance from the environment
+func NewHolidayConfig() (*HolidayConfig, error) {
+	return &HolidayConfig{Dir: os.Getenv(holidaysDirEnvName)}, nil
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+const (
+	idempotencyTTLEnvName = "IDEMPOTENCY_TTL"
+
+	defaultIdempotencyTTL = 24 * time.Hour
+)
+
+// IdempotencyConfig holds the configuration values for idempotent write requests
+type IdempotencyConfig struct {
+	// TTL is how long the response to an Idempotency-Key is kept for replaying.
+	TTL time.Duration
+}
+
+// NewIdempotencyConfig creates a new IdempotencyConfig instance, falling back to defaults for unset values
+func NewIdempotencyConfig() (*IdempotencyConfig, error) {
+	ttl, err := durationEnv(idempotencyTTLEnvName, defaultIdempotencyTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IdempotencyConfig{TTL: ttl}, nil
+}
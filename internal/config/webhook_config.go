@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	webhookOutboxPathEnvName  = "WEBHOOK_OUTBOX_PATH"
+	webhookMaxAttemptsEnvName = "WEBHOOK_MAX_ATTEMPTS"
+	webhookBaseBackoffEnvName = "WEBHOOK_BASE_BACKOFF"
+	webhookMaxBackoffEnvName  = "WEBHOOK_MAX_BACKOFF"
+	webhookTimeoutEnvName     = "WEBHOOK_TIMEOUT"
+	webhookLogSizeEnvName     = "WEBHOOK_LOG_SIZE"
+
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookBaseBackoff = time.Second
+	defaultWebhookMaxBackoff  = 10 * time.Minute
+	defaultWebhookTimeout     = 10 * time.Second
+	defaultWebhookLogSize     = 1000
+)
+
+// WebhookConfig holds the configuration values for webhook delivery
+type WebhookConfig struct {
+	// OutboxPath is the file the outbox is persisted to; empty keeps it in memory only.
+	OutboxPath  string
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration
+	// LogSize limits how many delivered and how many dead entries are kept in the delivery log.
+	LogSize int
+}
+
+// NewWebhookConfig creates a new WebhookConfig instance, falling back to defaults for unset values
+func NewWebhookConfig() (*WebhookConfig, error) {
+	cfg := &WebhookConfig{
+		OutboxPath:  os.Getenv(webhookOutboxPathEnvName),
+		MaxAttempts: defaultWebhookMaxAttempts,
+		BaseBackoff: defaultWebhookBaseBackoff,
+		MaxBackoff:  defaultWebhookMaxBackoff,
+		Timeout:     defaultWebhookTimeout,
+		LogSize:     defaultWebhookLogSize,
+	}
+
+	var err error
+	if cfg.MaxAttempts, err = intEnv(webhookMaxAttemptsEnvName, cfg.MaxAttempts); err != nil {
+		return nil, err
+	}
+	if cfg.LogSize, err = intEnv(webhookLogSizeEnvName, cfg.LogSize); err != nil {
+		return nil, err
+	}
+	if cfg.BaseBackoff, err = durationEnv(webhookBaseBackoffEnvName, cfg.BaseBackoff); err != nil {
+		return nil, err
+	}
+	if cfg.MaxBackoff, err = durationEnv(webhookMaxBackoffEnvName, cfg.MaxBackoff); err != nil {
+		return nil, err
+	}
+	if cfg.Timeout, err = durationEnv(webhookTimeoutEnvName, cfg.Timeout); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func intEnv(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if len(value) == 0 {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+
+	return n, nil
+}
+
+func durationEnv(name string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if len(value) == 0 {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,69 @@
+package converter
+
+import (
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// FromBatchOperation converts a batch operation DTO to a domain BatchOp and reports every invalid field.
+func FromBatchOperation(op *dto.BatchOperation) (model.BatchOp, error) {
+	result := model.BatchOp{
+		Type: model.BatchOpType(op.Op),
+		Event: model.Event{
+			ID:      string(op.ID),
+			UserID:  op.UserID,
+			AllDay:  op.AllDay,
+			Title:   op.Title,
+			Tags:    model.NormalizeTags(op.Tags),
+			Version: op.Version,
+
+			Description:  op.Description,
+			Location:     op.Location,
+			URL:          op.URL,
+			Status:       model.EventStatus(op.Status),
+			Transparency: model.Transparency(op.Transparency),
+			Visibility:   model.Visibility(op.Visibility),
+		},
+	}
+
+	verr := &model.ValidationError{}
+	switch result.Type {
+	case model.BatchCreate, model.BatchUpdate, model.BatchDelete:
+	case "":
+		verr.Add("op", model.ErrRequired)
+	default:
+		verr.Add("op", model.ErrInvalidBatchOp)
+	}
+
+	if result.Type != model.BatchCreate && !model.ValidEventID(string(op.ID)) {
+		verr.Add("id", model.ErrInvalidEventID)
+	}
+	if op.UserID <= 0 {
+		verr.Add("user_id", model.ErrInvalidUserID)
+	}
+	if op.Version < 0 {
+		verr.Add("version", model.ErrInvalidVersion)
+	}
+
+	if result.Type == model.BatchCreate || result.Type == model.BatchUpdate {
+		date, err := parseEventTime(op.Date, op.AllDay)
+		if err != nil {
+			verr.Add("date", err)
+		}
+		result.Event.Date = date
+
+		if len(op.End) > 0 {
+			end, err := parseEventTime(op.End, op.AllDay)
+			if err != nil {
+				verr.Add("end", err)
+			}
+			result.Event.End = end
+		}
+
+		if len(op.Title) == 0 {
+			verr.Add("title", model.ErrEmptyTitle)
+		}
+	}
+
+	return result, verr.OrNil()
+}
@@ -1,23 +1,33 @@
 package converter
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
 	"github.com/biryanim/wb_tech_calendar/internal/model"
 )
 
 // FromCreateEventReq converts a CreateEventRequest DTO to a domain Event model.
 func FromCreateEventReq(req *dto.CreateEventRequest) (*model.Event, error) {
-	date, err := time.Parse("2006-01-02", req.Date)
+	date, end, err := parseEventSpan(req.Date, req.End, req.AllDay)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse date: %v", err)
+		return nil, err
 	}
 	event := &model.Event{
 		UserID: req.UserID,
 		Title:  req.Title,
 		Date:   date,
+		End:    end,
+		AllDay: req.AllDay,
+		Tags:   model.NormalizeTags(req.Tags),
+
+		Description:  req.Description,
+		Location:     req.Location,
+		URL:          req.URL,
+		Status:       model.EventStatus(req.Status),
+		Transparency: model.Transparency(req.Transparency),
+		Visibility:   model.Visibility(req.Visibility),
 	}
 
 	err = event.Validate()
@@ -31,24 +41,66 @@ func FromCreateEventReq(req *dto.CreateEventRequest) (*model.Event, error) {
 // ToEventResp converts a domain Event model to an Event DTO for API responses.
 func ToEventResp(event *model.Event) *dto.Event {
 	return &dto.Event{
-		ID:     event.ID,
-		UserID: event.UserID,
-		Title:  event.Title,
-		Date:   event.Date.String(),
+		ID:       event.ID,
+		LegacyID: event.LegacyID,
+		UserID:   event.UserID,
+		Title:    event.Title,
+		Date:     event.Date.String(),
+		End:      formatEnd(event.End),
+		AllDay:   event.AllDay,
+		Version:  event.Version,
+		Tags:     toTags(event.Tags),
+
+		Description:  event.Description,
+		Location:     event.Location,
+		URL:          event.URL,
+		Status:       string(event.Status),
+		Transparency: string(event.Transparency),
+		Visibility:   string(event.Visibility),
 	}
 }
 
 // FromUpdateEventReq converts an UpdateEventRequest DTO to a domain Event model.
 func FromUpdateEventReq(req *dto.UpdateEventRequest) (*model.Event, error) {
-	date, err := time.Parse("2006-01-02", req.Date)
+	date, end, err := parseEventSpan(req.Date, req.End, req.AllDay)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse date: %v", err)
+		return nil, err
 	}
 	event := &model.Event{
-		ID:     req.ID,
+		ID:     string(req.ID),
 		UserID: req.UserID,
 		Title:  req.Title,
 		Date:   date,
+		End:    end,
+		AllDay: req.AllDay,
+		Tags:   model.NormalizeTags(req.Tags),
+
+		Description:  req.Description,
+		Location:     req.Location,
+		URL:          req.URL,
+		Status:       model.EventStatus(req.Status),
+		Transparency: model.Transparency(req.Transparency),
+		Visibility:   model.Visibility(req.Visibility),
+	}
+
+	err = event.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// FromEventFields converts the writable fields of a full replacement into a domain Event model.
+func FromEventFields(eventID string, userID int, fields *dto.EventFields) (*model.Event, error) {
+	event := &model.Event{
+		ID:     eventID,
+		UserID: userID,
+	}
+
+	err := fromEventFields(fields, event)
+	if err != nil {
+		return nil, err
 	}
 
 	err = event.Validate()
@@ -68,3 +120,83 @@ func ToEventsResp(events []*model.Event) []*dto.Event {
 
 	return result
 }
+
+// ToEventSegmentsResp converts the events of the range [from, to) to Event DTOs marking the
+// events that continue from before the range or past its end.
+func ToEventSegmentsResp(events []*model.Event, from, to time.Time) []*dto.Event {
+	result := make([]*dto.Event, 0, len(events))
+	for _, event := range events {
+		resp := ToEventResp(event)
+		resp.ContinuesBefore, resp.ContinuesAfter = event.Continues(from, to)
+		result = append(result, resp)
+	}
+
+	return result
+}
+
+// parseEventSpan parses the start and the optional exclusive end of an event. Each is a date, taken
+// as midnight in UTC, or an RFC 3339 date-time; all-day events take dates only.
+func parseEventSpan(date, end string, allDay bool) (time.Time, time.Time, error) {
+	verr := &model.ValidationError{}
+
+	start, err := parseEventTime(date, allDay)
+	if err != nil {
+		verr.Add("date", err)
+	}
+
+	var endTime time.Time
+	if len(end) > 0 {
+		if endTime, err = parseEventTime(end, allDay); err != nil {
+			verr.Add("end", err)
+		}
+	}
+
+	return start, endTime, verr.OrNil()
+}
+
+func parseEventTime(value string, allDay bool) (time.Time, *model.Error) {
+	if t, err := time.Parse(request.DateLayout, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	switch {
+	case err != nil:
+		return time.Time{}, model.ErrInvalidDate
+	case allDay:
+		return time.Time{}, model.ErrNotWholeDay
+	}
+
+	return t.UTC(), nil
+}
+
+// formatEventTime formats the start or end of an event the way parseEventTime reads it back,
+// dropping the time of a midnight in UTC.
+func formatEventTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if t.Equal(t.Truncate(24*time.Hour)) && t.Location() == time.UTC {
+		return t.Format(request.DateLayout)
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+// formatEnd formats the end of an event for a response like its date; a missing end is empty.
+func formatEnd(end time.Time) string {
+	if end.IsZero() {
+		return ""
+	}
+
+	return end.String()
+}
+
+// toTags returns the tags of an event for a response, where they are never null.
+func toTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+
+	return tags
+}
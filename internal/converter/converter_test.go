@@ -0,0 +1,118 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromCreateEventReq_Details(t *testing.T) {
+	event, err := FromCreateEventReq(&dto.CreateEventRequest{
+		UserID:       1,
+		Date:         "2025-10-01",
+		Title:        "conference",
+		Description:  "Talks and workshops",
+		Location:     "Hall A",
+		URL:          "https://example.com/conf",
+		Status:       "tentative",
+		Transparency: "free",
+		Visibility:   "confidential",
+	})
+	require.NoError(t, err)
+
+	resp := ToEventResp(event)
+	assert.Equal(t, "Talks and workshops", resp.Description)
+	assert.Equal(t, "Hall A", resp.Location)
+	assert.Equal(t, "https://example.com/conf", resp.URL)
+	assert.Equal(t, "tentative", resp.Status)
+	assert.Equal(t, "free", resp.Transparency)
+	assert.Equal(t, "confidential", resp.Visibility)
+
+	fields := ToEventFields(event)
+	patched := &model.Event{}
+	require.NoError(t, ApplyEventFields(patched, fields))
+	assert.Equal(t, event.Description, patched.Description)
+	assert.Equal(t, event.Visibility, patched.Visibility)
+
+	require.NoError(t, ApplyEventMergePatch(event, []byte(`{"status":null,"location":"Hall B"}`)))
+	assert.Empty(t, event.Status)
+	assert.Equal(t, "Hall B", event.Location)
+}
+
+func TestFromCreateEventReq_InvalidDetails(t *testing.T) {
+	_, err := FromCreateEventReq(&dto.CreateEventRequest{
+		UserID:       1,
+		Date:         "2025-10-01",
+		Title:        "conference",
+		Description:  strings.Repeat("a", model.MaxDescriptionLength+1),
+		URL:          "ftp://example.com",
+		Status:       "done",
+		Transparency: "opaque",
+		Visibility:   "secret",
+	})
+
+	var verr *model.ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	fields := make(map[string]*model.Error)
+	for _, f := range verr.Fields {
+		fields[f.Field] = f.Err
+	}
+	assert.Equal(t, map[string]*model.Error{
+		"description":  model.ErrTooLong,
+		"url":          model.ErrInvalidURL,
+		"status":       model.ErrInvalidEventStatus,
+		"transparency": model.ErrInvalidTransparency,
+		"visibility":   model.ErrInvalidVisibility,
+	}, fields)
+}
+
+func TestTemplateDuration(t *testing.T) {
+	for _, duration := range []string{"1h", "1h30m", "45m", "48h", "1m30s", ""} {
+		template, err := FromTemplateFields(1, 1, &dto.TemplateFields{Name: "review", Duration: duration})
+		require.NoError(t, err)
+		assert.Equal(t, duration, ToTemplateResp(template).Duration)
+	}
+
+	template, err := FromTemplateFields(1, 1, &dto.TemplateFields{Name: "review", Duration: "90m"})
+	require.NoError(t, err)
+	assert.Equal(t, "1h30m", ToTemplateResp(template).Duration)
+
+	_, err = FromTemplateFields(1, 1, &dto.TemplateFields{Name: "review", Duration: "an hour"})
+	assert.ErrorIs(t, err, model.ErrInvalidDuration)
+}
+
+func TestFromEventIDsPb(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		legacyID int64
+		want     string
+		wantErr  bool
+	}{
+		{name: "ulid", id: "01JAQ6Y4X2ZC5W8N3V7T0MBR9K", want: "01JAQ6Y4X2ZC5W8N3V7T0MBR9K"},
+		{name: "ulid over legacy", id: "01JAQ6Y4X2ZC5W8N3V7T0MBR9K", legacyID: 7, want: "01JAQ6Y4X2ZC5W8N3V7T0MBR9K"},
+		{name: "legacy", legacyID: 7, want: "7"},
+		{name: "legacy as id", id: "7", want: "7"},
+		{name: "lower-case ulid", id: "01jaq6y4x2zc5w8n3v7t0mbr9k", wantErr: true},
+		{name: "negative legacy", legacyID: -1, wantErr: true},
+		{name: "neither", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventID, userID, err := FromEventIDsPb(tt.id, tt.legacyID, 1)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, model.ErrInvalidEventID)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, eventID)
+			assert.Equal(t, 1, userID)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// FromQuickEventReq returns the time zone a QuickEventRequest is read in; nil when the request leaves
+// it to the user's settings.
+func FromQuickEventReq(req *dto.QuickEventRequest) (*time.Location, error) {
+	if len(req.TimeZone) == 0 {
+		return nil, nil
+	}
+
+	return loadLocation("time_zone", req.TimeZone)
+}
+
+// ToEventDraftResp converts a domain EventDraft to its DTO. Timed events are given in the time zone of
+// the draft and all-day ones as dates.
+func ToEventDraftResp(draft *model.EventDraft) *dto.EventDraft {
+	event := draft.Event
+	resp := &dto.EventDraft{
+		Event: &dto.CreateEventRequest{
+			UserID:       event.UserID,
+			Date:         formatDraftTime(event.Date, event.AllDay, draft.Location),
+			End:          formatDraftTime(event.End, event.AllDay, draft.Location),
+			AllDay:       event.AllDay,
+			Title:        event.Title,
+			Tags:         toTags(event.Tags),
+			Description:  event.Description,
+			Location:     event.Location,
+			URL:          event.URL,
+			Status:       string(event.Status),
+			Transparency: string(event.Transparency),
+			Visibility:   string(event.Visibility),
+		},
+		TimeZone: draft.Location.String(),
+	}
+	if draft.Recurrence != nil {
+		resp.Recurrence = draft.Recurrence.RRule()
+	}
+
+	return resp
+}
+
+// formatDraftTime formats a time of a draft event: a date for all-day events, otherwise an RFC 3339
+// date-time in loc. A zero time is empty.
+func formatDraftTime(t time.Time, allDay bool, loc *time.Location) string {
+	switch {
+	case t.IsZero():
+		return ""
+	case allDay:
+		return t.Format(request.DateLayout)
+	}
+
+	return t.In(loc).Format(time.RFC3339)
+}
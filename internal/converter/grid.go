@@ -0,0 +1,117 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+const monthLayout = "2006-01"
+
+// FromMonthGridQuery converts MonthGridQuery parameters into a domain MonthGridQuery.
+func FromMonthGridQuery(q *dto.MonthGridQuery) (*model.MonthGridQuery, error) {
+	userID, date, err := FromDateQuery(&q.DateQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := FromTagQuery(&q.TagQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &model.MonthGridQuery{
+		UserID:    userID,
+		Date:      date,
+		Filter:    filter,
+		MaxEvents: q.MaxEvents,
+	}
+	if len(q.WeekStart) > 0 {
+		weekStart, err := model.ParseWeekday(q.WeekStart)
+		if err != nil {
+			return nil, model.NewValidationError("week_start", model.ErrInvalidWeekday)
+		}
+		query.WeekStart = &weekStart
+	}
+
+	return query, nil
+}
+
+// ToMonthGridResp converts a domain MonthGrid to a MonthGrid DTO. The events of every day are marked
+// as continuation segments if they reach out of it.
+func ToMonthGridResp(grid *model.MonthGrid) *dto.MonthGrid {
+	weeks := make([][]*dto.GridDay, 0, len(grid.Weeks))
+	for _, week := range grid.Weeks {
+		days := make([]*dto.GridDay, 0, len(week))
+		for _, day := range week {
+			from, to := model.DayRange(day.Date)
+			days = append(days, &dto.GridDay{
+				Date:        day.Date.Format(request.DateLayout),
+				InMonth:     day.InMonth,
+				Weekend:     day.Weekend,
+				Holiday:     day.Holiday,
+				HolidayName: day.HolidayName,
+				Workday:     day.Workday,
+				Events:      ToEventSegmentsResp(day.Events, from, to),
+				Overflow:    day.Overflow,
+			})
+		}
+		weeks = append(weeks, days)
+	}
+
+	return &dto.MonthGrid{
+		Month:     grid.Month.Format(monthLayout),
+		WeekStart: model.FormatWeekday(grid.WeekStart),
+		Weeks:     weeks,
+	}
+}
+
+// FromWeekViewQuery converts WeekViewQuery parameters into a domain WeekViewQuery.
+func FromWeekViewQuery(q *dto.WeekViewQuery) (*model.WeekViewQuery, error) {
+	userID, date, weekStart, err := FromWeekQuery(&q.WeekQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := FromTagQuery(&q.TagQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WeekViewQuery{
+		UserID:    userID,
+		Date:      date,
+		WeekStart: weekStart,
+		Filter:    filter,
+	}, nil
+}
+
+// ToWeekViewResp converts a domain WeekView to a WeekView DTO. The events of every day are marked
+// as continuation segments if they reach out of it.
+func ToWeekViewResp(view *model.WeekView) *dto.WeekView {
+	days := make([]*dto.WeekDay, 0, len(view.Days))
+	for _, day := range view.Days {
+		from, to := model.DayRange(day.Date)
+		days = append(days, &dto.WeekDay{
+			Date:        day.Date.Format(request.DateLayout),
+			Weekend:     day.Weekend,
+			Holiday:     day.Holiday,
+			HolidayName: day.HolidayName,
+			Workday:     day.Workday,
+			Events:      ToEventSegmentsResp(day.Events, from, to),
+		})
+	}
+
+	return &dto.WeekView{
+		Start:     view.Start.Format(request.DateLayout),
+		WeekStart: model.FormatWeekday(view.WeekStart),
+		Days:      days,
+	}
+}
+
+// FromHolidaysQuery returns the country of the holiday calendar requested for a view; empty for the user's one.
+func FromHolidaysQuery(q *dto.HolidaysQuery) string {
+	return strings.ToLower(q.Holidays)
+}
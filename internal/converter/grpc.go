@@ -0,0 +1,339 @@
+package converter
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	calendarv1 "github.com/biryanim/wb_tech_calendar/pkg/pb/calendar/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var changeTypesPb = map[model.ChangeType]calendarv1.ChangeType{
+	model.ChangeCreated: calendarv1.ChangeType_CHANGE_TYPE_CREATED,
+	model.ChangeUpdated: calendarv1.ChangeType_CHANGE_TYPE_UPDATED,
+	model.ChangeDeleted: calendarv1.ChangeType_CHANGE_TYPE_DELETED,
+}
+
+var eventStatusesPb = map[model.EventStatus]calendarv1.EventStatus{
+	model.StatusConfirmed: calendarv1.EventStatus_EVENT_STATUS_CONFIRMED,
+	model.StatusTentative: calendarv1.EventStatus_EVENT_STATUS_TENTATIVE,
+	model.StatusCancelled: calendarv1.EventStatus_EVENT_STATUS_CANCELLED,
+}
+
+var transparenciesPb = map[model.Transparency]calendarv1.Transparency{
+	model.TransparencyBusy: calendarv1.Transparency_TRANSPARENCY_BUSY,
+	model.TransparencyFree: calendarv1.Transparency_TRANSPARENCY_FREE,
+}
+
+var visibilitiesPb = map[model.Visibility]calendarv1.Visibility{
+	model.VisibilityPublic:       calendarv1.Visibility_VISIBILITY_PUBLIC,
+	model.VisibilityPrivate:      calendarv1.Visibility_VISIBILITY_PRIVATE,
+	model.VisibilityConfidential: calendarv1.Visibility_VISIBILITY_CONFIDENTIAL,
+}
+
+// eventFieldPaths are the update mask paths of the writable event fields.
+var eventFieldPaths = []string{"date", "end", "all_day", "title", "tags", "description", "location", "url", "status", "transparency", "visibility"}
+
+// ToEventPb converts a domain Event model to its protobuf message.
+func ToEventPb(event *model.Event) *calendarv1.Event {
+	res := &calendarv1.Event{
+		Id:       event.ID,
+		LegacyId: int64(event.LegacyID),
+		UserId:   int64(event.UserID),
+		Date:     timestamppb.New(event.Date),
+		Title:    event.Title,
+		Version:  int64(event.Version),
+		Tags:     event.Tags,
+
+		Description:  event.Description,
+		Location:     event.Location,
+		Url:          event.URL,
+		Status:       eventStatusesPb[event.Status],
+		Transparency: transparenciesPb[event.Transparency],
+		Visibility:   visibilitiesPb[event.Visibility],
+		AllDay:       event.AllDay,
+	}
+	if !event.End.IsZero() {
+		res.End = timestamppb.New(event.End)
+	}
+
+	return res
+}
+
+// ToEventSegmentPb converts an event of the range [from, to) to its protobuf message, marking
+// whether it continues from before the range or past its end.
+func ToEventSegmentPb(event *model.Event, from, to time.Time) *calendarv1.Event {
+	res := ToEventPb(event)
+	res.ContinuesBefore, res.ContinuesAfter = event.Continues(from, to)
+
+	return res
+}
+
+// ToWarningsPb converts domain Warnings to their protobuf messages.
+func ToWarningsPb(warnings []*model.Warning) []*calendarv1.Warning {
+	var result []*calendarv1.Warning
+	for _, w := range warnings {
+		result = append(result, &calendarv1.Warning{Code: w.Code, Detail: w.Message})
+	}
+
+	return result
+}
+
+// FromCreateEventPb converts a CreateEventRequest message to a domain Event model.
+func FromCreateEventPb(req *calendarv1.CreateEventRequest) (*model.Event, error) {
+	date, end, err := parseEventSpan(req.GetDate(), req.GetEnd(), req.GetAllDay())
+	if err != nil {
+		return nil, err
+	}
+
+	event := &model.Event{
+		UserID: int(req.GetUserId()),
+		Title:  req.GetTitle(),
+		Date:   date,
+		End:    end,
+		AllDay: req.GetAllDay(),
+		Tags:   model.NormalizeTags(req.GetTags()),
+
+		Description:  req.GetDescription(),
+		Location:     req.GetLocation(),
+		URL:          req.GetUrl(),
+		Status:       fromEnumPb(eventStatusesPb, req.GetStatus()),
+		Transparency: fromEnumPb(transparenciesPb, req.GetTransparency()),
+		Visibility:   fromEnumPb(visibilitiesPb, req.GetVisibility()),
+	}
+
+	if err = event.Validate(); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// FromUpdateEventPb converts an UpdateEventRequest message to a domain Event model carrying the expected version.
+func FromUpdateEventPb(req *calendarv1.UpdateEventRequest) (*model.Event, error) {
+	eventID, userID, err := FromEventIDsPb(req.GetId(), req.GetLegacyId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	date, end, err := parseEventSpan(req.GetDate(), req.GetEnd(), req.GetAllDay())
+	if err != nil {
+		return nil, err
+	}
+
+	event := &model.Event{
+		ID:      eventID,
+		UserID:  userID,
+		Title:   req.GetTitle(),
+		Date:    date,
+		End:     end,
+		AllDay:  req.GetAllDay(),
+		Version: int(req.GetVersion()),
+		Tags:    model.NormalizeTags(req.GetTags()),
+
+		Description:  req.GetDescription(),
+		Location:     req.GetLocation(),
+		URL:          req.GetUrl(),
+		Status:       fromEnumPb(eventStatusesPb, req.GetStatus()),
+		Transparency: fromEnumPb(transparenciesPb, req.GetTransparency()),
+		Visibility:   fromEnumPb(visibilitiesPb, req.GetVisibility()),
+	}
+
+	if err = event.Validate(); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ApplyEventFieldMask copies the fields listed in the request's update mask onto event.
+// An empty mask replaces every writable field.
+func ApplyEventFieldMask(event *model.Event, req *calendarv1.PatchEventRequest) error {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = eventFieldPaths
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "date":
+			date, err := parseEventTime(req.GetDate(), false)
+			if err != nil {
+				return model.NewValidationError("date", err)
+			}
+			event.Date = date
+		case "end":
+			event.End = time.Time{}
+			if len(req.GetEnd()) > 0 {
+				end, err := parseEventTime(req.GetEnd(), false)
+				if err != nil {
+					return model.NewValidationError("end", err)
+				}
+				event.End = end
+			}
+		case "all_day":
+			event.AllDay = req.GetAllDay()
+		case "title":
+			event.Title = req.GetTitle()
+		case "tags":
+			event.Tags = model.NormalizeTags(req.GetTags())
+		case "description":
+			event.Description = req.GetDescription()
+		case "location":
+			event.Location = req.GetLocation()
+		case "url":
+			event.URL = req.GetUrl()
+		case "status":
+			event.Status = fromEnumPb(eventStatusesPb, req.GetStatus())
+		case "transparency":
+			event.Transparency = fromEnumPb(transparenciesPb, req.GetTransparency())
+		case "visibility":
+			event.Visibility = fromEnumPb(visibilitiesPb, req.GetVisibility())
+		default:
+			return model.NewValidationError("update_mask", model.ErrInvalidValue)
+		}
+	}
+
+	return nil
+}
+
+// FromEventIDsPb checks the event and owner IDs of a request addressing a single event.
+// The event is given by its ULID or, when that is empty, by its legacy integer ID.
+func FromEventIDsPb(eventID string, legacyID, userID int64) (string, int, error) {
+	if len(eventID) == 0 && legacyID != 0 {
+		eventID = strconv.FormatInt(legacyID, 10)
+	}
+
+	verr := &model.ValidationError{}
+	if !model.ValidEventID(eventID) {
+		verr.Add("id", model.ErrInvalidEventID)
+	}
+	if userID <= 0 {
+		verr.Add("user_id", model.ErrInvalidUserID)
+	}
+
+	return eventID, int(userID), verr.OrNil()
+}
+
+// FromUserIDPb checks the owner ID of a per-user request.
+func FromUserIDPb(userID int64) (int, error) {
+	if userID <= 0 {
+		return 0, model.NewValidationError("user_id", model.ErrInvalidUserID)
+	}
+
+	return int(userID), nil
+}
+
+// FromDateRequestPb converts a DateRequest message into the owner and the requested date in its time zone.
+func FromDateRequestPb(req *calendarv1.DateRequest) (int, time.Time, error) {
+	userID, err := FromUserIDPb(req.GetUserId())
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	loc, err := loadLocation("time_zone", req.GetTimeZone())
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	date, err := parseDate("date", req.GetDate(), loc)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return userID, date, nil
+}
+
+// FromRangeRequestPb converts a RangeRequest message into the owner and a half-open [from, to) interval
+// covering both requested days in full.
+func FromRangeRequestPb(req *calendarv1.RangeRequest) (int, time.Time, time.Time, error) {
+	userID, err := FromUserIDPb(req.GetUserId())
+	if err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+
+	loc, err := loadLocation("time_zone", req.GetTimeZone())
+	if err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+
+	from, to, err := parseDayRange("from", req.GetFrom(), "to", req.GetTo(), loc)
+	if err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+
+	return userID, from, to, nil
+}
+
+// FromTagFilterPb converts the tag filters of a list request into a domain TagFilter.
+func FromTagFilterPb(tags, excludeTags []string) (model.TagFilter, error) {
+	filter := model.TagFilter{
+		Include: model.NormalizeTags(tags),
+		Exclude: model.NormalizeTags(excludeTags),
+	}
+
+	verr := &model.ValidationError{}
+	for _, tag := range filter.Include {
+		if !model.ValidTagName(tag) {
+			verr.Add("tags", model.ErrInvalidTag)
+			break
+		}
+	}
+	for _, tag := range filter.Exclude {
+		if !model.ValidTagName(tag) {
+			verr.Add("exclude_tags", model.ErrInvalidTag)
+			break
+		}
+	}
+
+	return filter, verr.OrNil()
+}
+
+// ToSyncPb converts a domain SyncResult to a SyncResponse message.
+func ToSyncPb(res *model.SyncResult) *calendarv1.SyncResponse {
+	resp := &calendarv1.SyncResponse{
+		Events:        make([]*calendarv1.Event, 0, len(res.Upserts)),
+		Deleted:       res.Deleted,
+		LegacyDeleted: make([]int64, 0, len(res.LegacyDeleted)),
+		Token:         ToSyncToken(res.Seq),
+	}
+	for _, event := range res.Upserts {
+		resp.Events = append(resp.Events, ToEventPb(event))
+	}
+	for _, id := range res.LegacyDeleted {
+		resp.LegacyDeleted = append(resp.LegacyDeleted, int64(id))
+	}
+
+	return resp
+}
+
+// ToChangeMessagePb converts a domain ChangeMessage to its protobuf message.
+func ToChangeMessagePb(msg *model.ChangeMessage) *calendarv1.ChangeMessage {
+	if msg.Reset {
+		return &calendarv1.ChangeMessage{Id: msg.ID, ResetRequired: true}
+	}
+
+	return &calendarv1.ChangeMessage{
+		Id:         msg.ID,
+		Type:       changeTypesPb[msg.Change.Type],
+		OccurredAt: timestamppb.New(msg.Change.OccurredAt),
+		Event:      ToEventPb(&msg.Change.Event),
+	}
+}
+
+// fromEnumPb returns the model value of a protobuf enum value. The unspecified zero value maps to
+// the empty default and unknown values to one that Event.Validate rejects.
+func fromEnumPb[M ~string, P ~int32](values map[M]P, v P) M {
+	if v == 0 {
+		return ""
+	}
+
+	for value, pb := range values {
+		if pb == v {
+			return value
+		}
+	}
+
+	return M(strconv.Itoa(int(v)))
+}
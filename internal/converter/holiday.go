@@ -0,0 +1,53 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/holiday/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// FromHolidayRangeQuery converts RangeQuery parameters into a half-open [from, to) interval covering both days.
+func FromHolidayRangeQuery(q *dto.RangeQuery) (time.Time, time.Time, error) {
+	return parseDayRange("from", q.From, "to", q.To, time.UTC)
+}
+
+// FromWorkdaysQuery converts WorkdaysQuery parameters into the date to move and the working days to move it by.
+func FromWorkdaysQuery(q *dto.WorkdaysQuery) (time.Time, int, error) {
+	date, err := parseDate("date", q.Date, time.UTC)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return date, q.Days, nil
+}
+
+// ToHolidayCalendarsResp converts domain HolidayCalendar models to Calendar DTOs.
+func ToHolidayCalendarsResp(calendars []*model.HolidayCalendar) []*dto.Calendar {
+	result := make([]*dto.Calendar, 0, len(calendars))
+	for _, cal := range calendars {
+		result = append(result, &dto.Calendar{Country: cal.Country, Years: cal.Years})
+	}
+
+	return result
+}
+
+// ToHolidaysResp converts domain Holiday models to Holiday DTOs.
+func ToHolidaysResp(holidays []*model.Holiday) []*dto.Holiday {
+	result := make([]*dto.Holiday, 0, len(holidays))
+	for _, holiday := range holidays {
+		result = append(result, &dto.Holiday{
+			Date: holiday.Date.Format(request.DateLayout),
+			Name: holiday.Name,
+			Kind: string(holiday.Kind),
+		})
+	}
+
+	return result
+}
+
+// ToWorkdayResp converts a date reached by working days to a Workday DTO.
+func ToWorkdayResp(date time.Time) *dto.Workday {
+	return &dto.Workday{Date: date.Format(request.DateLayout)}
+}
@@ -0,0 +1,113 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// ApplyEventMergePatch applies a JSON Merge Patch (RFC 7396) to the writable fields of event.
+// Members set to null are reset to their zero value; unknown or read-only members are rejected.
+func ApplyEventMergePatch(event *model.Event, patch []byte) error {
+	var patchDoc any
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return fmt.Errorf("%w: %v", model.ErrInvalidPatch, err)
+	}
+	if _, ok := patchDoc.(map[string]any); !ok {
+		return fmt.Errorf("%w: patch must be a JSON object", model.ErrInvalidPatch)
+	}
+
+	current, err := json.Marshal(ToEventFields(event))
+	if err != nil {
+		return err
+	}
+
+	var target any
+	if err = json.Unmarshal(current, &target); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(target, patchDoc))
+	if err != nil {
+		return err
+	}
+
+	var fields dto.EventFields
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&fields); err != nil {
+		return fmt.Errorf("%w: %v", model.ErrInvalidPatch, err)
+	}
+
+	return fromEventFields(&fields, event)
+}
+
+// ApplyEventFields copies the writable fields onto event.
+func ApplyEventFields(event *model.Event, fields *dto.EventFields) error {
+	return fromEventFields(fields, event)
+}
+
+// ToEventFields returns the writable fields of event.
+func ToEventFields(event *model.Event) *dto.EventFields {
+	return &dto.EventFields{
+		Date:   formatEventTime(event.Date),
+		End:    formatEventTime(event.End),
+		AllDay: event.AllDay,
+		Title:  event.Title,
+		Tags:   event.Tags,
+
+		Description:  event.Description,
+		Location:     event.Location,
+		URL:          event.URL,
+		Status:       string(event.Status),
+		Transparency: string(event.Transparency),
+		Visibility:   string(event.Visibility),
+	}
+}
+
+func fromEventFields(fields *dto.EventFields, event *model.Event) error {
+	date, end, err := parseEventSpan(fields.Date, fields.End, fields.AllDay)
+	if err != nil {
+		return err
+	}
+
+	event.Date = date
+	event.End = end
+	event.AllDay = fields.AllDay
+	event.Title = fields.Title
+	event.Tags = model.NormalizeTags(fields.Tags)
+	event.Description = fields.Description
+	event.Location = fields.Location
+	event.URL = fields.URL
+	event.Status = model.EventStatus(fields.Status)
+	event.Transparency = model.Transparency(fields.Transparency)
+	event.Visibility = model.Visibility(fields.Visibility)
+
+	return nil
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396 over decoded JSON values.
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any)
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+
+	return targetObj
+}
@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEventMergePatch(t *testing.T) {
+	date := time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC)
+	event := &model.Event{ID: "01JAQ6Y4X2ZC5W8N3V7T0MBR9K", UserID: 1, Title: "original", Date: date}
+
+	err := ApplyEventMergePatch(event, []byte(`{"title":"patched"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "patched", event.Title)
+	assert.Equal(t, date, event.Date)
+
+	err = ApplyEventMergePatch(event, []byte(`{"date":"2025-11-01"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "patched", event.Title)
+	assert.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), event.Date)
+
+	err = ApplyEventMergePatch(event, []byte(`{"title":null}`))
+	require.NoError(t, err)
+	assert.Empty(t, event.Title)
+}
+
+func TestApplyEventMergePatch_Invalid(t *testing.T) {
+	event := &model.Event{ID: "01JAQ6Y4X2ZC5W8N3V7T0MBR9K", UserID: 1, Title: "original", Date: time.Now()}
+
+	err := ApplyEventMergePatch(event, []byte(`{"user_id":2}`))
+	assert.ErrorIs(t, err, model.ErrInvalidPatch)
+
+	err = ApplyEventMergePatch(event, []byte(`["title"]`))
+	assert.ErrorIs(t, err, model.ErrInvalidPatch)
+
+	err = ApplyEventMergePatch(event, []byte(`{"date":"tomorrow"}`))
+	assert.ErrorIs(t, err, model.ErrInvalidDate)
+}
@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// FromDateQuery converts DateQuery parameters into the owner and the requested date in its time zone.
+func FromDateQuery(q *dto.DateQuery) (int, time.Time, error) {
+	loc, err := fromZoneQuery(&q.ZoneQuery)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	date, err := parseDate("date", q.Date, loc)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return q.UserID, date, nil
+}
+
+// FromWeekQuery converts WeekQuery parameters into the owner, a date in the requested week in its time zone
+// and the first weekday requested for it. The first weekday is nil if the user's settings apply;
+// an ISO week always starts on Monday.
+func FromWeekQuery(q *dto.WeekQuery) (int, time.Time, *time.Weekday, error) {
+	loc, err := fromZoneQuery(&q.ZoneQuery)
+	if err != nil {
+		return 0, time.Time{}, nil, err
+	}
+
+	if len(q.Week) > 0 {
+		monday, err := model.ParseISOWeek(q.Week, loc)
+		if err != nil {
+			return 0, time.Time{}, nil, model.NewValidationError("week", model.ErrInvalidWeek)
+		}
+		weekStart := time.Monday
+		return q.UserID, monday, &weekStart, nil
+	}
+
+	date, err := parseDate("date", q.Date, loc)
+	if err != nil {
+		return 0, time.Time{}, nil, err
+	}
+
+	if len(q.WeekStart) == 0 {
+		return q.UserID, date, nil, nil
+	}
+
+	weekStart, err := model.ParseWeekday(q.WeekStart)
+	if err != nil {
+		return 0, time.Time{}, nil, model.NewValidationError("week_start", model.ErrInvalidWeekday)
+	}
+
+	return q.UserID, date, &weekStart, nil
+}
+
+// FromRangeQuery converts RangeQuery parameters into the owner and a half-open [from, to) interval
+// covering both requested days in full.
+func FromRangeQuery(q *dto.RangeQuery) (int, time.Time, time.Time, error) {
+	loc, err := fromZoneQuery(&q.ZoneQuery)
+	if err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+
+	from, to, err := parseDayRange("from", q.From, "to", q.To, loc)
+	if err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+
+	return q.UserID, from, to, nil
+}
+
+func fromZoneQuery(q *dto.ZoneQuery) (*time.Location, error) {
+	return loadLocation("tz", q.TimeZone)
+}
+
+// loadLocation loads an IANA time zone, UTC when name is empty. Errors are reported against field.
+func loadLocation(field, name string) (*time.Location, error) {
+	if len(name) == 0 {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, model.NewValidationError(field, model.ErrInvalidTimezone)
+	}
+
+	return loc, nil
+}
+
+// parseDate parses a YYYY-MM-DD day in loc. Errors are reported against field.
+func parseDate(field, value string, loc *time.Location) (time.Time, error) {
+	date, err := time.ParseInLocation(request.DateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, model.NewValidationError(field, model.ErrInvalidDate)
+	}
+
+	return date, nil
+}
+
+// parseDayRange parses two inclusive days into a half-open [from, to) interval covering both in full.
+func parseDayRange(fromField, fromValue, toField, toValue string, loc *time.Location) (time.Time, time.Time, error) {
+	from, err := parseDate(fromField, fromValue, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	to, err := parseDate(toField, toValue, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, model.NewValidationError(toField, model.ErrInvalidRange)
+	}
+
+	return from, to.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// defaultSearchLimit is how many hits a search returns when no limit is given.
+const defaultSearchLimit = 20
+
+// FromSearchQuery converts SearchQuery parameters into a domain SearchQuery.
+// Missing ends of the date range leave it open.
+func FromSearchQuery(q *dto.SearchQuery) (*model.SearchQuery, error) {
+	loc, err := fromZoneQuery(&q.ZoneQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &model.SearchQuery{
+		UserID: q.UserID,
+		Text:   q.Q,
+		Limit:  q.Limit,
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+
+	if len(q.From) > 0 {
+		if query.From, err = parseDate("from", q.From, loc); err != nil {
+			return nil, err
+		}
+	}
+	if len(q.To) > 0 {
+		to, err := parseDate("to", q.To, loc)
+		if err != nil {
+			return nil, err
+		}
+		query.To = to.AddDate(0, 0, 1)
+	}
+
+	return query, nil
+}
+
+// ToSearchHitsResp converts search hits to SearchHit DTOs.
+func ToSearchHitsResp(hits []*model.SearchHit) []*dto.SearchHit {
+	result := make([]*dto.SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		result = append(result, &dto.SearchHit{Event: ToEventResp(hit.Event), Score: hit.Score})
+	}
+
+	return result
+}
@@ -0,0 +1,184 @@
+package converter
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// FromSettingsFields converts the writable settings of a user into a domain UserSettings model.
+// Out-of-office dates are midnights in the user's time zone, UTC when none is set.
+func FromSettingsFields(userID int, fields *dto.SettingsFields) (*model.UserSettings, error) {
+	verr := &model.ValidationError{}
+
+	weekStart, err := model.ParseWeekday(fields.WeekStart)
+	if err != nil {
+		verr.Add("week_start", model.ErrInvalidWeekday)
+	}
+
+	timeZone := cmp.Or(fields.TimeZone, model.DefaultTimeZone)
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		verr.Add("time_zone", model.ErrInvalidTimezone)
+		loc = time.UTC
+	}
+
+	settings := &model.UserSettings{
+		UserID:    userID,
+		WeekStart: weekStart,
+		Country:   strings.ToLower(fields.Country),
+		TimeZone:  timeZone,
+	}
+
+	for _, h := range fields.WorkingHours {
+		weekday, dayErr := model.ParseWeekday(h.Weekday)
+		start, startErr := model.ParseTimeOfDay(h.Start)
+		end, endErr := model.ParseTimeOfDay(h.End)
+		if dayErr != nil || startErr != nil || endErr != nil {
+			verr.Add("working_hours", model.ErrInvalidWorkingHours)
+			break
+		}
+		settings.WorkingHours = append(settings.WorkingHours, model.WorkingHours{Weekday: weekday, Start: start, End: end})
+	}
+
+	for _, o := range fields.OutOfOffice {
+		start, startErr := parseTimeIn(o.Start, loc)
+		end, endErr := parseTimeIn(o.End, loc)
+		if startErr != nil || endErr != nil {
+			verr.Add("out_of_office", model.ErrInvalidDate)
+			break
+		}
+		settings.OutOfOffice = append(settings.OutOfOffice, model.OutOfOffice{Start: start, End: end, Note: o.Note})
+	}
+
+	if err = verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// ToSettingsResp converts a domain UserSettings model to a Settings DTO for API responses.
+func ToSettingsResp(settings *model.UserSettings) *dto.Settings {
+	resp := &dto.Settings{
+		UserID:       settings.UserID,
+		WeekStart:    model.FormatWeekday(settings.WeekStart),
+		Country:      settings.Country,
+		TimeZone:     settings.Location().String(),
+		WorkingHours: make([]*dto.WorkingHours, 0, len(settings.WorkingHours)),
+		OutOfOffice:  make([]*dto.OutOfOffice, 0, len(settings.OutOfOffice)),
+	}
+	for _, h := range settings.WorkingHours {
+		resp.WorkingHours = append(resp.WorkingHours, &dto.WorkingHours{
+			Weekday: model.FormatWeekday(h.Weekday),
+			Start:   model.FormatTimeOfDay(h.Start),
+			End:     model.FormatTimeOfDay(h.End),
+		})
+	}
+	for _, o := range settings.OutOfOffice {
+		resp.OutOfOffice = append(resp.OutOfOffice, &dto.OutOfOffice{
+			Start: o.Start.Format(time.RFC3339),
+			End:   o.End.Format(time.RFC3339),
+			Note:  o.Note,
+		})
+	}
+
+	return resp
+}
+
+// FromOverlapQuery converts OverlapQuery parameters into the users and a half-open [from, to) interval
+// covering both requested days in full.
+func FromOverlapQuery(q *dto.OverlapQuery) ([]int, time.Time, time.Time, error) {
+	loc, err := fromZoneQuery(&q.ZoneQuery)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+
+	userIDs, err := parseUserIDs("user_ids", q.UserIDs)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+
+	from, to, err := parseDayRange("from", q.From, "to", q.To, loc)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+
+	return userIDs, from, to, nil
+}
+
+// ToWorkingHoursOverlapResp converts a domain WorkingHoursOverlap to its DTO, with times in loc.
+func ToWorkingHoursOverlapResp(overlap *model.WorkingHoursOverlap, loc *time.Location) *dto.WorkingHoursOverlap {
+	resp := &dto.WorkingHoursOverlap{
+		Users:   make([]*dto.UserWorkingTime, 0, len(overlap.Users)),
+		Overlap: ToIntervalsResp(overlap.Overlap, loc),
+	}
+	for _, user := range overlap.Users {
+		resp.Users = append(resp.Users, &dto.UserWorkingTime{
+			UserID:   user.UserID,
+			TimeZone: user.TimeZone,
+			Working:  ToIntervalsResp(user.Working, loc),
+		})
+	}
+
+	return resp
+}
+
+// ToIntervalsResp converts domain Intervals to DTOs with times in loc.
+func ToIntervalsResp(intervals []model.Interval, loc *time.Location) []*dto.Interval {
+	result := make([]*dto.Interval, 0, len(intervals))
+	for _, iv := range intervals {
+		result = append(result, &dto.Interval{
+			Start: iv.Start.In(loc).Format(time.RFC3339),
+			End:   iv.End.In(loc).Format(time.RFC3339),
+		})
+	}
+
+	return result
+}
+
+// ToWarningsResp converts domain Warnings to DTOs; none is nil so that responses leave them out.
+func ToWarningsResp(warnings []*model.Warning) []*dto.Warning {
+	var result []*dto.Warning
+	for _, w := range warnings {
+		result = append(result, &dto.Warning{Code: w.Code, Detail: w.Message})
+	}
+
+	return result
+}
+
+// parseTimeIn parses a date, taken as midnight in loc, or an RFC 3339 date-time.
+func parseTimeIn(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation(request.DateLayout, value, loc); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseUserIDs parses comma-separated user ids. Errors are reported against field.
+func parseUserIDs(field, value string) ([]int, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) > model.MaxOverlapUsers {
+		return nil, model.NewValidationError(field, model.ErrTooManyUsers)
+	}
+
+	result := make([]int, 0, len(parts))
+	for _, part := range parts {
+		userID, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || userID <= 0 {
+			return nil, model.NewValidationError(field, model.ErrInvalidUserID)
+		}
+		if !slices.Contains(result, userID) {
+			result = append(result, userID)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/stream/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// ToMessageResp converts a domain ChangeMessage to a stream Message DTO.
+func ToMessageResp(msg *model.ChangeMessage) *dto.Message {
+	if msg.Reset {
+		return &dto.Message{ID: msg.ID, Type: dto.ResetType}
+	}
+
+	return &dto.Message{
+		ID:         msg.ID,
+		Type:       string(msg.Change.Type),
+		OccurredAt: msg.Change.OccurredAt.Format(time.RFC3339Nano),
+		Event:      ToEventResp(&msg.Change.Event),
+	}
+}
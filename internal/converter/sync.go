@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+const syncTokenPrefix = "v1:"
+
+// FromSyncToken decodes an opaque sync token into a change sequence position.
+// An empty token means a full sync and decodes to zero.
+func FromSyncToken(token string) (int64, error) {
+	if len(token) == 0 {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), syncTokenPrefix) {
+		return 0, model.ErrInvalidSyncToken
+	}
+
+	seq, err := strconv.ParseInt(strings.TrimPrefix(string(raw), syncTokenPrefix), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, model.ErrInvalidSyncToken
+	}
+
+	return seq, nil
+}
+
+// ToSyncToken encodes a change sequence position as an opaque sync token.
+func ToSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(syncTokenPrefix + strconv.FormatInt(seq, 10)))
+}
+
+// ToSyncResp converts a domain SyncResult to a SyncResponse DTO.
+func ToSyncResp(res *model.SyncResult) *dto.SyncResponse {
+	return &dto.SyncResponse{
+		Events:        ToEventsResp(res.Upserts),
+		Deleted:       res.Deleted,
+		LegacyDeleted: res.LegacyDeleted,
+		Token:         ToSyncToken(res.Seq),
+	}
+}
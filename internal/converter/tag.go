@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/api/request"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// FromCreateTagReq converts a CreateTagRequest DTO to a domain Tag model.
+func FromCreateTagReq(req *dto.CreateTagRequest) *model.Tag {
+	return &model.Tag{
+		UserID:   req.UserID,
+		Name:     model.NormalizeTag(req.Name),
+		Category: model.NormalizeTag(req.Category),
+		Color:    strings.ToLower(req.Color),
+	}
+}
+
+// FromTagFields converts the writable fields of a tag replacement into a domain Tag model.
+func FromTagFields(userID int, fields *dto.TagFields) *model.Tag {
+	return &model.Tag{
+		UserID:   userID,
+		Name:     model.NormalizeTag(fields.Name),
+		Category: model.NormalizeTag(fields.Category),
+		Color:    strings.ToLower(fields.Color),
+	}
+}
+
+// ToTagResp converts a domain Tag model to a Tag DTO for API responses.
+func ToTagResp(tag *model.Tag) *dto.Tag {
+	return &dto.Tag{
+		UserID:   tag.UserID,
+		Name:     tag.Name,
+		Category: tag.Category,
+		Color:    tag.Color,
+	}
+}
+
+// ToTagsResp converts a slice of domain Tag models to a slice of Tag DTOs.
+func ToTagsResp(tags []*model.Tag) []*dto.Tag {
+	result := make([]*dto.Tag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, ToTagResp(tag))
+	}
+
+	return result
+}
+
+// FromTagQuery converts the tag filters of a range query into a domain TagFilter.
+func FromTagQuery(q *dto.TagQuery) (model.TagFilter, error) {
+	include, err := parseTagList("tags", q.Tags)
+	if err != nil {
+		return model.TagFilter{}, err
+	}
+
+	exclude, err := parseTagList("exclude_tags", q.ExcludeTags)
+	if err != nil {
+		return model.TagFilter{}, err
+	}
+
+	return model.TagFilter{Include: include, Exclude: exclude}, nil
+}
+
+// FromStatsQuery converts StatsQuery parameters into a domain TagStatsQuery.
+func FromStatsQuery(q *dto.StatsQuery) (*model.TagStatsQuery, error) {
+	userID, from, to, err := FromRangeQuery(&q.RangeQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := FromTagQuery(&q.TagQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	period := model.Period(q.Period)
+	if len(period) == 0 {
+		period = model.PeriodWeek
+	}
+
+	return &model.TagStatsQuery{
+		UserID: userID,
+		From:   from,
+		To:     to,
+		Period: period,
+		Filter: filter,
+	}, nil
+}
+
+// ToPeriodStatsResp converts per-period statistics to PeriodStats DTOs.
+func ToPeriodStatsResp(stats []*model.TagStats) []*dto.PeriodStats {
+	result := make([]*dto.PeriodStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, &dto.PeriodStats{
+			Start:      s.Start.Format(request.DateLayout),
+			Total:      s.Total,
+			Untagged:   s.Untagged,
+			Tags:       s.Tags,
+			Categories: s.Categories,
+		})
+	}
+
+	return result
+}
+
+// parseTagList parses a comma-separated list of tags. Errors are reported against field.
+func parseTagList(field, value string) ([]string, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	tags := model.NormalizeTags(strings.Split(value, ","))
+	for _, tag := range tags {
+		if !model.ValidTagName(tag) {
+			return nil, model.NewValidationError(field, model.ErrInvalidTag)
+		}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,114 @@
+package converter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/calendar/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// FromCreateTemplateReq converts a CreateTemplateRequest DTO to a domain EventTemplate model.
+func FromCreateTemplateReq(req *dto.CreateTemplateRequest) (*model.EventTemplate, error) {
+	return FromTemplateFields(0, req.UserID, &dto.TemplateFields{
+		Name:         req.Name,
+		Title:        req.Title,
+		Duration:     req.Duration,
+		AllDay:       req.AllDay,
+		Tags:         req.Tags,
+		Description:  req.Description,
+		Location:     req.Location,
+		URL:          req.URL,
+		Status:       req.Status,
+		Transparency: req.Transparency,
+		Visibility:   req.Visibility,
+	})
+}
+
+// FromTemplateFields converts the writable fields of a template replacement into a domain EventTemplate model.
+func FromTemplateFields(templateID, userID int, fields *dto.TemplateFields) (*model.EventTemplate, error) {
+	template := &model.EventTemplate{
+		ID:     templateID,
+		UserID: userID,
+		Name:   strings.TrimSpace(fields.Name),
+		Title:  fields.Title,
+		AllDay: fields.AllDay,
+		Tags:   model.NormalizeTags(fields.Tags),
+
+		Description:  fields.Description,
+		Location:     fields.Location,
+		URL:          fields.URL,
+		Status:       model.EventStatus(fields.Status),
+		Transparency: model.Transparency(fields.Transparency),
+		Visibility:   model.Visibility(fields.Visibility),
+	}
+
+	if len(fields.Duration) > 0 {
+		duration, err := time.ParseDuration(fields.Duration)
+		if err != nil {
+			return nil, model.NewValidationError("duration", model.ErrInvalidDuration)
+		}
+		template.Duration = duration
+	}
+
+	return template, nil
+}
+
+// ToTemplateResp converts a domain EventTemplate model to a Template DTO for API responses.
+func ToTemplateResp(template *model.EventTemplate) *dto.Template {
+	return &dto.Template{
+		ID:       template.ID,
+		UserID:   template.UserID,
+		Name:     template.Name,
+		Title:    template.Title,
+		Duration: formatDuration(template.Duration),
+		AllDay:   template.AllDay,
+		Tags:     toTags(template.Tags),
+
+		Description:  template.Description,
+		Location:     template.Location,
+		URL:          template.URL,
+		Status:       string(template.Status),
+		Transparency: string(template.Transparency),
+		Visibility:   string(template.Visibility),
+	}
+}
+
+// ToTemplatesResp converts a slice of domain EventTemplate models to a slice of Template DTOs.
+func ToTemplatesResp(templates []*model.EventTemplate) []*dto.Template {
+	result := make([]*dto.Template, 0, len(templates))
+	for _, template := range templates {
+		result = append(result, ToTemplateResp(template))
+	}
+
+	return result
+}
+
+// FromCreateFromTemplateReq parses the start of an event made from a template. It is a date, taken
+// as midnight in UTC, or an RFC 3339 date-time.
+func FromCreateFromTemplateReq(req *dto.CreateFromTemplateRequest) (time.Time, error) {
+	start, err := parseEventTime(req.Date, false)
+	if err != nil {
+		return time.Time{}, model.NewValidationError("date", err)
+	}
+
+	return start, nil
+}
+
+// formatDuration formats d the way time.ParseDuration reads it back, without trailing zero units:
+// "1h30m" rather than "1h30m0s". Zero is empty.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
@@ -0,0 +1,84 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/webhook/dto"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// FromCreateSubscriptionReq converts a CreateSubscriptionRequest DTO to a domain Subscription model.
+func FromCreateSubscriptionReq(req *dto.CreateSubscriptionRequest) (*model.Subscription, error) {
+	sub := &model.Subscription{
+		UserID: req.UserID,
+		URL:    req.URL,
+		Secret: req.Secret,
+	}
+	for _, t := range req.Events {
+		sub.Events = append(sub.Events, model.ChangeType(t))
+	}
+
+	err := sub.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// ToSubscriptionResp converts a domain Subscription model to a Subscription DTO. The secret is never returned.
+func ToSubscriptionResp(sub *model.Subscription) *dto.Subscription {
+	events := make([]string, 0, len(sub.Events))
+	for _, t := range sub.Events {
+		events = append(events, string(t))
+	}
+
+	return &dto.Subscription{
+		ID:        sub.ID,
+		UserID:    sub.UserID,
+		URL:       sub.URL,
+		Events:    events,
+		CreatedAt: sub.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToSubscriptionsResp converts a slice of domain Subscription models to a slice of Subscription DTOs.
+func ToSubscriptionsResp(subs []*model.Subscription) []*dto.Subscription {
+	result := make([]*dto.Subscription, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, ToSubscriptionResp(sub))
+	}
+
+	return result
+}
+
+// ToDeliveryResp converts a domain Delivery model to a Delivery DTO.
+func ToDeliveryResp(d *model.Delivery) *dto.Delivery {
+	resp := &dto.Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.Change.Event.ID,
+		Type:           string(d.Change.Type),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		LastStatusCode: d.LastStatusCode,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      d.UpdatedAt.Format(time.RFC3339),
+	}
+	if d.Status == model.DeliveryPending {
+		resp.NextAttemptAt = d.NextAttemptAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
+
+// ToDeliveriesResp converts a slice of domain Delivery models to a slice of Delivery DTOs.
+func ToDeliveriesResp(deliveries []*model.Delivery) []*dto.Delivery {
+	result := make([]*dto.Delivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, ToDeliveryResp(d))
+	}
+
+	return result
+}
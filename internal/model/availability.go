@@ -0,0 +1,182 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Limits of the working hours and out-of-office periods of a user.
+const (
+	MaxWorkingHours = 14
+	MaxOutOfOffice  = 100
+	MaxNoteLength   = 1024
+	MaxOverlapUsers = 50
+)
+
+// WarningOutOfOffice is the code of the warning about an event created while its owner is out of office.
+const WarningOutOfOffice = "out_of_office"
+
+// Errors returned by working hours and out-of-office periods.
+var (
+	ErrInvalidTimeOfDay    = NewError(KindInvalid, "invalid_time_of_day", "time of day must be HH:MM from 00:00 to 24:00")
+	ErrInvalidWorkingHours = NewError(KindInvalid, "invalid_working_hours", "working hours must end after they start")
+	ErrOverlappingHours    = NewError(KindInvalid, "overlapping_working_hours", "working hours of a weekday overlap")
+	ErrTooManyWorkingHours = NewError(KindInvalid, "too_many_working_hours", "too many working hours")
+	ErrInvalidOutOfOffice  = NewError(KindInvalid, "invalid_out_of_office", "out-of-office period must end after it starts")
+	ErrTooManyOutOfOffice  = NewError(KindInvalid, "too_many_out_of_office", "too many out-of-office periods")
+	ErrTooManyUsers        = NewError(KindInvalid, "too_many_users", "too many users")
+)
+
+var timeOfDayPattern = regexp.MustCompile(`^(\d{2}):(\d{2})$`)
+
+// ParseTimeOfDay parses a wall-clock time HH:MM into its offset from midnight. 24:00 stands for the
+// midnight that ends a day.
+func ParseTimeOfDay(value string) (time.Duration, error) {
+	m := timeOfDayPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, ErrInvalidTimeOfDay
+	}
+
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	if minutes > 59 || hours > 24 || hours == 24 && minutes > 0 {
+		return 0, ErrInvalidTimeOfDay
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// FormatTimeOfDay formats an offset from midnight as HH:MM.
+func FormatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// WorkingHours are the hours a user works on a weekday, from Start to the exclusive End. Both are
+// offsets from midnight in the user's time zone, read as wall-clock times on days that change the clock.
+type WorkingHours struct {
+	Weekday time.Weekday  `json:"weekday"`
+	Start   time.Duration `json:"start"`
+	End     time.Duration `json:"end"`
+}
+
+// OutOfOffice is a period from Start to the exclusive End in which a user is away.
+type OutOfOffice struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Note  string    `json:"note,omitempty"`
+}
+
+// Overlaps reports whether the period takes up any of [start, end); an instant counts if it falls inside.
+func (o OutOfOffice) Overlaps(start, end time.Time) bool {
+	if start.Equal(end) {
+		return !start.Before(o.Start) && start.Before(o.End)
+	}
+
+	return o.Start.Before(end) && o.End.After(start)
+}
+
+// Warning is a problem with a request that did not stop it, such as an event created for a user who is away.
+type Warning struct {
+	Code    string
+	Message string
+}
+
+// NewOutOfOfficeWarning creates the warning for an event in the out-of-office period o of its owner.
+func NewOutOfOfficeWarning(o OutOfOffice) *Warning {
+	message := fmt.Sprintf("the user is out of office from %s to %s", o.Start.Format(time.RFC3339), o.End.Format(time.RFC3339))
+	if len(o.Note) > 0 {
+		message += ": " + o.Note
+	}
+
+	return &Warning{Code: WarningOutOfOffice, Message: message}
+}
+
+// Interval is the time from Start to the exclusive End.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IntersectIntervals returns the time covered by both a and b. Both must be sorted and free of overlaps,
+// and so is the result.
+func IntersectIntervals(a, b []Interval) []Interval {
+	result := make([]Interval, 0)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := laterTime(a[i].Start, b[j].Start), earlierTime(a[i].End, b[j].End)
+		if start.Before(end) {
+			result = append(result, Interval{Start: start, End: end})
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return result
+}
+
+// SubtractIntervals returns the time of a that none of b covers. a must be sorted and free of overlaps,
+// and so is the result.
+func SubtractIntervals(a, b []Interval) []Interval {
+	result := slices.Clone(a)
+	for _, cut := range b {
+		next := make([]Interval, 0, len(result)+1)
+		for _, iv := range result {
+			if !cut.Start.Before(iv.End) || !cut.End.After(iv.Start) {
+				next = append(next, iv)
+				continue
+			}
+			if iv.Start.Before(cut.Start) {
+				next = append(next, Interval{Start: iv.Start, End: cut.Start})
+			}
+			if cut.End.Before(iv.End) {
+				next = append(next, Interval{Start: cut.End, End: iv.End})
+			}
+		}
+		result = next
+	}
+
+	return result
+}
+
+// appendInterval appends iv to sorted intervals, merging it with the last one if they touch.
+func appendInterval(intervals []Interval, iv Interval) []Interval {
+	if n := len(intervals); n > 0 && !intervals[n-1].End.Before(iv.Start) {
+		intervals[n-1].End = laterTime(intervals[n-1].End, iv.End)
+		return intervals
+	}
+
+	return append(intervals, iv)
+}
+
+func laterTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// UserWorkingTime is the time a user works within a range.
+type UserWorkingTime struct {
+	UserID   int
+	TimeZone string
+	Working  []Interval
+}
+
+// WorkingHoursOverlap holds the working time of several users within a range and the part of it
+// they all share.
+type WorkingHoursOverlap struct {
+	Users   []*UserWorkingTime
+	Overlap []Interval
+}
@@ -0,0 +1,32 @@
+package model
+
+// Errors returned by batch operations.
+var (
+	ErrInvalidBatchOp = NewError(KindInvalid, "invalid_batch_op", "unknown batch operation")
+	ErrBatchAborted   = NewError(KindConflict, "batch_aborted", "not applied because another operation of the batch failed")
+)
+
+// BatchOpType identifies the mutation performed by a batch operation.
+type BatchOpType string
+
+// Supported batch operation types.
+const (
+	BatchCreate BatchOpType = "create"
+	BatchUpdate BatchOpType = "update"
+	BatchDelete BatchOpType = "delete"
+)
+
+// BatchOp is a single mutation of a batch. Event carries what the matching single-event
+// call takes: the new event for a create, the replacement for an update and the
+// ID, owner and expected version for a delete.
+type BatchOp struct {
+	Type  BatchOpType
+	Event Event
+}
+
+// BatchResult is the outcome of a single batch operation. Event is the created,
+// updated or deleted event when Err is nil.
+type BatchResult struct {
+	Event *Event
+	Err   error
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// ChangeType identifies the kind of mutation applied to an event.
+type ChangeType string
+
+// Supported change types.
+const (
+	ChangeCreated ChangeType = "event.created"
+	ChangeUpdated ChangeType = "event.updated"
+	ChangeDeleted ChangeType = "event.deleted"
+)
+
+// Valid reports whether the change type is one of the supported values.
+func (t ChangeType) Valid() bool {
+	switch t {
+	case ChangeCreated, ChangeUpdated, ChangeDeleted:
+		return true
+	}
+	return false
+}
+
+// EventChange describes a single mutation of a calendar event.
+// Seq is the position of the change in the calendar's change sequence.
+type EventChange struct {
+	Seq        int64      `json:"seq"`
+	Type       ChangeType `json:"type"`
+	Event      Event      `json:"event"`
+	OccurredAt time.Time  `json:"occurred_at"`
+}
+
+// ChangeMessage is an EventChange numbered for delivery over a change stream.
+// Reset messages carry no change and tell the client its resume point was lost,
+// so it must reload its data before applying further changes.
+type ChangeMessage struct {
+	ID     int64       `json:"id"`
+	Reset  bool        `json:"reset,omitempty"`
+	Change EventChange `json:"change"`
+}
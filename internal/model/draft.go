@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxTextLength is the longest text an event draft is read from, in characters.
+const MaxTextLength = 500
+
+// Errors returned when reading an event from text.
+var (
+	ErrEmptyText      = NewError(KindInvalid, "empty_text", "empty text")
+	ErrDateNotFound   = NewError(KindInvalid, "date_not_found", "no date or time found in the text")
+	ErrTimeNotFound   = NewError(KindInvalid, "time_not_found", "the text gives a duration shorter than a day but no time")
+	ErrAmountTooLarge = NewError(KindInvalid, "amount_too_large", "the text gives a relative date, duration or interval longer than 100 years")
+)
+
+// WarningRecurrenceIgnored is the code of the warning about a recurrence the created event does not have.
+const WarningRecurrenceIgnored = "recurrence_ignored"
+
+// Frequency is the period a recurrence repeats in.
+type Frequency string
+
+// Recurrence frequencies.
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// rruleDays are the iCalendar abbreviations of the weekdays, Sunday first.
+var rruleDays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Recurrence tells how an event repeats: every Interval periods of Frequency, on Weekdays when
+// the weekly recurrence names them. An Interval of 0 or 1 is every period.
+type Recurrence struct {
+	Frequency Frequency
+	Interval  int
+	Weekdays  []time.Weekday
+}
+
+// RRule formats the recurrence as an iCalendar RRULE value such as FREQ=WEEKLY;INTERVAL=2;BYDAY=MO.
+func (r Recurrence) RRule() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.Weekdays) > 0 {
+		days := make([]string, 0, len(r.Weekdays))
+		for _, day := range r.Weekdays {
+			days = append(days, rruleDays[day])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// EventDraft is an event read from free-form text that has not been stored.
+type EventDraft struct {
+	Event *Event
+	// Location is the time zone the dates and times of the text were read in.
+	Location *time.Location
+	// Recurrence is how the text asks the event to repeat, or nil. Events do not repeat yet,
+	// so it is only reported.
+	Recurrence *Recurrence
+}
+
+// NewRecurrenceIgnoredWarning creates the warning for an event created from a draft that asked to repeat.
+func NewRecurrenceIgnoredWarning(r *Recurrence) *Warning {
+	return &Warning{
+		Code:    WarningRecurrenceIgnored,
+		Message: fmt.Sprintf("events do not repeat yet; only the first occurrence of %s was created", r.RRule()),
+	}
+}
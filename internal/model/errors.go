@@ -0,0 +1,121 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorKind classifies domain errors so the API can map them to a response status.
+type ErrorKind string
+
+// Supported error kinds.
+const (
+	KindInvalid       ErrorKind = "invalid"
+	KindNotFound      ErrorKind = "not_found"
+	KindConflict      ErrorKind = "conflict"
+	KindPrecondition  ErrorKind = "precondition_failed"
+	KindGone          ErrorKind = "gone"
+	KindUnsupported   ErrorKind = "unsupported"
+	KindUnprocessable ErrorKind = "unprocessable"
+)
+
+// Error is a domain error with a stable machine-readable code.
+// Package-level Error values are sentinels and can be matched with errors.Is.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+}
+
+// NewError creates a new domain error.
+func NewError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Common errors shared by all request payloads.
+var (
+	ErrRequired        = NewError(KindInvalid, "required", "field is required")
+	ErrInvalidValue    = NewError(KindInvalid, "invalid_value", "invalid value")
+	ErrMalformed       = NewError(KindInvalid, "malformed_request", "malformed request")
+	ErrUnsupported     = NewError(KindUnsupported, "unsupported_media_type", "unsupported media type")
+	ErrInvalidRange    = NewError(KindInvalid, "invalid_range", "end of range is before its start")
+	ErrInvalidTimezone = NewError(KindInvalid, "invalid_timezone", "invalid time zone")
+	ErrExclusive       = NewError(KindInvalid, "mutually_exclusive", "field cannot be combined with an alternative one")
+)
+
+// FieldError binds a domain error to the request field that caused it.
+type FieldError struct {
+	Field string
+	Err   *Error
+}
+
+// ValidationError collects every invalid field of a request or model.
+// errors.Is matches it against the domain error of any of its fields.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// NewValidationError creates a ValidationError for a single field.
+func NewValidationError(field string, err *Error) *ValidationError {
+	return &ValidationError{Fields: []FieldError{{Field: field, Err: err}}}
+}
+
+// Add appends a field error.
+func (e *ValidationError) Add(field string, err *Error) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Err: err})
+}
+
+// OrNil returns the ValidationError if it holds any field errors and nil otherwise.
+func (e *ValidationError) OrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Err.Error())
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// Unwrap returns the domain errors of all fields.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		errs = append(errs, f.Err)
+	}
+
+	return errs
+}
+
+// AsError returns the domain error carried by err, if any.
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+
+	return nil, false
+}
+
+// PublicMessage returns the message of err to show to API clients. A not-found error shows only the message
+// of its sentinel: wrapped context such as "user id not match" would tell another user's resource apart
+// from a missing one.
+func PublicMessage(err error) string {
+	if domainErr, ok := AsError(err); ok && domainErr.Kind == KindNotFound {
+		return domainErr.Message
+	}
+
+	return err.Error()
+}
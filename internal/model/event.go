@@ -1,39 +1,339 @@
 package model
 
 import (
-	"errors"
+	"encoding/json"
+	"net/url"
+	"strconv"
 	"time"
+	"unicode/utf8"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Limits of the free-text fields of an event, in characters.
+const (
+	MaxDescriptionLength = 8192
+	MaxLocationLength    = 1024
+	MaxURLLength         = 2048
 )
 
 // Common errors returned by event operations.
 var (
-	ErrEventNotFound = errors.New("event not found")
-	ErrInvalidUserID = errors.New("invalid user id")
-	ErrEmptyTitle    = errors.New("empty title")
-	ErrInvalidDate   = errors.New("invalid date")
+	ErrEventNotFound       = NewError(KindNotFound, "event_not_found", "event not found")
+	ErrInvalidEventID      = NewError(KindInvalid, "invalid_event_id", "invalid event id")
+	ErrInvalidUserID       = NewError(KindInvalid, "invalid_user_id", "invalid user id")
+	ErrEmptyTitle          = NewError(KindInvalid, "empty_title", "empty title")
+	ErrInvalidDate         = NewError(KindInvalid, "invalid_date", "invalid date")
+	ErrVersionConflict     = NewError(KindPrecondition, "version_conflict", "version conflict")
+	ErrInvalidVersion      = NewError(KindInvalid, "invalid_version", "invalid version")
+	ErrInvalidPatch        = NewError(KindInvalid, "invalid_patch", "invalid patch")
+	ErrTooLong             = NewError(KindInvalid, "too_long", "value is too long")
+	ErrInvalidEventStatus  = NewError(KindInvalid, "invalid_status", "status must be confirmed, tentative or cancelled")
+	ErrInvalidTransparency = NewError(KindInvalid, "invalid_transparency", "transparency must be busy or free")
+	ErrInvalidVisibility   = NewError(KindInvalid, "invalid_visibility", "visibility must be public, private or confidential")
+	ErrInvalidEnd          = NewError(KindInvalid, "invalid_end", "end must be after date")
+	ErrNotWholeDay         = NewError(KindInvalid, "not_whole_day", "all-day events take dates without a time")
 )
 
+// EventStatus tells whether an event takes place.
+type EventStatus string
+
+// Event statuses.
+const (
+	StatusConfirmed EventStatus = "confirmed"
+	StatusTentative EventStatus = "tentative"
+	StatusCancelled EventStatus = "cancelled"
+)
+
+// Valid reports whether s is a known status.
+func (s EventStatus) Valid() bool {
+	return s == StatusConfirmed || s == StatusTentative || s == StatusCancelled
+}
+
+// Transparency tells whether an event blocks the owner's time in free/busy.
+type Transparency string
+
+// Event transparencies.
+const (
+	TransparencyBusy Transparency = "busy"
+	TransparencyFree Transparency = "free"
+)
+
+// Valid reports whether t is a known transparency.
+func (t Transparency) Valid() bool {
+	return t == TransparencyBusy || t == TransparencyFree
+}
+
+// Visibility tells how much of an event is shown to users other than its owner.
+type Visibility string
+
+// Event visibilities. Other users see every field of a public event, the title and time of a
+// confidential one and only the time of a private one.
+const (
+	VisibilityPublic       Visibility = "public"
+	VisibilityPrivate      Visibility = "private"
+	VisibilityConfidential Visibility = "confidential"
+)
+
+// Valid reports whether v is a known visibility.
+func (v Visibility) Valid() bool {
+	return v == VisibilityPublic || v == VisibilityPrivate || v == VisibilityConfidential
+}
+
 // Event represents a calendar event in the domain model.
+// ID is a ULID assigned when the event is stored, so IDs are unique across processes and sort by creation time.
+// LegacyID is the integer the event would have been identified by before; it is kept until clients have moved to ULIDs.
+// Version starts at 1 and grows with every update; zero in a request means "any version".
+// Empty Status, Transparency and Visibility stand for their defaults and are filled in when the event is stored.
 type Event struct {
-	ID     int       `json:"id"`
-	UserID int       `json:"user_id"`
-	Date   time.Time `json:"date"`
-	Title  string    `json:"title"`
+	ID       string    `json:"id"`
+	LegacyID int       `json:"legacy_id,omitempty"`
+	UserID   int       `json:"user_id"`
+	Date     time.Time `json:"date"`
+	Title    string    `json:"title"`
+	Version  int       `json:"version"`
+	// End is the exclusive end of the event: the instant a timed event ends or the day after the last
+	// day of an all-day one. A timed event without an end is an instant.
+	End time.Time `json:"end,omitzero"`
+	// AllDay events cover whole calendar days. Their Date and End are midnights in UTC that stand for
+	// dates and are looked at as the same dates in every time zone.
+	AllDay bool `json:"all_day,omitempty"`
+	// Tags are normalized tag names, sorted and without duplicates.
+	Tags         []string     `json:"tags,omitempty"`
+	Description  string       `json:"description,omitempty"`
+	Location     string       `json:"location,omitempty"`
+	URL          string       `json:"url,omitempty"`
+	Status       EventStatus  `json:"status,omitempty"`
+	Transparency Transparency `json:"transparency,omitempty"`
+	Visibility   Visibility   `json:"visibility,omitempty"`
+}
+
+// UnmarshalJSON reads an event, including one saved before events had ULIDs, such as in a webhook
+// outbox. The numeric id of such an event becomes both its ID and its legacy ID.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	var stored struct {
+		event
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	*e = Event(stored.event)
+
+	if len(stored.ID) == 0 || string(stored.ID) == "null" {
+		return nil
+	}
+	if stored.ID[0] == '"' {
+		return json.Unmarshal(stored.ID, &e.ID)
+	}
+
+	var legacyID int
+	if err := json.Unmarshal(stored.ID, &legacyID); err != nil {
+		return err
+	}
+	e.ID = strconv.Itoa(legacyID)
+	if e.LegacyID == 0 {
+		e.LegacyID = legacyID
+	}
+
+	return nil
+}
+
+// ValidEventID reports whether id refers to an event: a ULID in its canonical upper-case form or a legacy integer ID.
+func ValidEventID(id string) bool {
+	if parsed, err := ulid.ParseStrict(id); err == nil {
+		return parsed.String() == id
+	}
+
+	_, ok := ParseLegacyEventID(id)
+	return ok
+}
+
+// ParseLegacyEventID returns the legacy integer event ID spelled by id, which must be a positive
+// decimal number without sign or leading zeros.
+func ParseLegacyEventID(id string) (int, bool) {
+	n, err := strconv.Atoi(id)
+	if err != nil || n <= 0 || strconv.Itoa(n) != id {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// SetDefaults fills in the status, transparency and visibility left empty and makes an all-day
+// event without an end last one day.
+func (e *Event) SetDefaults() {
+	if e.AllDay && e.End.IsZero() {
+		e.End = e.Date.AddDate(0, 0, 1)
+	}
+	if len(e.Status) == 0 {
+		e.Status = StatusConfirmed
+	}
+	if len(e.Transparency) == 0 {
+		e.Transparency = TransparencyBusy
+	}
+	if len(e.Visibility) == 0 {
+		e.Visibility = VisibilityPublic
+	}
+}
+
+// Span returns the time the event takes up, from its start to its exclusive end. The days of an
+// all-day event are placed in loc; a timed event without an end starts and ends at the same instant.
+func (e *Event) Span(loc *time.Location) (time.Time, time.Time) {
+	if !e.AllDay {
+		if e.End.IsZero() {
+			return e.Date, e.Date
+		}
+		return e.Date, e.End
+	}
+
+	end := e.End
+	if end.IsZero() {
+		end = e.Date.AddDate(0, 0, 1)
+	}
+
+	return sameDate(e.Date, loc), sameDate(end, loc)
+}
+
+// Overlaps reports whether the event takes up any of [from, to). All-day events are placed in the
+// time zone of from, so that a day query sees them on their own dates whatever its zone.
+func (e *Event) Overlaps(from, to time.Time) bool {
+	start, end := e.Span(from.Location())
+	if start.Equal(end) {
+		return !start.Before(from) && start.Before(to)
+	}
+
+	return start.Before(to) && end.After(from)
+}
+
+// Continues reports whether the event, seen within [from, to), started before from and goes on after to.
+// Either makes the part within the range a continuation segment of a longer event.
+func (e *Event) Continues(from, to time.Time) (before, after bool) {
+	start, end := e.Span(from.Location())
+	return start.Before(from), end.After(to)
 }
 
-// Validate checks if the Event has valid field values.
+// Blocks reports whether the event makes its owner busy: it is neither cancelled nor marked free.
+func (e *Event) Blocks() bool {
+	return e.Status != StatusCancelled && e.Transparency != TransparencyFree
+}
+
+// Redacted returns the event as users other than its owner may see it, hiding what its visibility
+// does not share. Public events are returned as is.
+func (e *Event) Redacted() *Event {
+	if e.Visibility != VisibilityPrivate && e.Visibility != VisibilityConfidential {
+		return e
+	}
+
+	redacted := *e
+	redacted.Tags = nil
+	redacted.Description = ""
+	redacted.Location = ""
+	redacted.URL = ""
+	if e.Visibility == VisibilityPrivate {
+		redacted.Title = ""
+	}
+
+	return &redacted
+}
+
+// VersionConflictError is returned when a write is based on a stale version of an event.
+type VersionConflictError struct {
+	Current *Event
+}
+
+// Error implements the error interface.
+func (e *VersionConflictError) Error() string {
+	return ErrVersionConflict.Error()
+}
+
+// Unwrap returns ErrVersionConflict.
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// Validate checks if the Event has valid field values and reports every invalid field.
 func (e Event) Validate() error {
+	verr := &ValidationError{}
+
 	if e.UserID <= 0 {
-		return ErrInvalidUserID
+		verr.Add("user_id", ErrInvalidUserID)
 	}
 
 	if len(e.Title) == 0 {
-		return ErrEmptyTitle
+		verr.Add("title", ErrEmptyTitle)
 	}
 
 	if e.Date.IsZero() {
-		return ErrInvalidDate
+		verr.Add("date", ErrInvalidDate)
+	} else if e.AllDay && !isDate(e.Date) {
+		verr.Add("date", ErrNotWholeDay)
 	}
 
-	return nil
+	if !e.End.IsZero() {
+		switch {
+		case !e.End.After(e.Date):
+			verr.Add("end", ErrInvalidEnd)
+		case e.AllDay && !isDate(e.End):
+			verr.Add("end", ErrNotWholeDay)
+		}
+	}
+
+	e.validateDetails(verr)
+
+	return verr.OrNil()
+}
+
+// validateDetails adds the errors of the fields besides the owner, title and time to verr.
+func (e Event) validateDetails(verr *ValidationError) {
+	if err := validateTags(e.Tags); err != nil {
+		verr.Add("tags", err)
+	}
+
+	if utf8.RuneCountInString(e.Description) > MaxDescriptionLength {
+		verr.Add("description", ErrTooLong)
+	}
+
+	if utf8.RuneCountInString(e.Location) > MaxLocationLength {
+		verr.Add("location", ErrTooLong)
+	}
+
+	if len(e.URL) > MaxURLLength {
+		verr.Add("url", ErrTooLong)
+	} else if len(e.URL) > 0 && !validURL(e.URL) {
+		verr.Add("url", ErrInvalidURL)
+	}
+
+	if len(e.Status) > 0 && !e.Status.Valid() {
+		verr.Add("status", ErrInvalidEventStatus)
+	}
+
+	if len(e.Transparency) > 0 && !e.Transparency.Valid() {
+		verr.Add("transparency", ErrInvalidTransparency)
+	}
+
+	if len(e.Visibility) > 0 && !e.Visibility.Valid() {
+		verr.Add("visibility", ErrInvalidVisibility)
+	}
+}
+
+// validURL reports whether raw is an absolute http or https URL.
+func validURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) > 0
+}
+
+// isDate reports whether t is a midnight in UTC, the way dates of all-day events are stored.
+func isDate(t time.Time) bool {
+	return t.Location() == time.UTC && t.Equal(t.Truncate(24*time.Hour))
+}
+
+// sameDate returns the midnight in loc of the date that t, a midnight in UTC, stands for.
+func sameDate(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
 }
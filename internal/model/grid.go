@@ -0,0 +1,75 @@
+package model
+
+import "time"
+
+// Month grid dimensions: six weeks always cover a month whatever day it starts on.
+const (
+	GridWeeks = 6
+	// DefaultGridEvents is how many events a grid cell lists unless a query asks for another number.
+	DefaultGridEvents = 3
+)
+
+// MonthGridQuery selects the month grid of a user for the month containing Date.
+// The grid is laid out in the location of Date.
+type MonthGridQuery struct {
+	UserID int
+	Date   time.Time
+	// WeekStart is the first column of the grid; the user's settings apply when it is nil.
+	WeekStart *time.Weekday
+	Filter    TagFilter
+	// MaxEvents is how many events a cell lists; the others are only counted in its Overflow.
+	MaxEvents int
+	// Holidays is the holiday calendar marked on the grid; nil marks none.
+	Holidays *HolidayCalendar
+}
+
+// WeekViewQuery selects the week view of a user for the calendar week containing Date.
+// The days are laid out in the location of Date.
+type WeekViewQuery struct {
+	UserID int
+	Date   time.Time
+	// WeekStart is the first day of the week; the user's settings apply when it is nil.
+	WeekStart *time.Weekday
+	Filter    TagFilter
+	// Holidays is the holiday calendar marked on the view; nil marks none.
+	Holidays *HolidayCalendar
+}
+
+// WeekView is a calendar week laid out day by day.
+type WeekView struct {
+	// Start is the first day of the week.
+	Start     time.Time
+	WeekStart time.Weekday
+	Days      []*GridDay
+}
+
+// MonthGrid is a month laid out as GridWeeks rows of seven days, the way calendar UIs show it.
+// The first and last rows are filled up with days of the neighbouring months.
+type MonthGrid struct {
+	// Month is the first day of the month.
+	Month     time.Time
+	WeekStart time.Weekday
+	Weeks     [][]*GridDay
+}
+
+// GridDay is a single cell of a MonthGrid or a day of a WeekView.
+type GridDay struct {
+	Date time.Time
+	// InMonth is only set in a MonthGrid.
+	InMonth bool
+	Weekend bool
+	// Holiday reports a day off in the holiday calendar and HolidayName names its entry,
+	// which may also be a weekend day that is worked. Workday tells whether the day is worked.
+	Holiday     bool
+	HolidayName string
+	Workday     bool
+	// Events are the events taking up any of the day, all-day ones first, then by start time.
+	Events []*Event
+	// Overflow counts the events of the day left out of Events.
+	Overflow int
+}
+
+// IsWeekend reports whether day is a Saturday or a Sunday.
+func IsWeekend(day time.Weekday) bool {
+	return day == time.Saturday || day == time.Sunday
+}
@@ -0,0 +1,146 @@
+package model
+
+import (
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Errors returned by holiday calendars.
+var (
+	ErrInvalidCountry          = NewError(KindInvalid, "invalid_country", "country must be a lower-case ISO 3166 code such as ru")
+	ErrHolidayCalendarNotFound = NewError(KindNotFound, "holiday_calendar_not_found", "no holiday calendar is loaded for the country")
+	ErrUnknownCountry          = NewError(KindInvalid, "unknown_country", "no holiday calendar is loaded for the country")
+)
+
+var countryPattern = regexp.MustCompile(`^[a-z]{2}$`)
+
+// ValidCountry reports whether country is a lower-case ISO 3166-1 alpha-2 code.
+func ValidCountry(country string) bool {
+	return countryPattern.MatchString(country)
+}
+
+// HolidayKind tells a day off from a weekend day that is worked instead of a day off moved elsewhere.
+type HolidayKind string
+
+// Supported holiday kinds.
+const (
+	HolidayDayOff  HolidayKind = "holiday"
+	HolidayWorkday HolidayKind = "workday"
+)
+
+// Valid reports whether k is a known holiday kind.
+func (k HolidayKind) Valid() bool {
+	return k == HolidayDayOff || k == HolidayWorkday
+}
+
+// Holiday is a day of a production calendar that differs from the usual Monday to Friday week:
+// a public holiday or day off, or a weekend day that is a working day.
+type Holiday struct {
+	// Date is the day at midnight UTC.
+	Date time.Time
+	Name string
+	Kind HolidayKind
+}
+
+// HolidayCalendar is the read-only production calendar of a country, loaded per year.
+// Days of years that are not loaded follow the usual week with Saturday and Sunday off.
+// A nil calendar is valid and has no holidays at all.
+type HolidayCalendar struct {
+	Country string
+	// Years lists the loaded years in ascending order.
+	Years []int
+	days  map[civilDate]*Holiday
+}
+
+// civilDate identifies a day regardless of time zone.
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilDateOf(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year: year, month: month, day: day}
+}
+
+// NewHolidayCalendar creates an empty calendar of a country.
+func NewHolidayCalendar(country string) *HolidayCalendar {
+	return &HolidayCalendar{Country: country, days: make(map[civilDate]*Holiday)}
+}
+
+// AddYear records the days of a loaded year; a later day replaces an earlier one of the same date.
+func (c *HolidayCalendar) AddYear(year int, days []*Holiday) {
+	if !slices.Contains(c.Years, year) {
+		c.Years = append(c.Years, year)
+		slices.Sort(c.Years)
+	}
+
+	for _, day := range days {
+		c.days[civilDateOf(day.Date)] = day
+	}
+}
+
+// Holiday returns the calendar entry for the day of date in date's location, if there is one.
+func (c *HolidayCalendar) Holiday(date time.Time) (*Holiday, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	day, ok := c.days[civilDateOf(date)]
+	return day, ok
+}
+
+// IsWorkday reports whether the day of date is a working day.
+func (c *HolidayCalendar) IsWorkday(date time.Time) bool {
+	if day, ok := c.Holiday(date); ok {
+		return day.Kind == HolidayWorkday
+	}
+
+	return !IsWeekend(date.Weekday())
+}
+
+// Holidays returns the entries for the days of [from, to) in date order. Days are placed in the location of from.
+func (c *HolidayCalendar) Holidays(from, to time.Time) []*Holiday {
+	result := make([]*Holiday, 0)
+	if c == nil {
+		return result
+	}
+
+	for _, holiday := range c.days {
+		if day := sameDate(holiday.Date, from.Location()); !day.Before(from) && day.Before(to) {
+			result = append(result, holiday)
+		}
+	}
+	slices.SortFunc(result, func(a, b *Holiday) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return result
+}
+
+// AddWorkdays returns the day n working days after the day of date, or before it for a negative n.
+// A zero n returns the day itself if it is a working day and the next working day otherwise.
+func (c *HolidayCalendar) AddWorkdays(date time.Time, n int) time.Time {
+	day, _ := DayRange(date)
+	if n == 0 {
+		for !c.IsWorkday(day) {
+			day = day.AddDate(0, 0, 1)
+		}
+		return day
+	}
+
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		day = day.AddDate(0, 0, step)
+		if c.IsWorkday(day) {
+			n--
+		}
+	}
+
+	return day
+}
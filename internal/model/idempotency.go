@@ -0,0 +1,21 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// Errors returned for requests carrying an idempotency key.
+var (
+	ErrInvalidIdempotencyKey = NewError(KindInvalid, "invalid_idempotency_key", "idempotency key must be 1 to 255 characters long")
+	ErrIdempotencyKeyInUse   = NewError(KindConflict, "idempotency_key_in_use", "a request with this idempotency key is still being processed")
+	ErrIdempotencyKeyReused  = NewError(KindUnprocessable, "idempotency_key_reused", "idempotency key was already used for a different request")
+)
+
+// StoredResponse is the response of a completed write request kept for replaying it on a retry.
+type StoredResponse struct {
+	Status    int
+	Header    http.Header
+	Body      []byte
+	ExpiresAt time.Time
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultWeekStart is the first day of the week unless a user or a request chooses another.
+const DefaultWeekStart = time.Monday
+
+// MaxRangePeriods limits how many days, weeks or months a single statistics or availability request may span.
+const MaxRangePeriods = 1000
+
+// Errors returned for malformed week parameters.
+var (
+	ErrInvalidWeek    = NewError(KindInvalid, "invalid_week", "week must be an ISO week formatted as YYYY-Www")
+	ErrInvalidWeekday = NewError(KindInvalid, "invalid_weekday", "weekday must be a day name such as monday or sunday")
+)
+
+var isoWeekPattern = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+
+// DayRange returns the calendar day containing date as the half-open interval [from, to) in date's location.
+func DayRange(date time.Time) (time.Time, time.Time) {
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return from, from.AddDate(0, 0, 1)
+}
+
+// CheckRangeDays returns ErrRangeTooLarge for the to field if [from, to) spans more than MaxRangePeriods days.
+func CheckRangeDays(from, to time.Time) error {
+	if to.After(from.AddDate(0, 0, MaxRangePeriods)) {
+		return NewValidationError("to", ErrRangeTooLarge)
+	}
+
+	return nil
+}
+
+// WeekRange returns the calendar week containing date for weeks starting on weekStart.
+func WeekRange(date time.Time, weekStart time.Weekday) (time.Time, time.Time) {
+	from, _ := DayRange(date)
+	from = from.AddDate(0, 0, -(int(from.Weekday()-weekStart)+7)%7)
+	return from, from.AddDate(0, 0, 7)
+}
+
+// MonthRange returns the calendar month containing date.
+func MonthRange(date time.Time) (time.Time, time.Time) {
+	from := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	return from, from.AddDate(0, 1, 0)
+}
+
+// ParseISOWeek parses an ISO 8601 week such as 2026-W42 and returns its Monday in loc.
+func ParseISOWeek(value string, loc *time.Location) (time.Time, error) {
+	match := isoWeekPattern.FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, ErrInvalidWeek
+	}
+
+	year, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+	if week < 1 || week > isoWeeksInYear(year) {
+		return time.Time{}, ErrInvalidWeek
+	}
+
+	// January 4th always falls into the first ISO week.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	monday, _ := WeekRange(jan4, time.Monday)
+	return monday.AddDate(0, 0, 7*(week-1)), nil
+}
+
+// FormatISOWeek formats the ISO 8601 week containing date, e.g. 2026-W42.
+func FormatISOWeek(date time.Time) string {
+	year, week := date.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// isoWeeksInYear returns 52 or 53: December 28th always falls into the last ISO week.
+func isoWeeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// ParseWeekday parses a lower-case English day name such as monday.
+func ParseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if name == FormatWeekday(day) {
+			return day, nil
+		}
+	}
+
+	return 0, ErrInvalidWeekday
+}
+
+// FormatWeekday formats a day as its lower-case English name.
+func FormatWeekday(day time.Weekday) string {
+	return strings.ToLower(day.String())
+}
+
+// ValidWeekday reports whether day is one of the seven days of the week.
+func ValidWeekday(day time.Weekday) bool {
+	return day >= time.Sunday && day <= time.Saturday
+}
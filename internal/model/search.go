@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// ErrEmptySearchQuery is returned for a search query without a single word in it.
+var ErrEmptySearchQuery = NewError(KindInvalid, "empty_search_query", "search query has no words")
+
+// SearchQuery selects a user's events by the words in their text fields.
+// Events overlapping [From, To) match, like in range queries; a zero From or To leaves the range open at that end.
+// A zero Limit returns every match.
+type SearchQuery struct {
+	UserID int
+	Text   string
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
+
+// SearchHit is an event matched by a search together with its relevance; higher scores rank first.
+type SearchHit struct {
+	Event *Event
+	Score float64
+}
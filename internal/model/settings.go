@@ -0,0 +1,173 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultTimeZone is the time zone of users who have not set one.
+const DefaultTimeZone = "UTC"
+
+// UserSettings holds the calendar preferences of a user.
+type UserSettings struct {
+	UserID int `json:"user_id"`
+	// WeekStart is the first day of the user's weeks, used by the week views and weekly statistics.
+	WeekStart time.Weekday `json:"week_start"`
+	// Country selects the holiday calendar overlaid on the user's views; none when empty.
+	Country string `json:"country,omitempty"`
+	// TimeZone is the IANA time zone the working hours are in.
+	TimeZone string `json:"time_zone"`
+	// WorkingHours are the weekly hours the user works. A user without any works at any time.
+	WorkingHours []WorkingHours `json:"working_hours,omitempty"`
+	// OutOfOffice are the periods the user is away, whatever the working hours.
+	OutOfOffice []OutOfOffice `json:"out_of_office,omitempty"`
+}
+
+// DefaultUserSettings returns the settings of a user who has not saved any.
+func DefaultUserSettings(userID int) *UserSettings {
+	return &UserSettings{UserID: userID, WeekStart: DefaultWeekStart, TimeZone: DefaultTimeZone}
+}
+
+// Clone returns a copy of the settings that shares no slices with them.
+func (s *UserSettings) Clone() *UserSettings {
+	result := *s
+	result.WorkingHours = slices.Clone(s.WorkingHours)
+	result.OutOfOffice = slices.Clone(s.OutOfOffice)
+
+	return &result
+}
+
+// Location returns the time zone of the working hours; UTC if it is unset or unknown.
+func (s *UserSettings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// WorkingTime returns the time in [from, to) the user works and is not out of office, in order and
+// in the time zone of from.
+func (s *UserSettings) WorkingTime(from, to time.Time) []Interval {
+	working := make([]Interval, 0)
+	if len(s.WorkingHours) == 0 {
+		working = append(working, Interval{Start: from, End: to})
+	} else {
+		hours := slices.SortedFunc(slices.Values(s.WorkingHours), func(a, b WorkingHours) int {
+			return cmp.Compare(a.Start, b.Start)
+		})
+
+		loc := s.Location()
+		first := from.In(loc)
+		for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+			for _, h := range hours {
+				if h.Weekday != day.Weekday() {
+					continue
+				}
+				start, end := wallClock(day, h.Start).In(from.Location()), wallClock(day, h.End).In(from.Location())
+				iv := Interval{Start: laterTime(start, from), End: earlierTime(end, to)}
+				if iv.Start.Before(iv.End) {
+					working = appendInterval(working, iv)
+				}
+			}
+		}
+	}
+
+	away := make([]Interval, 0, len(s.OutOfOffice))
+	for _, o := range s.OutOfOffice {
+		away = append(away, Interval{Start: o.Start, End: o.End})
+	}
+
+	return SubtractIntervals(working, away)
+}
+
+// OutOfOfficeDuring returns the out-of-office periods that take up any of [start, end).
+func (s *UserSettings) OutOfOfficeDuring(start, end time.Time) []OutOfOffice {
+	var result []OutOfOffice
+	for _, o := range s.OutOfOffice {
+		if o.Overlaps(start, end) {
+			result = append(result, o)
+		}
+	}
+
+	return result
+}
+
+// wallClock returns the time offset past midnight of day read as a wall-clock time in its time zone.
+func wallClock(day time.Time, offset time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+// Validate checks if the UserSettings have valid field values and reports every invalid field.
+func (s UserSettings) Validate() error {
+	verr := &ValidationError{}
+
+	if s.UserID <= 0 {
+		verr.Add("user_id", ErrInvalidUserID)
+	}
+
+	if !ValidWeekday(s.WeekStart) {
+		verr.Add("week_start", ErrInvalidWeekday)
+	}
+
+	if len(s.Country) > 0 && !ValidCountry(s.Country) {
+		verr.Add("country", ErrInvalidCountry)
+	}
+
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		verr.Add("time_zone", ErrInvalidTimezone)
+	}
+
+	if err := validateWorkingHours(s.WorkingHours); err != nil {
+		verr.Add("working_hours", err)
+	}
+
+	if err := validateOutOfOffice(s.OutOfOffice); err != nil {
+		verr.Add("out_of_office", err)
+	}
+
+	return verr.OrNil()
+}
+
+func validateWorkingHours(hours []WorkingHours) *Error {
+	if len(hours) > MaxWorkingHours {
+		return ErrTooManyWorkingHours
+	}
+
+	for _, h := range hours {
+		if !ValidWeekday(h.Weekday) || h.Start < 0 || h.End > 24*time.Hour || h.Start >= h.End {
+			return ErrInvalidWorkingHours
+		}
+	}
+
+	sorted := slices.SortedFunc(slices.Values(hours), func(a, b WorkingHours) int {
+		return cmp.Or(cmp.Compare(a.Weekday, b.Weekday), cmp.Compare(a.Start, b.Start))
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Weekday == sorted[i-1].Weekday && sorted[i].Start < sorted[i-1].End {
+			return ErrOverlappingHours
+		}
+	}
+
+	return nil
+}
+
+func validateOutOfOffice(periods []OutOfOffice) *Error {
+	if len(periods) > MaxOutOfOffice {
+		return ErrTooManyOutOfOffice
+	}
+
+	for _, o := range periods {
+		if !o.End.After(o.Start) {
+			return ErrInvalidOutOfOffice
+		}
+		if utf8.RuneCountInString(o.Note) > MaxNoteLength {
+			return ErrTooLong
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Common errors returned by sync operations.
+var (
+	ErrInvalidSyncToken = NewError(KindInvalid, "invalid_sync_token", "invalid sync token")
+	ErrSyncTokenExpired = NewError(KindGone, "sync_token_expired", "sync token expired")
+)
+
+// Tombstone records a deleted event so that incremental sync can report it.
+type Tombstone struct {
+	EventID       string    `json:"event_id"`
+	LegacyEventID int       `json:"legacy_event_id"`
+	UserID        int       `json:"user_id"`
+	Seq           int64     `json:"seq"`
+	DeletedAt     time.Time `json:"deleted_at"`
+}
+
+// SyncResult holds the changes of a user's events since a point in the change sequence.
+type SyncResult struct {
+	Upserts []*Event
+	Deleted []string
+	// LegacyDeleted holds the legacy integer IDs of the deleted events in the order of Deleted.
+	LegacyDeleted []int
+	// Seq is the position in the change sequence the result is consistent with.
+	Seq int64
+}
@@ -0,0 +1,187 @@
+package model
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxEventTags is the most tags a single event can carry.
+const MaxEventTags = 20
+
+// Errors returned by tag operations.
+var (
+	ErrInvalidTag      = NewError(KindInvalid, "invalid_tag", "tag must be 1 to 64 lower-case letters, digits, '-' or '_'")
+	ErrTooManyTags     = NewError(KindInvalid, "too_many_tags", "too many tags")
+	ErrInvalidCategory = NewError(KindInvalid, "invalid_category", "category must be up to 64 lower-case letters, digits, '-' or '_'")
+	ErrInvalidColor    = NewError(KindInvalid, "invalid_color", "color must be formatted as #rrggbb")
+	ErrTagNotFound     = NewError(KindNotFound, "tag_not_found", "tag not found")
+	ErrTagExists       = NewError(KindConflict, "tag_exists", "tag already exists")
+	ErrInvalidPeriod   = NewError(KindInvalid, "invalid_period", "period must be day, week or month")
+	ErrRangeTooLarge   = NewError(KindInvalid, "range_too_large", "range spans too many periods")
+)
+
+var (
+	tagPattern   = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]{1,64}$`)
+	colorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+)
+
+// Tag is a user-defined label for events. Tags are identified by their name within a user's
+// calendar and can be grouped into categories, e.g. the tags "algorithms" and "physics"
+// in the category "study". Tags used on an event are created implicitly.
+type Tag struct {
+	UserID   int    `json:"user_id"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color,omitempty"`
+}
+
+// Validate checks if the Tag has valid field values and reports every invalid field.
+func (t Tag) Validate() error {
+	verr := &ValidationError{}
+
+	if t.UserID <= 0 {
+		verr.Add("user_id", ErrInvalidUserID)
+	}
+
+	if !ValidTagName(t.Name) {
+		verr.Add("name", ErrInvalidTag)
+	}
+
+	if len(t.Category) > 0 && !ValidTagName(t.Category) {
+		verr.Add("category", ErrInvalidCategory)
+	}
+
+	if len(t.Color) > 0 && !colorPattern.MatchString(t.Color) {
+		verr.Add("color", ErrInvalidColor)
+	}
+
+	return verr.OrNil()
+}
+
+// ValidTagName reports whether name is a valid normalized tag or category name.
+func ValidTagName(name string) bool {
+	return tagPattern.MatchString(name)
+}
+
+// NormalizeTag returns the canonical form of a tag or category name.
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTags normalizes tag names and returns them sorted and without duplicates.
+// Empty names are dropped; nil stays nil.
+func NormalizeTags(names []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = NormalizeTag(name); len(name) > 0 {
+			result = append(result, name)
+		}
+	}
+	slices.Sort(result)
+
+	return slices.Compact(result)
+}
+
+// validateTags reports whether names are valid event tags.
+func validateTags(names []string) *Error {
+	if len(names) > MaxEventTags {
+		return ErrTooManyTags
+	}
+
+	for _, name := range names {
+		if !ValidTagName(name) {
+			return ErrInvalidTag
+		}
+	}
+
+	return nil
+}
+
+// TagFilter selects events by their tags. An event matches if it has any of the Include tags,
+// or Include is empty, and none of the Exclude tags.
+type TagFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// Match reports whether event passes the filter.
+func (f TagFilter) Match(event *Event) bool {
+	for _, tag := range f.Exclude {
+		if slices.Contains(event.Tags, tag) {
+			return false
+		}
+	}
+
+	if len(f.Include) == 0 {
+		return true
+	}
+
+	for _, tag := range f.Include {
+		if slices.Contains(event.Tags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply returns the events that pass the filter.
+func (f TagFilter) Apply(events []*Event) []*Event {
+	if len(f.Include) == 0 && len(f.Exclude) == 0 {
+		return events
+	}
+
+	result := make([]*Event, 0, len(events))
+	for _, event := range events {
+		if f.Match(event) {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
+
+// Period is the length of the buckets events are counted in.
+type Period string
+
+// Supported periods.
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// Valid reports whether the period is one of the supported values.
+func (p Period) Valid() bool {
+	switch p {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return true
+	}
+	return false
+}
+
+// TagStatsQuery selects the events of a user starting in [From, To) to be counted per Period.
+// Periods are aligned in the location of From.
+type TagStatsQuery struct {
+	UserID int
+	From   time.Time
+	To     time.Time
+	Period Period
+	Filter TagFilter
+}
+
+// TagStats counts the events of a single period. An event counts once for each of its tags
+// and once for each distinct category of its tags.
+type TagStats struct {
+	Start      time.Time
+	Total      int
+	Untagged   int
+	Tags       map[string]int
+	Categories map[string]int
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits of the event templates of a user.
+const (
+	MaxTemplates          = 100
+	MaxTemplateNameLength = 128
+)
+
+// Errors returned by event template operations.
+var (
+	ErrTemplateNotFound = NewError(KindNotFound, "template_not_found", "template not found")
+	ErrEmptyName        = NewError(KindInvalid, "empty_name", "empty name")
+	ErrInvalidDuration  = NewError(KindInvalid, "invalid_duration", "duration must not be negative and must be whole days for all-day events")
+	ErrTooManyTemplates = NewError(KindInvalid, "too_many_templates", "too many templates")
+)
+
+// EventTemplate holds the default fields of a kind of event a user creates over and over, such as
+// a one-hour sprint review tagged "team". Events are made from it by Instantiate.
+type EventTemplate struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	// Title may be left empty for every event to set its own.
+	Title string `json:"title,omitempty"`
+	// Duration is the length of the events; a timed event without one is an instant and an all-day
+	// event without one lasts a day. All-day events last whole days.
+	Duration     time.Duration `json:"duration,omitempty"`
+	AllDay       bool          `json:"all_day,omitempty"`
+	Tags         []string      `json:"tags,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	Location     string        `json:"location,omitempty"`
+	URL          string        `json:"url,omitempty"`
+	Status       EventStatus   `json:"status,omitempty"`
+	Transparency Transparency  `json:"transparency,omitempty"`
+	Visibility   Visibility    `json:"visibility,omitempty"`
+}
+
+// Instantiate returns a new event of the template's owner with its fields, starting at start.
+// The event is not validated; a start with a time of day makes an all-day event invalid.
+func (t *EventTemplate) Instantiate(start time.Time) *Event {
+	event := &Event{
+		UserID:       t.UserID,
+		Date:         start,
+		Title:        t.Title,
+		AllDay:       t.AllDay,
+		Tags:         slices.Clone(t.Tags),
+		Description:  t.Description,
+		Location:     t.Location,
+		URL:          t.URL,
+		Status:       t.Status,
+		Transparency: t.Transparency,
+		Visibility:   t.Visibility,
+	}
+
+	switch {
+	case t.Duration == 0:
+	case t.AllDay:
+		event.End = start.AddDate(0, 0, int(t.Duration/(24*time.Hour)))
+	default:
+		event.End = start.Add(t.Duration)
+	}
+
+	return event
+}
+
+// Validate checks if the EventTemplate has valid field values and reports every invalid field.
+// The fields it shares with events are checked as Event.Validate checks them.
+func (t EventTemplate) Validate() error {
+	verr := &ValidationError{}
+
+	if t.UserID <= 0 {
+		verr.Add("user_id", ErrInvalidUserID)
+	}
+
+	if len(t.Name) == 0 {
+		verr.Add("name", ErrEmptyName)
+	} else if utf8.RuneCountInString(t.Name) > MaxTemplateNameLength {
+		verr.Add("name", ErrTooLong)
+	}
+
+	if t.Duration < 0 || t.AllDay && t.Duration%(24*time.Hour) != 0 {
+		verr.Add("duration", ErrInvalidDuration)
+	}
+
+	t.Instantiate(time.Time{}).validateDetails(verr)
+
+	return verr.OrNil()
+}
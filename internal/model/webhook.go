@@ -0,0 +1,92 @@
+package model
+
+import "time"
+
+// Common errors returned by webhook operations.
+var (
+	ErrSubscriptionNotFound = NewError(KindNotFound, "subscription_not_found", "subscription not found")
+	ErrInvalidURL           = NewError(KindInvalid, "invalid_url", "invalid url")
+	ErrEmptySecret          = NewError(KindInvalid, "empty_secret", "empty secret")
+	ErrInvalidChangeType    = NewError(KindInvalid, "invalid_change_type", "invalid change type")
+	ErrInvalidStatus        = NewError(KindInvalid, "invalid_status", "invalid status")
+)
+
+// DeliveryStatus describes the state of a webhook delivery.
+type DeliveryStatus string
+
+// Supported delivery statuses.
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// Subscription represents a webhook endpoint registered by a user.
+type Subscription struct {
+	ID        int          `json:"id"`
+	UserID    int          `json:"user_id"`
+	URL       string       `json:"url"`
+	Secret    string       `json:"secret"`
+	Events    []ChangeType `json:"events"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// Validate checks if the Subscription has valid field values and reports every invalid field.
+func (s Subscription) Validate() error {
+	verr := &ValidationError{}
+
+	if s.UserID <= 0 {
+		verr.Add("user_id", ErrInvalidUserID)
+	}
+
+	if !validURL(s.URL) {
+		verr.Add("url", ErrInvalidURL)
+	}
+
+	if len(s.Secret) == 0 {
+		verr.Add("secret", ErrEmptySecret)
+	}
+
+	for _, t := range s.Events {
+		if !t.Valid() {
+			verr.Add("events", ErrInvalidChangeType)
+			break
+		}
+	}
+
+	return verr.OrNil()
+}
+
+// Matches reports whether the subscription wants to receive the given change.
+func (s Subscription) Matches(change *EventChange) bool {
+	if change.Event.UserID != s.UserID {
+		return false
+	}
+
+	if len(s.Events) == 0 {
+		return true
+	}
+
+	for _, t := range s.Events {
+		if t == change.Type {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Delivery represents a single attempt-tracked webhook delivery.
+type Delivery struct {
+	ID             int            `json:"id"`
+	SubscriptionID int            `json:"subscription_id"`
+	UserID         int            `json:"user_id"`
+	Change         EventChange    `json:"change"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastError      string         `json:"last_error,omitempty"`
+	LastStatusCode int            `json:"last_status_code,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
@@ -0,0 +1,53 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// GetWorkingHoursOverlap returns the working time of each user in [from, to), leaving out their
+// out-of-office periods, and the part all of them share. Longer ranges than MaxRangePeriods days are rejected.
+func (s *serv) GetWorkingHoursOverlap(ctx context.Context, userIDs []int, from, to time.Time) (*model.WorkingHoursOverlap, error) {
+	if err := model.CheckRangeDays(from, to); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := &model.WorkingHoursOverlap{Users: make([]*model.UserWorkingTime, 0, len(userIDs))}
+	for n, userID := range userIDs {
+		settings := s.userSettings(userID)
+		working := settings.WorkingTime(from, to)
+		result.Users = append(result.Users, &model.UserWorkingTime{
+			UserID:   userID,
+			TimeZone: settings.Location().String(),
+			Working:  working,
+		})
+
+		if n == 0 {
+			result.Overlap = working
+		} else {
+			result.Overlap = model.IntersectIntervals(result.Overlap, working)
+		}
+	}
+
+	return result, nil
+}
+
+// CheckEvent warns about every out-of-office period of the event's owner that the event takes up.
+// All-day events are placed in the owner's time zone.
+func (s *serv) CheckEvent(ctx context.Context, event *model.Event) ([]*model.Warning, error) {
+	s.mu.RLock()
+	settings := s.userSettings(event.UserID)
+	s.mu.RUnlock()
+
+	var warnings []*model.Warning
+	for _, o := range settings.OutOfOfficeDuring(event.Span(settings.Location())) {
+		warnings = append(warnings, model.NewOutOfOfficeWarning(o))
+	}
+
+	return warnings, nil
+}
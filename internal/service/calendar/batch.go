@@ -0,0 +1,134 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// ApplyBatch applies ops in order under a single lock. Every operation is first staged
+// against a view of the calendar that already includes the earlier operations of the batch,
+// so nothing is stored or notified until the whole batch has been checked.
+// Unless continueOnError is set, a single failed operation aborts the batch.
+func (s *serv) ApplyBatch(ctx context.Context, ops []model.BatchOp, continueOnError bool) ([]model.BatchResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan := &batchPlan{
+		serv:         s,
+		staged:       make(map[string]*model.Event),
+		legacyIDs:    make(map[int]string),
+		nextLegacyID: s.nextLegacyID,
+	}
+	results := make([]model.BatchResult, len(ops))
+	failed := false
+	for i := range ops {
+		results[i].Event, results[i].Err = plan.stage(&ops[i])
+		failed = failed || results[i].Err != nil
+	}
+
+	if failed && !continueOnError {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i] = model.BatchResult{Err: model.ErrBatchAborted}
+			}
+		}
+		return results, nil
+	}
+
+	for i := range ops {
+		if results[i].Err == nil {
+			results[i].Event = s.commit(ops[i].Type, results[i].Event)
+		}
+	}
+
+	return results, nil
+}
+
+// commit stores an event staged by a batchPlan. Updates store the staged copy in place of the current
+// event, so the result of every operation stays the event as that operation left it. The caller must
+// hold the write lock.
+func (s *serv) commit(opType model.BatchOpType, staged *model.Event) *model.Event {
+	switch opType {
+	case model.BatchCreate:
+		return s.storeEvent(staged)
+	case model.BatchUpdate:
+		return s.replaceEvent(staged)
+	default:
+		s.deleteEvent(s.events[staged.ID])
+		return staged
+	}
+}
+
+// batchPlan stages batch operations without touching the calendar.
+// staged holds the events changed by the batch so far by ULID; nil marks a deleted one.
+// Created events get their IDs when staged, so later operations of the batch can refer to them.
+type batchPlan struct {
+	serv         *serv
+	staged       map[string]*model.Event
+	legacyIDs    map[int]string
+	nextLegacyID int
+}
+
+// get returns the event as the batch currently sees it. eventID may also be a legacy integer ID.
+func (p *batchPlan) get(eventID string) *model.Event {
+	if legacyID, ok := model.ParseLegacyEventID(eventID); ok {
+		if id, ok := p.legacyIDs[legacyID]; ok {
+			eventID = id
+		} else {
+			eventID = p.serv.legacyIDs[legacyID]
+		}
+	}
+
+	if event, ok := p.staged[eventID]; ok {
+		return event
+	}
+
+	return p.serv.events[eventID]
+}
+
+// stage checks op and records its effect. It returns the event commit will store.
+func (p *batchPlan) stage(op *model.BatchOp) (*model.Event, error) {
+	switch op.Type {
+	case model.BatchCreate:
+		event := op.Event
+		if err := event.Validate(); err != nil {
+			return nil, err
+		}
+		event.ID = p.serv.newEventID()
+		event.LegacyID = p.nextLegacyID
+		event.Version = 1
+		p.nextLegacyID++
+		p.staged[event.ID] = &event
+		p.legacyIDs[event.LegacyID] = event.ID
+
+		return &event, nil
+	case model.BatchUpdate:
+		cur := p.get(op.Event.ID)
+		if err := checkEvent(cur, op.Event.UserID, op.Event.Version); err != nil {
+			return nil, err
+		}
+
+		patched, err := patchedCopy(cur, func(event *model.Event) error {
+			replaceFields(event, &op.Event)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		p.staged[patched.ID] = patched
+
+		return patched, nil
+	case model.BatchDelete:
+		cur := p.get(op.Event.ID)
+		if err := checkEvent(cur, op.Event.UserID, op.Event.Version); err != nil {
+			return nil, err
+		}
+		deleted := *cur
+		p.staged[cur.ID] = nil
+
+		return &deleted, nil
+	default:
+		return nil, model.NewValidationError("op", model.ErrInvalidBatchOp)
+	}
+}
@@ -0,0 +1,118 @@
+package calendar
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// GetMonthGrid lays out the user's events of the month containing query.Date as a grid of weeks.
+func (s *serv) GetMonthGrid(ctx context.Context, query *model.MonthGridQuery) (*model.MonthGrid, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	weekStart := s.weekStart(query.UserID)
+	if query.WeekStart != nil {
+		weekStart = *query.WeekStart
+	}
+
+	maxEvents := query.MaxEvents
+	if maxEvents <= 0 {
+		maxEvents = model.DefaultGridEvents
+	}
+
+	month, _ := model.MonthRange(query.Date)
+	from, _ := model.WeekRange(month, weekStart)
+	days := s.layoutDays(query.UserID, from, from.AddDate(0, 0, 7*model.GridWeeks), query.Filter, maxEvents, query.Holidays)
+
+	grid := &model.MonthGrid{
+		Month:     month,
+		WeekStart: weekStart,
+		Weeks:     make([][]*model.GridDay, 0, model.GridWeeks),
+	}
+	for week := range slices.Chunk(days, 7) {
+		for _, day := range week {
+			day.InMonth = day.Date.Month() == month.Month()
+		}
+		grid.Weeks = append(grid.Weeks, week)
+	}
+
+	return grid, nil
+}
+
+// GetWeekView lays out the user's events of the calendar week containing query.Date day by day.
+func (s *serv) GetWeekView(ctx context.Context, query *model.WeekViewQuery) (*model.WeekView, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	weekStart := s.weekStart(query.UserID)
+	if query.WeekStart != nil {
+		weekStart = *query.WeekStart
+	}
+
+	from, to := model.WeekRange(query.Date, weekStart)
+	return &model.WeekView{
+		Start:     from,
+		WeekStart: weekStart,
+		Days:      s.layoutDays(query.UserID, from, to, query.Filter, 0, query.Holidays),
+	}, nil
+}
+
+// layoutDays builds a cell for every day of [from, to) holding the user's events that pass filter,
+// all-day ones first and then by start time. A cell lists at most maxEvents of them, all when it is zero,
+// and counts the others. Days are marked by the holiday calendar. The caller must hold the lock.
+func (s *serv) layoutDays(userID int, from, to time.Time, filter model.TagFilter, maxEvents int, holidays *model.HolidayCalendar) []*model.GridDay {
+	events := make([]*model.Event, 0)
+	for _, eventID := range s.userEvents[userID] {
+		event := s.events[eventID]
+		if event.Overlaps(from, to) && filter.Match(event) {
+			events = append(events, event)
+		}
+	}
+	slices.SortFunc(events, compareEventTimes)
+
+	var days []*model.GridDay
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		cell := &model.GridDay{
+			Date:    day,
+			Weekend: model.IsWeekend(day.Weekday()),
+			Workday: holidays.IsWorkday(day),
+			Events:  make([]*model.Event, 0),
+		}
+		if holiday, ok := holidays.Holiday(day); ok {
+			cell.Holiday = holiday.Kind == model.HolidayDayOff
+			cell.HolidayName = holiday.Name
+		}
+
+		next := day.AddDate(0, 0, 1)
+		for _, event := range events {
+			if !event.Overlaps(day, next) {
+				continue
+			}
+			if maxEvents == 0 || len(cell.Events) < maxEvents {
+				cell.Events = append(cell.Events, event)
+			} else {
+				cell.Overflow++
+			}
+		}
+
+		days = append(days, cell)
+	}
+
+	return days
+}
+
+// compareEventTimes orders all-day events before timed ones and then by start time and ID.
+func compareEventTimes(a, b *model.Event) int {
+	if a.AllDay != b.AllDay {
+		if a.AllDay {
+			return -1
+		}
+		return 1
+	}
+
+	return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+}
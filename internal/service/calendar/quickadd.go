@@ -0,0 +1,947 @@
+package calendar
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// ParseEvent reads a draft event of the user from a Russian or English phrase. Relative dates are
+// resolved against the current time in loc, or in the user's time zone when loc is nil.
+func (s *serv) ParseEvent(ctx context.Context, userID int, text string, loc *time.Location) (*model.EventDraft, error) {
+	if loc == nil {
+		s.mu.RLock()
+		loc = s.userSettings(userID).Location()
+		s.mu.RUnlock()
+	}
+
+	draft, err := parseEventText(text, s.clock.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+	draft.Event.UserID = userID
+
+	if err = draft.Event.Validate(); err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// token is a word of the parsed text: as written, for the title, and lower-cased for matching.
+type token struct {
+	text string
+	word string
+}
+
+// unit is a unit of relative dates, durations and recurrences.
+type unit int
+
+const (
+	unitMinute unit = iota + 1
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+// maxAmountDays bounds the relative dates, durations and recurrence intervals read from text to
+// 100 years, well within what time.Duration and the years 1 to 9999 of stored dates can hold.
+const maxAmountDays = 36525
+
+// unitDays are the lengths of the units in days, taking months and years at their average length.
+var unitDays = map[unit]float64{
+	unitMinute: 1.0 / (24 * 60), unitHour: 1.0 / 24, unitDay: 1, unitWeek: 7, unitMonth: 30.4375, unitYear: 365.25,
+}
+
+var unitWords = map[string]unit{
+	"min": unitMinute, "mins": unitMinute, "minute": unitMinute, "minutes": unitMinute,
+	"мин": unitMinute, "минута": unitMinute, "минуту": unitMinute, "минуты": unitMinute, "минут": unitMinute,
+	"h": unitHour, "hr": unitHour, "hrs": unitHour, "hour": unitHour, "hours": unitHour,
+	"ч": unitHour, "час": unitHour, "часа": unitHour, "часов": unitHour,
+	"day": unitDay, "days": unitDay, "день": unitDay, "дня": unitDay, "дней": unitDay, "сутки": unitDay, "суток": unitDay,
+	"week": unitWeek, "weeks": unitWeek, "неделя": unitWeek, "неделю": unitWeek, "недели": unitWeek, "недель": unitWeek,
+	"month": unitMonth, "months": unitMonth, "месяц": unitMonth, "месяца": unitMonth, "месяцев": unitMonth,
+	"year": unitYear, "years": unitYear, "год": unitYear, "года": unitYear, "лет": unitYear,
+}
+
+var numberWords = map[string]float64{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3,
+	"один": 1, "одна": 1, "одну": 1, "два": 2, "две": 2, "три": 3, "полтора": 1.5, "полторы": 1.5,
+}
+
+var monthWords = map[string]time.Month{
+	"january": time.January, "february": time.February, "march": time.March, "april": time.April,
+	"may": time.May, "june": time.June, "july": time.July, "august": time.August,
+	"september": time.September, "october": time.October, "november": time.November, "december": time.December,
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April, "jun": time.June, "jul": time.July,
+	"aug": time.August, "sep": time.September, "sept": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+	"января": time.January, "февраля": time.February, "марта": time.March, "апреля": time.April,
+	"мая": time.May, "июня": time.June, "июля": time.July, "августа": time.August,
+	"сентября": time.September, "октября": time.October, "ноября": time.November, "декабря": time.December,
+	"январь": time.January, "февраль": time.February, "март": time.March, "апрель": time.April,
+	"май": time.May, "июнь": time.June, "июль": time.July, "август": time.August,
+	"сентябрь": time.September, "октябрь": time.October, "ноябрь": time.November, "декабрь": time.December,
+	"янв": time.January, "фев": time.February, "мар": time.March, "апр": time.April, "июн": time.June, "июл": time.July,
+	"авг": time.August, "сен": time.September, "сент": time.September, "окт": time.October, "ноя": time.November, "дек": time.December,
+}
+
+// weekdayWords maps day names to their weekday. Plural names, as in "mondays" or "по понедельникам",
+// are in pluralWeekdayWords.
+var weekdayWords = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+	"воскресенье": time.Sunday, "понедельник": time.Monday, "вторник": time.Tuesday, "среда": time.Wednesday,
+	"среду": time.Wednesday, "четверг": time.Thursday, "пятница": time.Friday, "пятницу": time.Friday,
+	"суббота": time.Saturday, "субботу": time.Saturday,
+}
+
+var pluralWeekdayWords = map[string]time.Weekday{
+	"sundays": time.Sunday, "mondays": time.Monday, "tuesdays": time.Tuesday, "wednesdays": time.Wednesday,
+	"thursdays": time.Thursday, "fridays": time.Friday, "saturdays": time.Saturday,
+	"воскресеньям": time.Sunday, "понедельникам": time.Monday, "вторникам": time.Tuesday, "средам": time.Wednesday,
+	"четвергам": time.Thursday, "пятницам": time.Friday, "субботам": time.Saturday,
+}
+
+var workdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+var (
+	numberPattern   = regexp.MustCompile(`^\d+([.,]\d+)?$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|a\.m|p\.m)?$`)
+	dayPattern      = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+	yearPattern     = regexp.MustCompile(`^\d{4}$`)
+	isoDatePattern  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	dotDatePattern  = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}|\d{2}))?$`)
+	hoursPattern    = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)(?:h|ч)(?:(\d+)(?:m|min|мин))?$`)
+	minutesPattern  = regexp.MustCompile(`^(\d+)(?:m|min|мин)$`)
+	rangeSeparators = []string{"-", "–", "—"}
+)
+
+// later returns a function that moves a start that has already passed the given time ahead.
+func later(years, months, days int) func(start time.Time) time.Time {
+	return func(start time.Time) time.Time {
+		return start.AddDate(years, months, days)
+	}
+}
+
+// laterWeekday returns a function that moves a start that has already passed to the next of days.
+func laterWeekday(days []time.Weekday) func(start time.Time) time.Time {
+	return func(start time.Time) time.Time {
+		for i := 1; ; i++ {
+			if next := start.AddDate(0, 0, i); slices.Contains(days, next.Weekday()) {
+				return next
+			}
+		}
+	}
+}
+
+// phrase collects what parseEventText has read from a text.
+type phrase struct {
+	now   time.Time
+	title []string
+
+	date time.Time
+	// roll moves a start that has passed ahead when the text did not fix its date; nil keeps it.
+	roll func(start time.Time) time.Time
+	// at is an exact start given relative to now, as in "in 2 hours".
+	at       time.Time
+	clock    time.Duration
+	hasClock bool
+	endClock time.Duration
+	hasEnd   bool
+	duration time.Duration
+	allDay   bool
+
+	recurrence *model.Recurrence
+	// err is the first problem found in the text, such as an amount that is too large; draft returns it.
+	err error
+}
+
+// parseEventText reads an event from text such as "lunch with Ivan tomorrow 13:00 for 1h" or
+// "созвон в пятницу в 10" at the time now, in the location of now. The words that are not part of a
+// date, time, duration or recurrence make up the title. A date without a time makes an all-day event.
+func parseEventText(text string, now time.Time) (*model.EventDraft, error) {
+	text = strings.TrimSpace(text)
+	switch {
+	case len(text) == 0:
+		return nil, model.NewValidationError("text", model.ErrEmptyText)
+	case utf8.RuneCountInString(text) > model.MaxTextLength:
+		return nil, model.NewValidationError("text", model.ErrTooLong)
+	}
+
+	p := &phrase{now: now}
+	readers := []func([]token) int{
+		p.readRecurrence, p.readRelative, p.readDayWord, p.readWeekday, p.readDate,
+		p.readTimeRange, p.readTime, p.readDuration, p.readAllDay,
+	}
+
+	tokens := splitPhrase(text)
+	for i := 0; i < len(tokens); {
+		n := 0
+		for _, read := range readers {
+			if n = read(tokens[i:]); n > 0 {
+				break
+			}
+		}
+		if n == 0 {
+			p.title = append(p.title, tokens[i].text)
+			n = 1
+		}
+		i += n
+	}
+
+	return p.draft()
+}
+
+// splitPhrase splits text into words, dropping the punctuation around them for matching.
+func splitPhrase(text string) []token {
+	fields := strings.Fields(text)
+	tokens := make([]token, 0, len(fields))
+	for _, field := range fields {
+		word := strings.ToLower(strings.Trim(field, `,.;:!?"'«»()`))
+		tokens = append(tokens, token{text: field, word: word})
+	}
+
+	return tokens
+}
+
+// draft builds the event from what has been read.
+func (p *phrase) draft() (*model.EventDraft, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	loc := p.now.Location()
+	event := &model.Event{Title: strings.Trim(strings.Join(p.title, " "), " ,;:-–—")}
+
+	switch {
+	case !p.at.IsZero():
+		event.Date = p.at.UTC()
+		if p.duration > 0 {
+			event.End = p.at.Add(p.duration).UTC()
+		}
+	case p.hasClock && !p.allDay:
+		day := p.startDay()
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc).Add(p.clock)
+		if start.Before(p.now) && p.roll != nil {
+			start = p.roll(start)
+		}
+		event.Date = start.UTC()
+
+		switch {
+		case p.hasEnd:
+			end := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc).Add(p.endClock)
+			if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+			event.End = end.UTC()
+		case p.duration > 0:
+			event.End = start.Add(p.duration).UTC()
+		}
+	case p.duration > 0 && p.duration < 24*time.Hour:
+		return nil, model.NewValidationError("text", model.ErrTimeNotFound)
+	case p.date.IsZero() && p.recurrence == nil && !p.allDay:
+		return nil, model.NewValidationError("text", model.ErrDateNotFound)
+	default:
+		day := p.startDay()
+		event.AllDay = true
+		event.Date = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		if days := int(math.Ceil(p.duration.Hours() / 24)); days > 1 {
+			event.End = event.Date.AddDate(0, 0, days)
+		}
+	}
+
+	for _, t := range []time.Time{event.Date, event.End} {
+		if !t.IsZero() && (t.Year() < 1 || t.Year() > 9999) {
+			return nil, model.NewValidationError("text", model.ErrInvalidDate)
+		}
+	}
+
+	return &model.EventDraft{Event: event, Location: loc, Recurrence: p.recurrence}, nil
+}
+
+// startDay returns the day the event starts on, at midnight: the date read, the first day of the
+// recurrence or today. It sets how a start that has passed rolls over when the text did not fix it.
+func (p *phrase) startDay() time.Time {
+	if !p.date.IsZero() {
+		return p.date
+	}
+
+	today := p.today()
+	r := p.recurrence
+	switch {
+	case r == nil:
+		p.roll = later(0, 0, 1)
+	case len(r.Weekdays) > 0:
+		p.roll = laterWeekday(r.Weekdays)
+		return nextWeekday(today, r.Weekdays, false)
+	case r.Frequency == model.FrequencyDaily:
+		p.roll = later(0, 0, 1)
+	case r.Frequency == model.FrequencyWeekly:
+		p.roll = later(0, 0, 7)
+	case r.Frequency == model.FrequencyMonthly:
+		p.roll = later(0, 1, 0)
+	default:
+		p.roll = later(1, 0, 0)
+	}
+
+	return today
+}
+
+func (p *phrase) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+// readRecurrence reads "every day", "every 2 weeks", "every 2 weeks on friday", "every monday and friday",
+// "weekly", "mondays", "каждый день", "каждые 2 недели", "каждую пятницу", "по понедельникам",
+// "ежедневно" and the like.
+func (p *phrase) readRecurrence(tokens []token) int {
+	if p.recurrence != nil {
+		return 0
+	}
+
+	switch tokens[0].word {
+	case "daily", "ежедневно":
+		p.recurrence = &model.Recurrence{Frequency: model.FrequencyDaily}
+		return 1
+	case "weekly", "еженедельно":
+		p.recurrence = &model.Recurrence{Frequency: model.FrequencyWeekly}
+		return 1
+	case "monthly", "ежемесячно":
+		p.recurrence = &model.Recurrence{Frequency: model.FrequencyMonthly}
+		return 1
+	case "yearly", "annually", "ежегодно":
+		p.recurrence = &model.Recurrence{Frequency: model.FrequencyYearly}
+		return 1
+	case "weekdays":
+		p.recurrence = &model.Recurrence{Frequency: model.FrequencyWeekly, Weekdays: workdays}
+		return 1
+	case "on", "по":
+		if len(tokens) > 1 && tokens[1].word == "будням" {
+			p.recurrence = &model.Recurrence{Frequency: model.FrequencyWeekly, Weekdays: workdays}
+			return 2
+		}
+		if n := p.readWeekdayList(tokens[1:], pluralWeekdayWords); n > 0 {
+			return n + 1
+		}
+		return 0
+	case "every", "each", "каждый", "каждую", "каждое", "каждые":
+	default:
+		return p.readWeekdayList(tokens, pluralWeekdayWords)
+	}
+
+	i, interval := 1, 1.0
+	if i < len(tokens) {
+		if tokens[i].word == "other" {
+			i, interval = i+1, 2
+		} else if n, ok := number(tokens[i].word); ok && n == math.Trunc(n) && n > 1 {
+			i, interval = i+1, n
+		}
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	if tokens[i].word == "weekday" || tokens[i].word == "будний" && i+1 < len(tokens) && tokens[i+1].word == "день" {
+		p.recurrence = &model.Recurrence{Frequency: model.FrequencyWeekly, Weekdays: workdays}
+		if tokens[i].word == "будний" {
+			return i + 2
+		}
+		return i + 1
+	}
+
+	if interval == 1 {
+		if n := p.readWeekdayList(tokens[i:], weekdayWords); n > 0 {
+			return n + i
+		}
+	}
+
+	frequencies := map[unit]model.Frequency{
+		unitDay: model.FrequencyDaily, unitWeek: model.FrequencyWeekly,
+		unitMonth: model.FrequencyMonthly, unitYear: model.FrequencyYearly,
+	}
+	u := unitWords[tokens[i].word]
+	frequency, ok := frequencies[u]
+	if !ok {
+		return 0
+	}
+	if !p.checkAmount(u, interval) {
+		return i + 1
+	}
+	n := i + 1
+	p.recurrence = &model.Recurrence{Frequency: frequency}
+	if frequency == model.FrequencyWeekly && n+1 < len(tokens) {
+		switch tokens[n].word {
+		case "on", "в", "во":
+			n += p.readDays(tokens[n+1:], weekdayWords)
+		case "по":
+			n += p.readDays(tokens[n+1:], pluralWeekdayWords)
+		}
+	}
+	p.recurrence.Interval = int(interval)
+
+	return n
+}
+
+// readDays reads the days of a weekly recurrence after a preposition, as in "every 2 weeks on friday",
+// and returns the number of tokens read including the preposition, or 0 if no day follows it.
+func (p *phrase) readDays(tokens []token, names map[string]time.Weekday) int {
+	if n := p.readWeekdayList(tokens, names); n > 0 {
+		return n + 1
+	}
+
+	return 0
+}
+
+// readWeekdayList reads a weekly recurrence on the days named by names, such as "monday and friday".
+func (p *phrase) readWeekdayList(tokens []token, names map[string]time.Weekday) int {
+	var days []time.Weekday
+	n := 0
+	for i := 0; i < len(tokens); i++ {
+		if day, ok := names[tokens[i].word]; ok {
+			days = append(days, day)
+			n = i + 1
+			continue
+		}
+		if len(days) == 0 || tokens[i].word != "and" && tokens[i].word != "и" {
+			break
+		}
+	}
+	if len(days) == 0 {
+		return 0
+	}
+
+	p.recurrence = &model.Recurrence{Frequency: model.FrequencyWeekly, Weekdays: days}
+
+	return n
+}
+
+// readRelative reads "in 3 days", "in 2 hours", "через неделю", "через 30 минут" and "через полчаса".
+// Minutes and hours fix the start itself; longer units only the date.
+func (p *phrase) readRelative(tokens []token) int {
+	if tokens[0].word != "in" && tokens[0].word != "через" || len(tokens) < 2 || !p.at.IsZero() {
+		return 0
+	}
+
+	amount, u, n := readAmount(tokens[1:], tokens[0].word == "через")
+	if n == 0 {
+		return 0
+	}
+
+	switch u {
+	case unitMinute, unitHour:
+		if p.hasClock {
+			return 0
+		}
+		if !p.checkAmount(u, amount) {
+			return n + 1
+		}
+		p.at = p.now.Add(unitDuration(u, amount)).Truncate(time.Minute)
+	default:
+		if !p.date.IsZero() || amount != math.Trunc(amount) {
+			return 0
+		}
+		if !p.checkAmount(u, amount) {
+			return n + 1
+		}
+		today, count := p.today(), int(amount)
+		switch u {
+		case unitDay:
+			p.date = today.AddDate(0, 0, count)
+		case unitWeek:
+			p.date = today.AddDate(0, 0, 7*count)
+		case unitMonth:
+			p.date = today.AddDate(0, count, 0)
+		default:
+			p.date = today.AddDate(count, 0, 0)
+		}
+	}
+
+	return n + 1
+}
+
+// readDayWord reads "today", "tomorrow", "the day after tomorrow", "сегодня", "завтра" and "послезавтра".
+func (p *phrase) readDayWord(tokens []token) int {
+	if !p.date.IsZero() {
+		return 0
+	}
+
+	i := 0
+	if tokens[0].word == "on" || tokens[0].word == "на" {
+		i = 1
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	days, n := 0, 1
+	switch tokens[i].word {
+	case "today", "сегодня":
+	case "tomorrow", "завтра":
+		days = 1
+	case "послезавтра":
+		days = 2
+	case "the", "day":
+		words := []string{"day", "after", "tomorrow"}
+		if tokens[i].word == "the" {
+			words = append([]string{"the"}, words...)
+		}
+		if len(tokens) < i+len(words) {
+			return 0
+		}
+		for j, word := range words {
+			if tokens[i+j].word != word {
+				return 0
+			}
+		}
+		days, n = 2, len(words)
+	default:
+		return 0
+	}
+	p.date = p.today().AddDate(0, 0, days)
+
+	return i + n
+}
+
+// readWeekday reads "friday", "on friday", "next friday", "в пятницу" and "в следующий вторник".
+// A day name alone is the nearest such day from today on; with "next" it is after today.
+func (p *phrase) readWeekday(tokens []token) int {
+	if !p.date.IsZero() {
+		return 0
+	}
+
+	i := 0
+	switch tokens[0].word {
+	case "on", "в", "во", "на":
+		i = 1
+	}
+
+	next := false
+	if i < len(tokens) {
+		switch tokens[i].word {
+		case "next", "следующий", "следующую", "следующее":
+			next = true
+			i++
+		case "this", "этот", "эту", "это":
+			i++
+		}
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	day, ok := weekdayWords[tokens[i].word]
+	if !ok {
+		return 0
+	}
+
+	p.date = nextWeekday(p.today(), []time.Weekday{day}, next)
+	if !next {
+		p.roll = later(0, 0, 7)
+	}
+
+	return i + 1
+}
+
+// nextWeekday returns the first of days from today on, or after today if afterToday is set.
+func nextWeekday(today time.Time, days []time.Weekday, afterToday bool) time.Time {
+	start := 0
+	if afterToday {
+		start = 1
+	}
+	for i := start; ; i++ {
+		day := today.AddDate(0, 0, i)
+		for _, weekday := range days {
+			if day.Weekday() == weekday {
+				return day
+			}
+		}
+	}
+}
+
+// readDate reads "2026-10-23", "23.10", "23.10.2026", "23 october", "october 23rd, 2026" and "на 23 октября".
+// A date without a year is the nearest such date from today on.
+func (p *phrase) readDate(tokens []token) int {
+	if !p.date.IsZero() {
+		return 0
+	}
+
+	i := 0
+	if (tokens[0].word == "on" || tokens[0].word == "на") && len(tokens) > 1 {
+		i = 1
+	}
+	loc := p.now.Location()
+
+	word := tokens[i].word
+	if isoDatePattern.MatchString(word) {
+		date, err := time.ParseInLocation("2006-01-02", word, loc)
+		if err != nil {
+			return 0
+		}
+		p.date = date
+		return i + 1
+	}
+
+	if m := dotDatePattern.FindStringSubmatch(word); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		year := 0
+		if len(m[3]) > 0 {
+			year, _ = strconv.Atoi(m[3])
+			if year < 100 {
+				year += 2000
+			}
+		}
+		if !p.setDate(year, time.Month(month), day) {
+			return 0
+		}
+		return i + 1
+	}
+
+	var day int
+	var month time.Month
+	var n int
+	if m := dayPattern.FindStringSubmatch(word); m != nil && i+1 < len(tokens) {
+		day, _ = strconv.Atoi(m[1])
+		month, n = monthWords[tokens[i+1].word], 2
+	} else if mon, ok := monthWords[word]; ok && i+1 < len(tokens) {
+		if m := dayPattern.FindStringSubmatch(tokens[i+1].word); m != nil {
+			day, _ = strconv.Atoi(m[1])
+			month, n = mon, 2
+		}
+	}
+	if month == 0 {
+		return 0
+	}
+
+	year := 0
+	if i+n < len(tokens) && yearPattern.MatchString(tokens[i+n].word) {
+		year, _ = strconv.Atoi(tokens[i+n].word)
+		n++
+	}
+	if !p.setDate(year, month, day) {
+		return 0
+	}
+
+	return i + n
+}
+
+// setDate sets the date if it exists. A zero year is this year, or the next one if the date has passed.
+func (p *phrase) setDate(year int, month time.Month, day int) bool {
+	explicit := year != 0
+	if !explicit {
+		year = p.now.Year()
+	}
+	if month < time.January || month > time.December {
+		return false
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, p.now.Location())
+	if date.Day() != day {
+		return false
+	}
+	if !explicit && date.Before(p.today()) {
+		date = date.AddDate(1, 0, 0)
+	}
+	p.date = date
+
+	return true
+}
+
+// readTimeRange reads "from 10 to 11:30", "10:00-11:30", "1-3pm", "с 10 до 12" and "в 10-11".
+func (p *phrase) readTimeRange(tokens []token) int {
+	if p.hasClock || !p.at.IsZero() {
+		return 0
+	}
+
+	i, prefixed := 0, false
+	switch tokens[0].word {
+	case "from", "at", "с", "со", "в", "во":
+		i, prefixed = 1, true
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	for _, sep := range rangeSeparators {
+		parts := strings.Split(tokens[i].word, sep)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			continue
+		}
+		end, n, ok := readClock(append([]token{{word: parts[1]}}, tokens[i+1:]...), prefixed)
+		if !ok {
+			return 0
+		}
+		start, _, ok := readClock([]token{{word: parts[0]}}, true)
+		if !ok {
+			return 0
+		}
+		p.setRange(start, end, parts[0], parts[1])
+		return i + n
+	}
+
+	start, n1, ok := readClock(tokens[i:], prefixed)
+	if !ok || i+n1 >= len(tokens) {
+		return 0
+	}
+	switch tokens[i+n1].word {
+	case "to", "till", "until", "до", "-", "–", "—":
+	default:
+		return 0
+	}
+	end, n2, ok := readClock(tokens[i+n1+1:], true)
+	if !ok {
+		return 0
+	}
+	p.setRange(start, end, tokens[i].word, tokens[i+n1+1].word)
+
+	return i + n1 + 1 + n2
+}
+
+// setRange sets the start and end times of the day. A start without am or pm ends up in the afternoon
+// when only the end has pm and it would be later than 12 hours before the end, as in "1-3pm".
+func (p *phrase) setRange(start, end time.Duration, startWord, endWord string) {
+	if start < 12*time.Hour && end > 12*time.Hour && start+12*time.Hour < end &&
+		!strings.Contains(startWord, "m") && strings.HasSuffix(endWord, "m") {
+		start += 12 * time.Hour
+	}
+	p.clock, p.hasClock = start, true
+	p.endClock, p.hasEnd = end, true
+}
+
+// readTime reads "13:00", "1pm", "at 10", "noon", "в 10", "в 10 утра", "в 7 вечера" and "в полдень".
+// A number alone is a time only after at or в.
+func (p *phrase) readTime(tokens []token) int {
+	if p.hasClock || !p.at.IsZero() {
+		return 0
+	}
+
+	i := 0
+	switch tokens[0].word {
+	case "at", "@", "в", "во", "к":
+		i = 1
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	clock, n, ok := readClock(tokens[i:], i > 0)
+	if !ok {
+		return 0
+	}
+	p.clock, p.hasClock = clock, true
+
+	return i + n
+}
+
+// readClock reads a time of day at the start of tokens, with the words that qualify it such as pm,
+// утра or часов. A bare hour is only read if bare is set.
+func readClock(tokens []token, bare bool) (time.Duration, int, bool) {
+	if len(tokens) == 0 {
+		return 0, 0, false
+	}
+
+	switch tokens[0].word {
+	case "noon", "midday", "полдень":
+		return 12 * time.Hour, 1, true
+	case "midnight", "полночь":
+		return 0, 1, true
+	}
+
+	m := clockPattern.FindStringSubmatch(tokens[0].word)
+	if m == nil {
+		return 0, 0, false
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if len(m[2]) > 0 {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	meridiem := strings.ReplaceAll(m[3], ".", "")
+
+	n := 1
+	explicit := len(m[2]) > 0 || len(meridiem) > 0
+qualifiers:
+	for ; n < len(tokens) && n < 3; n++ {
+		word := strings.ReplaceAll(tokens[n].word, ".", "")
+		switch {
+		case len(meridiem) == 0 && (word == "am" || word == "pm"):
+			meridiem = word
+		case word == "o'clock" || word == "час" || word == "часа" || word == "часов" || word == "ч":
+		case len(meridiem) == 0 && (word == "утра" || word == "дня" || word == "вечера" || word == "ночи"):
+			meridiem = word
+		default:
+			break qualifiers
+		}
+		explicit = true
+	}
+
+	if !explicit && !bare || minute > 59 {
+		return 0, 0, false
+	}
+
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	case "дня", "вечера":
+		if hour < 12 {
+			hour += 12
+		}
+	case "ночи":
+		if hour == 12 {
+			hour = 0
+		}
+	}
+	if hour > 23 {
+		return 0, 0, false
+	}
+
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, n, true
+}
+
+// readDuration reads "for 1h", "for 30 minutes", "for an hour", "for half an hour", "на 2 часа",
+// "на час", "на полчаса" and compact lengths such as "1h30m" or "90min" without a preposition.
+func (p *phrase) readDuration(tokens []token) int {
+	if p.duration > 0 || p.hasEnd {
+		return 0
+	}
+
+	if minutes, ok := compactMinutes(tokens[0].word); ok {
+		return p.setDuration(unitMinute, minutes, 1)
+	}
+
+	if tokens[0].word != "for" && tokens[0].word != "на" || len(tokens) < 2 {
+		return 0
+	}
+
+	if minutes, ok := compactMinutes(tokens[1].word); ok {
+		return p.setDuration(unitMinute, minutes, 2)
+	}
+
+	if len(tokens) > 3 && tokens[1].word == "half" && (tokens[2].word == "an" || tokens[2].word == "a") && tokens[3].word == "hour" {
+		p.duration = 30 * time.Minute
+		return 4
+	}
+
+	amount, u, n := readAmount(tokens[1:], tokens[0].word == "на")
+	if n == 0 || u > unitWeek {
+		return 0
+	}
+
+	return p.setDuration(u, amount, n+1)
+}
+
+// setDuration sets the duration to amount units unless the amount is too large and returns n,
+// the number of tokens it was read from.
+func (p *phrase) setDuration(u unit, amount float64, n int) int {
+	if p.checkAmount(u, amount) {
+		p.duration = unitDuration(u, amount)
+	}
+
+	return n
+}
+
+// readAllDay reads "all day", "all-day", "весь день" and "целый день".
+func (p *phrase) readAllDay(tokens []token) int {
+	switch tokens[0].word {
+	case "all-day", "allday":
+		p.allDay = true
+		return 1
+	case "all", "весь", "целый":
+		if len(tokens) > 1 && (tokens[1].word == "day" || tokens[1].word == "день") {
+			p.allDay = true
+			return 2
+		}
+	}
+
+	return 0
+}
+
+// readAmount reads a number and a unit, such as "2 hours", "a week" or "30 минут". With implicitOne
+// a unit alone counts one, as in "через час"; полчаса is always half an hour.
+func readAmount(tokens []token, implicitOne bool) (float64, unit, int) {
+	if tokens[0].word == "полчаса" {
+		return 30, unitMinute, 1
+	}
+
+	if u, ok := unitWords[tokens[0].word]; ok && implicitOne {
+		return 1, u, 1
+	}
+
+	amount, ok := number(tokens[0].word)
+	if !ok || len(tokens) < 2 {
+		return 0, 0, 0
+	}
+	u, ok := unitWords[tokens[1].word]
+	if !ok {
+		return 0, 0, 0
+	}
+
+	return amount, u, 2
+}
+
+// checkAmount reports whether amount units are at most maxAmountDays long and records
+// model.ErrAmountTooLarge if they are not.
+func (p *phrase) checkAmount(u unit, amount float64) bool {
+	if amount*unitDays[u] <= maxAmountDays {
+		return true
+	}
+	if p.err == nil {
+		p.err = model.NewValidationError("text", model.ErrAmountTooLarge)
+	}
+
+	return false
+}
+
+// unitDuration returns amount units as a duration; months and years have none.
+func unitDuration(u unit, amount float64) time.Duration {
+	lengths := map[unit]time.Duration{unitMinute: time.Minute, unitHour: time.Hour, unitDay: 24 * time.Hour, unitWeek: 7 * 24 * time.Hour}
+	return time.Duration(amount * float64(lengths[u]))
+}
+
+// number parses a positive number written in digits, with a decimal point or comma, or as a word.
+func number(word string) (float64, bool) {
+	if n, ok := numberWords[word]; ok {
+		return n, true
+	}
+	if !numberPattern.MatchString(word) {
+		return 0, false
+	}
+
+	n, err := strconv.ParseFloat(strings.Replace(word, ",", ".", 1), 64)
+	return n, err == nil && n > 0
+}
+
+// compactMinutes parses lengths such as "1h", "1.5h", "1h30m", "90m", "90min" and "2ч" into minutes.
+func compactMinutes(word string) (float64, bool) {
+	if m := hoursPattern.FindStringSubmatch(word); m != nil {
+		hours, _ := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+		minutes := 0.0
+		if len(m[2]) > 0 {
+			minutes, _ = strconv.ParseFloat(m[2], 64)
+		}
+		total := hours*60 + minutes
+		return total, total > 0
+	}
+
+	if m := minutesPattern.FindStringSubmatch(word); m != nil {
+		minutes, _ := strconv.ParseFloat(m[1], 64)
+		return minutes, minutes > 0
+	}
+
+	return 0, false
+}
@@ -0,0 +1,291 @@
+package calendar
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// Weights of the ways a query word can match an indexed term.
+const (
+	exactMatchWeight  = 1.0
+	prefixMatchWeight = 0.7
+	typoMatchWeight   = 0.5
+)
+
+// Minimum query word lengths for prefix matching and for tolerating one and two typos.
+const (
+	minPrefixLength = 2
+	minOneTypoLen   = 4
+	minTwoTyposLen  = 8
+)
+
+// indexedField is a free-text field of an event and how much a match in it counts.
+type indexedField struct {
+	text   string
+	weight float64
+}
+
+// indexedFields lists the text of an event that search looks into.
+func indexedFields(event *model.Event) []indexedField {
+	return []indexedField{
+		{text: event.Title, weight: 2},
+		{text: event.Description, weight: 1},
+		{text: event.Location, weight: 1},
+	}
+}
+
+// searchIndex is an inverted index from terms to the events of one user containing them.
+// Keeping an index per user limits the typo scan of a query to the terms of the user searching.
+// It is not safe for concurrent use; the calendar lock guards it.
+type searchIndex struct {
+	// postings maps a term to the weighted number of its occurrences in each event.
+	postings map[string]map[string]float64
+	// terms holds every indexed term in sorted order for prefix lookups.
+	terms []string
+	// eventTerms remembers the terms of each event so that it can be removed.
+	eventTerms map[string][]string
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings:   make(map[string]map[string]float64),
+		eventTerms: make(map[string][]string),
+	}
+}
+
+// userIndex returns the search index of a user's events, creating it on first use. The caller must hold the write lock.
+func (s *serv) userIndex(userID int) *searchIndex {
+	idx, ok := s.indexes[userID]
+	if !ok {
+		idx = newSearchIndex()
+		s.indexes[userID] = idx
+	}
+
+	return idx
+}
+
+// add indexes event, replacing what was indexed for it before.
+func (idx *searchIndex) add(event *model.Event) {
+	idx.remove(event.ID)
+
+	weights := make(map[string]float64)
+	for _, field := range indexedFields(event) {
+		for _, term := range tokenize(field.text) {
+			weights[term] += field.weight
+		}
+	}
+
+	terms := make([]string, 0, len(weights))
+	for term, weight := range weights {
+		postings, ok := idx.postings[term]
+		if !ok {
+			postings = make(map[string]float64)
+			idx.postings[term] = postings
+			i, _ := slices.BinarySearch(idx.terms, term)
+			idx.terms = slices.Insert(idx.terms, i, term)
+		}
+		postings[event.ID] = weight
+		terms = append(terms, term)
+	}
+	idx.eventTerms[event.ID] = terms
+}
+
+// remove drops an event from the index.
+func (idx *searchIndex) remove(eventID string) {
+	for _, term := range idx.eventTerms[eventID] {
+		postings := idx.postings[term]
+		delete(postings, eventID)
+		if len(postings) == 0 {
+			delete(idx.postings, term)
+			if i, ok := slices.BinarySearch(idx.terms, term); ok {
+				idx.terms = slices.Delete(idx.terms, i, i+1)
+			}
+		}
+	}
+	delete(idx.eventTerms, eventID)
+}
+
+// match scores the events containing every word of the query. A word matches a term exactly,
+// as a prefix of it, or with a small number of typos; each event takes its best match per word.
+func (idx *searchIndex) match(words []string) map[string]float64 {
+	var scores map[string]float64
+	for _, word := range words {
+		wordScores := make(map[string]float64)
+		for term, weight := range idx.candidates(word) {
+			for eventID, occurrences := range idx.postings[term] {
+				wordScores[eventID] = max(wordScores[eventID], weight*occurrences)
+			}
+		}
+
+		if scores == nil {
+			scores = wordScores
+			continue
+		}
+		for eventID, score := range scores {
+			if wordScore, ok := wordScores[eventID]; ok {
+				scores[eventID] = score + wordScore
+			} else {
+				delete(scores, eventID)
+			}
+		}
+	}
+
+	return scores
+}
+
+// candidates returns the indexed terms word matches and the weight of each match.
+func (idx *searchIndex) candidates(word string) map[string]float64 {
+	result := make(map[string]float64)
+	if _, ok := idx.postings[word]; ok {
+		result[word] = exactMatchWeight
+	}
+
+	length := len([]rune(word))
+	if length >= minPrefixLength {
+		i := sort.SearchStrings(idx.terms, word)
+		for ; i < len(idx.terms) && strings.HasPrefix(idx.terms[i], word); i++ {
+			if idx.terms[i] != word {
+				result[idx.terms[i]] = prefixMatchWeight
+			}
+		}
+	}
+
+	maxTypos := 0
+	switch {
+	case length >= minTwoTyposLen:
+		maxTypos = 2
+	case length >= minOneTypoLen:
+		maxTypos = 1
+	}
+	if maxTypos == 0 {
+		return result
+	}
+
+	for _, term := range idx.terms {
+		if _, ok := result[term]; ok {
+			continue
+		}
+		if distance := editDistance(word, term, maxTypos); distance <= maxTypos {
+			result[term] = typoMatchWeight / float64(distance)
+		}
+	}
+
+	return result
+}
+
+// tokenize splits text into lower-cased words of letters and digits. Ё is folded into е.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = strings.ReplaceAll(word, "ё", "е")
+	}
+
+	return words
+}
+
+// editDistance returns the Levenshtein distance between a and b, or limit+1 once it is known to exceed limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// SearchEvents finds the user's events containing every word of the query, best matches first.
+// Ties are ordered by start date.
+func (s *serv) SearchEvents(ctx context.Context, query *model.SearchQuery) ([]*model.SearchHit, error) {
+	words := tokenize(query.Text)
+	if len(words) == 0 {
+		return nil, model.NewValidationError("q", model.ErrEmptySearchQuery)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hits := make([]*model.SearchHit, 0)
+	idx, ok := s.indexes[query.UserID]
+	if !ok {
+		return hits, nil
+	}
+
+	from, to := searchRange(query)
+	for eventID, score := range idx.match(words) {
+		event := s.events[eventID]
+		if !event.Overlaps(from, to) {
+			continue
+		}
+		hits = append(hits, &model.SearchHit{Event: event, Score: score})
+	}
+
+	slices.SortFunc(hits, func(a, b *model.SearchHit) int {
+		switch {
+		case a.Score != b.Score:
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		case !a.Event.Date.Equal(b.Event.Date):
+			return a.Event.Date.Compare(b.Event.Date)
+		default:
+			return strings.Compare(a.Event.ID, b.Event.ID)
+		}
+	})
+
+	if query.Limit > 0 && len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+
+	return hits, nil
+}
+
+// searchRange returns the range of a search query with its open ends moved out to the first and the last year
+// an event can have.
+func searchRange(query *model.SearchQuery) (from, to time.Time) {
+	loc := query.From.Location()
+	if query.From.IsZero() {
+		loc = query.To.Location()
+	}
+
+	from, to = query.From, query.To
+	if from.IsZero() {
+		from = time.Date(1, time.January, 1, 0, 0, 0, 0, loc)
+	}
+	if to.IsZero() {
+		to = time.Date(10000, time.January, 1, 0, 0, 0, 0, loc)
+	}
+
+	return from, to
+}
@@ -2,137 +2,415 @@ package calendar
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/biryanim/wb_tech_calendar/internal/model"
 	"github.com/biryanim/wb_tech_calendar/internal/service"
+	"github.com/oklog/ulid/v2"
 )
 
+// tombstoneRetention is how long deleted events are remembered for incremental sync.
+const tombstoneRetention = 30 * 24 * time.Hour
+
 var _ service.CalendarService = (*serv)(nil)
 
 type serv struct {
-	mu         sync.RWMutex
-	events     map[int]*model.Event
-	nextID     int
-	userEvents map[int][]int
+	mu sync.RWMutex
+	// events holds every event by ULID and userEvents the ULIDs of each user's events.
+	events     map[string]*model.Event
+	userEvents map[int][]string
+	// entropy makes the ULIDs generated within a millisecond grow monotonically.
+	entropy *ulid.MonotonicEntropy
+	// legacyIDs maps the legacy integer IDs still accepted for events to their ULIDs.
+	legacyIDs    map[int]string
+	nextLegacyID int
+	notifiers    []service.EventNotifier
+	// indexes holds the search index of each user's events.
+	indexes map[int]*searchIndex
+	// tags holds the tags of each user by name.
+	tags map[int]map[string]*model.Tag
+	// settings holds the settings each user has saved.
+	settings map[int]*model.UserSettings
+	// templates holds the event templates of each user by ID.
+	templates      map[int]map[int]*model.EventTemplate
+	nextTemplateID int
+
+	// seq is the last position in the change sequence; eventSeqs holds the position of each event's last change.
+	seq        int64
+	eventSeqs  map[string]int64
+	tombstones map[int][]model.Tombstone
+	// purgedSeq is the newest sequence position whose tombstone has been discarded.
+	purgedSeq int64
+
+	// clock tells the time of changes and the day dates such as "tomorrow" are read from.
+	clock service.Clock
 }
 
-// New creates and initializes a new in-memory calendar service.
-func New() *serv {
+// New creates and initializes a new in-memory calendar service that tells the time by clk.
+// Every successful mutation is reported to the given notifiers.
+func New(clk service.Clock, notifiers ...service.EventNotifier) *serv {
 	return &serv{
-		events:     make(map[int]*model.Event),
-		nextID:     1,
-		userEvents: make(map[int][]int),
+		events:       make(map[string]*model.Event),
+		userEvents:   make(map[int][]string),
+		entropy:      ulid.Monotonic(rand.Reader, 0),
+		legacyIDs:    make(map[int]string),
+		nextLegacyID: 1,
+		notifiers:    notifiers,
+		indexes:      make(map[int]*searchIndex),
+		tags:         make(map[int]map[string]*model.Tag),
+		settings:     make(map[int]*model.UserSettings),
+		eventSeqs:    make(map[string]int64),
+		tombstones:   make(map[int][]model.Tombstone),
+
+		templates:      make(map[int]map[int]*model.EventTemplate),
+		nextTemplateID: 1,
+
+		clock: clk,
 	}
 }
 
-// CreateEvent creates a new calendar event and assigns it a unique ID.
+// CreateEvent creates a new calendar event and assigns it a ULID and a legacy integer ID.
 func (s *serv) CreateEvent(ctx context.Context, event *model.Event) (*model.Event, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	event.ID = s.nextID
-	s.nextID++
+	return s.createEvent(event), nil
+}
 
-	s.events[event.ID] = event
-	s.userEvents[event.UserID] = append(s.userEvents[event.UserID], event.ID)
+// GetEvent returns a single event owned by the user.
+func (s *serv) GetEvent(ctx context.Context, eventID string, userID int) (*model.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	event, ok := s.events[s.resolveID(eventID)]
+	if !ok {
+		return nil, model.ErrEventNotFound
+	}
+
+	if event.UserID != userID {
+		return nil, fmt.Errorf("user id not match: %w", model.ErrEventNotFound)
+	}
 
 	return event, nil
 }
 
-// UpdateEvent updates an existing calendar event's date and title.
+// UpdateEvent replaces the writable fields of an existing calendar event and bumps its version.
 func (s *serv) UpdateEvent(ctx context.Context, event *model.Event) (*model.Event, error) {
+	return s.PatchEvent(ctx, event.ID, event.UserID, event.Version, func(cur *model.Event) error {
+		replaceFields(cur, event)
+		return nil
+	})
+}
+
+// PatchEvent applies patch to a copy of an existing event, validates it and bumps the version.
+func (s *serv) PatchEvent(ctx context.Context, eventID string, userID, version int, patch func(event *model.Event) error) (*model.Event, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	curEvent, ok := s.events[event.ID]
-	if !ok {
-		return nil, model.ErrEventNotFound
+	curEvent := s.events[s.resolveID(eventID)]
+	if err := checkEvent(curEvent, userID, version); err != nil {
+		return nil, err
 	}
 
-	if event.UserID != curEvent.UserID {
-		return nil, fmt.Errorf("user id not match: %w", model.ErrEventNotFound)
+	patched, err := patchedCopy(curEvent, patch)
+	if err != nil {
+		return nil, err
 	}
 
-	curEvent.Date = event.Date
-	curEvent.Title = event.Title
-
-	return curEvent, nil
+	return s.replaceEvent(patched), nil
 }
 
 // DeleteEvent removes a calendar event from the system.
-func (s *serv) DeleteEvent(ctx context.Context, eventID, userID int) error {
+func (s *serv) DeleteEvent(ctx context.Context, eventID string, userID, version int) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	event, ok := s.events[eventID]
-	if !ok {
-		return model.ErrEventNotFound
+	event := s.events[s.resolveID(eventID)]
+	if err := checkEvent(event, userID, version); err != nil {
+		return err
 	}
 
-	if event.UserID != userID {
-		return fmt.Errorf("user id not match: %w", model.ErrEventNotFound)
+	s.deleteEvent(event)
+
+	return nil
+}
+
+// Sync returns the user's events changed and deleted after the given change sequence position.
+// A zero position returns every event of the user.
+func (s *serv) Sync(ctx context.Context, userID int, since int64) (*model.SyncResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if since < 0 || since > s.seq {
+		return nil, model.ErrInvalidSyncToken
 	}
 
-	delete(s.events, eventID)
+	if since > 0 && since < s.purgedSeq {
+		return nil, model.ErrSyncTokenExpired
+	}
 
-	userEventsIDs := s.userEvents[userID]
-	for i, id := range userEventsIDs {
-		if id == event.ID {
-			userEventsIDs = append(userEventsIDs[:i], userEventsIDs[i+1:]...)
-			break
+	result := &model.SyncResult{
+		Upserts:       make([]*model.Event, 0),
+		Deleted:       make([]string, 0),
+		LegacyDeleted: make([]int, 0),
+		Seq:           s.seq,
+	}
+
+	for _, eventID := range s.userEvents[userID] {
+		if s.eventSeqs[eventID] > since {
+			result.Upserts = append(result.Upserts, s.events[eventID])
 		}
 	}
 
-	return nil
+	if since > 0 {
+		for _, t := range s.tombstones[userID] {
+			if t.Seq > since {
+				result.Deleted = append(result.Deleted, t.EventID)
+				result.LegacyDeleted = append(result.LegacyDeleted, t.LegacyEventID)
+			}
+		}
+	}
+
+	return result, nil
 }
 
 // GetEventsForDay retrieves all events for a specific user on a given calendar day.
 func (s *serv) GetEventsForDay(ctx context.Context, userID int, date time.Time) ([]*model.Event, error) {
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	startOfDay, endOfDay := model.DayRange(date)
 
-	fmt.Println("Start of day", startOfDay, endOfDay)
 	return s.getEventsInRange(userID, startOfDay, endOfDay)
 }
 
-// GetEventsForWeek retrieves all events for a specific user during a 7-day period.
+// GetEventsForWeek retrieves all events for a specific user during the calendar week containing date.
+// Weeks start on the day chosen in the user's settings.
 func (s *serv) GetEventsForWeek(ctx context.Context, userID int, date time.Time) ([]*model.Event, error) {
-	startOfWeek := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfWeek := startOfWeek.Add(7 * 24 * time.Hour)
+	s.mu.RLock()
+	weekStart := s.weekStart(userID)
+	s.mu.RUnlock()
+
+	startOfWeek, endOfWeek := model.WeekRange(date, weekStart)
 
 	return s.getEventsInRange(userID, startOfWeek, endOfWeek)
 }
 
 // GetEventsForMonth retrieves all events for a specific user during the calendar month
 func (s *serv) GetEventsForMonth(ctx context.Context, userID int, date time.Time) ([]*model.Event, error) {
-	startOfMonth := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
-	endOfMonth := startOfMonth.AddDate(0, 1, 0)
+	startOfMonth, endOfMonth := model.MonthRange(date)
 
 	return s.getEventsInRange(userID, startOfMonth, endOfMonth)
 }
 
-func (s *serv) getEventsInRange(userID int, startDate, endDate time.Time) ([]*model.Event, error) {
+// GetEventsInRange retrieves all events for a specific user taking up any of [from, to).
+func (s *serv) GetEventsInRange(ctx context.Context, userID int, from, to time.Time) ([]*model.Event, error) {
+	return s.getEventsInRange(userID, from, to)
+}
+
+// GetEventsInRangeForUsers retrieves the events of several users taking up any of [from, to) under a single lock.
+func (s *serv) GetEventsInRangeForUsers(ctx context.Context, userIDs []int, from, to time.Time) (map[int][]*model.Event, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	events, ok := s.userEvents[userID]
-	if !ok {
-		return nil, model.ErrEventNotFound
+	result := make(map[int][]*model.Event, len(userIDs))
+	for _, userID := range userIDs {
+		events := make([]*model.Event, 0)
+		for _, eventID := range s.userEvents[userID] {
+			event := s.events[eventID]
+			if event.Overlaps(from, to) {
+				events = append(events, event)
+			}
+		}
+		result[userID] = events
 	}
 
-	var result []*model.Event
+	return result, nil
+}
+
+func (s *serv) getEventsInRange(userID int, startDate, endDate time.Time) ([]*model.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*model.Event, 0)
 
-	for _, eventID := range events {
+	for _, eventID := range s.userEvents[userID] {
 		event := s.events[eventID]
-		fmt.Println(event.Date.After(startDate), event.Date.Before(endDate), event.Date)
-		if !event.Date.Before(startDate) && event.Date.Before(endDate) {
+		if event.Overlaps(startDate, endDate) {
 			result = append(result, event)
 		}
 	}
 
-	fmt.Println("Result", result)
 	return result, nil
 }
+
+// replaceFields copies the client-writable fields of src onto dst.
+func replaceFields(dst, src *model.Event) {
+	dst.Date = src.Date
+	dst.Title = src.Title
+	dst.End = src.End
+	dst.AllDay = src.AllDay
+	dst.Tags = src.Tags
+	dst.Description = src.Description
+	dst.Location = src.Location
+	dst.URL = src.URL
+	dst.Status = src.Status
+	dst.Transparency = src.Transparency
+	dst.Visibility = src.Visibility
+}
+
+// checkEvent verifies that event exists, is owned by userID and, for a non-zero version, has not changed since.
+func checkEvent(event *model.Event, userID, version int) error {
+	if event == nil {
+		return model.ErrEventNotFound
+	}
+
+	if event.UserID != userID {
+		return fmt.Errorf("user id not match: %w", model.ErrEventNotFound)
+	}
+
+	if version != 0 && version != event.Version {
+		current := *event
+		return &model.VersionConflictError{Current: &current}
+	}
+
+	return nil
+}
+
+// patchedCopy applies patch to a copy of event, keeps its IDs and owner, bumps the version and validates the result.
+func patchedCopy(event *model.Event, patch func(event *model.Event) error) (*model.Event, error) {
+	patched := *event
+	if err := patch(&patched); err != nil {
+		return nil, err
+	}
+	patched.ID = event.ID
+	patched.LegacyID = event.LegacyID
+	patched.UserID = event.UserID
+	patched.Version = event.Version + 1
+
+	if err := patched.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &patched, nil
+}
+
+// createEvent assigns a new event its IDs and stores it. The caller must hold the write lock.
+func (s *serv) createEvent(event *model.Event) *model.Event {
+	event.ID = s.newEventID()
+	event.LegacyID = s.nextLegacyID
+	event.Version = 1
+
+	return s.storeEvent(event)
+}
+
+// storeEvent stores a new event that has been assigned its IDs. The caller must hold the write lock.
+func (s *serv) storeEvent(event *model.Event) *model.Event {
+	event.SetDefaults()
+	s.nextLegacyID = max(s.nextLegacyID, event.LegacyID+1)
+
+	s.events[event.ID] = event
+	s.legacyIDs[event.LegacyID] = event.ID
+	s.userEvents[event.UserID] = append(s.userEvents[event.UserID], event.ID)
+	s.ensureTags(event)
+	s.userIndex(event.UserID).add(event)
+	s.record(model.ChangeCreated, event)
+
+	return event
+}
+
+// replaceEvent stores the patched copy of an event in place of the stored one. Stored events are
+// never modified, so the pointers already handed to callers stay consistent snapshots after the
+// lock is released. The caller must hold the write lock.
+func (s *serv) replaceEvent(patched *model.Event) *model.Event {
+	patched.SetDefaults()
+	s.events[patched.ID] = patched
+	s.ensureTags(patched)
+	s.userIndex(patched.UserID).add(patched)
+	s.record(model.ChangeUpdated, patched)
+
+	return patched
+}
+
+// deleteEvent removes a stored event. The caller must hold the write lock.
+func (s *serv) deleteEvent(event *model.Event) {
+	delete(s.events, event.ID)
+	delete(s.legacyIDs, event.LegacyID)
+
+	userEventsIDs := s.userEvents[event.UserID]
+	for i, id := range userEventsIDs {
+		if id == event.ID {
+			s.userEvents[event.UserID] = append(userEventsIDs[:i], userEventsIDs[i+1:]...)
+			break
+		}
+	}
+	s.userIndex(event.UserID).remove(event.ID)
+	s.record(model.ChangeDeleted, event)
+}
+
+// newEventID generates a ULID for a new event from the current time. The caller must hold the write lock.
+func (s *serv) newEventID() string {
+	return ulid.MustNew(ulid.Timestamp(s.clock.Now()), s.entropy).String()
+}
+
+// resolveID returns the ULID of the event eventID refers to, which may also be a legacy integer ID.
+// An unknown legacy ID resolves to an empty string. The caller must hold the lock.
+func (s *serv) resolveID(eventID string) string {
+	if legacyID, ok := model.ParseLegacyEventID(eventID); ok {
+		return s.legacyIDs[legacyID]
+	}
+
+	return eventID
+}
+
+// record advances the change sequence for a mutation of event and reports it to the notifiers.
+func (s *serv) record(changeType model.ChangeType, event *model.Event) {
+	s.seq++
+	now := s.clock.Now()
+
+	if changeType == model.ChangeDeleted {
+		delete(s.eventSeqs, event.ID)
+		s.tombstones[event.UserID] = append(s.tombstones[event.UserID], model.Tombstone{
+			EventID:       event.ID,
+			LegacyEventID: event.LegacyID,
+			UserID:        event.UserID,
+			Seq:           s.seq,
+			DeletedAt:     now,
+		})
+		s.pruneTombstones(now)
+	} else {
+		s.eventSeqs[event.ID] = s.seq
+	}
+
+	change := model.EventChange{
+		Seq:        s.seq,
+		Type:       changeType,
+		Event:      *event,
+		OccurredAt: now,
+	}
+
+	for _, n := range s.notifiers {
+		n.Notify(change)
+	}
+}
+
+// pruneTombstones drops tombstones older than tombstoneRetention. Sync tokens
+// issued before the newest dropped tombstone can no longer be served.
+func (s *serv) pruneTombstones(now time.Time) {
+	cutoff := now.Add(-tombstoneRetention)
+
+	for userID, tombstones := range s.tombstones {
+		i := 0
+		for i < len(tombstones) && tombstones[i].DeletedAt.Before(cutoff) {
+			s.purgedSeq = max(s.purgedSeq, tombstones[i].Seq)
+			i++
+		}
+
+		switch {
+		case i == len(tombstones):
+			delete(s.tombstones, userID)
+		case i > 0:
+			s.tombstones[userID] = tombstones[i:]
+		}
+	}
+}
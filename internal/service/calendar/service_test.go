@@ -2,38 +2,45 @@ package calendar
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service/clock"
+	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// testNow is the time the clocks of the tests show: Monday, 19 October 2026, 09:00 UTC.
+var testNow = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
 func TestCreateEvent(t *testing.T) {
-	s := New()
+	s := New(clock.NewFake(testNow))
 	ctx := context.Background()
 	event := &model.Event{
 		UserID: 1,
 		Title:  "test",
-		Date:   time.Now(),
+		Date:   testNow,
 	}
 
 	created, err := s.CreateEvent(ctx, event)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, created.ID)
+	assert.True(t, model.ValidEventID(created.ID))
+	assert.Equal(t, 1, created.LegacyID)
 	assert.Equal(t, event.Title, s.events[created.ID].Title)
 	assert.Equal(t, event.Date, s.events[created.ID].Date)
 	assert.Len(t, s.userEvents[event.UserID], 1)
 }
 
 func TestUpdateEvent(t *testing.T) {
-	s := New()
+	s := New(clock.NewFake(testNow))
 	ctx := context.Background()
 	event := &model.Event{
 		UserID: 2,
 		Title:  "original",
-		Date:   time.Now(),
+		Date:   testNow,
 	}
 
 	created, err := s.CreateEvent(ctx, event)
@@ -54,35 +61,35 @@ func TestUpdateEvent(t *testing.T) {
 	_, err = s.UpdateEvent(ctx, updated)
 	assert.Error(t, err)
 
-	updated.ID = 333
+	updated.ID = "333"
 	updated.UserID = 2
 	_, err = s.UpdateEvent(ctx, updated)
 	assert.Error(t, err)
 }
 
 func TestDeleteEvent(t *testing.T) {
-	s := New()
+	s := New(clock.NewFake(testNow))
 	ctx := context.Background()
 	event := &model.Event{
 		UserID: 1,
 		Title:  "test",
-		Date:   time.Now(),
+		Date:   testNow,
 	}
 	created, err := s.CreateEvent(ctx, event)
 	require.NoError(t, err)
 
-	err = s.DeleteEvent(ctx, created.ID, created.UserID)
+	err = s.DeleteEvent(ctx, created.ID, created.UserID, 0)
 	assert.NoError(t, err)
 	_, ok := s.events[created.ID]
 	assert.False(t, ok)
 
 	created2, err := s.CreateEvent(ctx, event)
-	err = s.DeleteEvent(ctx, created2.ID, 99)
+	err = s.DeleteEvent(ctx, created2.ID, 99, 0)
 	assert.Error(t, err)
 }
 
 func TestGetEventsForDay(t *testing.T) {
-	s := New()
+	s := New(clock.NewFake(testNow))
 	ctx := context.Background()
 	userID := 9
 	date := time.Date(2025, 10, 30, 10, 0, 0, 0, time.UTC)
@@ -110,29 +117,155 @@ func TestGetEventsForDay(t *testing.T) {
 }
 
 func TestGetEventsForWeek(t *testing.T) {
-	s := New()
+	s := New(clock.NewFake(testNow))
 	ctx := context.Background()
 	userID := 3
-	start := time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC)
-	mid := start.Add(4 * 24 * time.Hour)
-	late := start.Add(8 * 24 * time.Hour)
+	// Thursday; the week runs from Monday the 27th to Sunday the 2nd by default.
+	thursday := time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 11, 2, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC)
 
-	e1 := &model.Event{UserID: userID, Title: "test", Date: mid}
-	e2 := &model.Event{UserID: userID, Title: "test2", Date: late}
+	e1 := &model.Event{UserID: userID, Title: "test", Date: sunday}
+	e2 := &model.Event{UserID: userID, Title: "test2", Date: monday}
 
 	_, err := s.CreateEvent(ctx, e1)
 	require.NoError(t, err)
 	_, err = s.CreateEvent(ctx, e2)
 	require.NoError(t, err)
 
-	events, err := s.GetEventsForWeek(ctx, userID, start)
+	events, err := s.GetEventsForWeek(ctx, userID, thursday)
 	assert.NoError(t, err)
-	assert.Len(t, events, 1)
+	require.Len(t, events, 1)
 	assert.Equal(t, e1.Title, events[0].Title)
+
+	// A week starting on Sunday runs from the 26th to the 1st.
+	_, err = s.UpdateSettings(ctx, &model.UserSettings{UserID: userID, WeekStart: time.Sunday})
+	require.NoError(t, err)
+
+	events, err = s.GetEventsForWeek(ctx, userID, thursday)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = s.GetEventsForWeek(ctx, userID, monday)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestSettings(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	userID := 5
+
+	settings, err := s.GetSettings(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultUserSettings(userID), settings)
+
+	_, err = s.UpdateSettings(ctx, &model.UserSettings{UserID: userID, WeekStart: 7})
+	assert.ErrorIs(t, err, model.ErrInvalidWeekday)
+
+	_, err = s.UpdateSettings(ctx, &model.UserSettings{UserID: userID, WeekStart: time.Sunday})
+	require.NoError(t, err)
+
+	settings, err = s.GetSettings(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, time.Sunday, settings.WeekStart)
+
+	// Weekly statistics follow the first weekday too.
+	saturday := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	_, err = s.CreateEvent(ctx, &model.Event{UserID: userID, Title: "run", Date: saturday})
+	require.NoError(t, err)
+	_, err = s.CreateEvent(ctx, &model.Event{UserID: userID, Title: "read", Date: saturday.AddDate(0, 0, 1)})
+	require.NoError(t, err)
+
+	stats, err := s.GetTagStats(ctx, &model.TagStatsQuery{
+		UserID: userID,
+		From:   time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+		Period: model.PeriodWeek,
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, time.Sunday, stats[0].Start.Weekday())
+	assert.Equal(t, 1, stats[0].Total)
+	assert.Equal(t, 1, stats[1].Total)
+}
+
+func TestGetEvents_MultiDay(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	userID := 4
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	conference, err := s.CreateEvent(ctx, &model.Event{
+		UserID: userID,
+		Title:  "conference",
+		Date:   monday,
+		End:    monday.AddDate(0, 0, 3),
+		AllDay: true,
+	})
+	require.NoError(t, err)
+	holiday, err := s.CreateEvent(ctx, &model.Event{UserID: userID, Title: "holiday", Date: monday.AddDate(0, 0, 4), AllDay: true})
+	require.NoError(t, err)
+	assert.Equal(t, monday.AddDate(0, 0, 5), holiday.End)
+	shift, err := s.CreateEvent(ctx, &model.Event{
+		UserID: userID,
+		Title:  "night shift",
+		Date:   monday.Add(22 * time.Hour),
+		End:    monday.Add(30 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	titles := func(events []*model.Event) []string {
+		result := make([]string, 0, len(events))
+		for _, event := range events {
+			result = append(result, event.Title)
+		}
+		return result
+	}
+
+	tuesday := monday.AddDate(0, 0, 1)
+	events, err := s.GetEventsForDay(ctx, userID, tuesday)
+	require.NoError(t, err)
+	assert.Equal(t, []string{conference.Title, shift.Title}, titles(events))
+
+	events, err = s.GetEventsForDay(ctx, userID, monday.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// All-day events keep their dates in every zone, timed ones are placed by their instants.
+	vladivostok, err := time.LoadLocation("Asia/Vladivostok")
+	require.NoError(t, err)
+	events, err = s.GetEventsForDay(ctx, userID, time.Date(2026, 10, 23, 0, 0, 0, 0, vladivostok))
+	require.NoError(t, err)
+	assert.Equal(t, []string{holiday.Title}, titles(events))
+
+	events, err = s.GetEventsForMonth(ctx, userID, monday)
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	from, to := model.DayRange(tuesday)
+	before, after := conference.Continues(from, to)
+	assert.True(t, before)
+	assert.True(t, after)
+	before, after = holiday.Continues(model.WeekRange(monday, time.Monday))
+	assert.False(t, before)
+	assert.False(t, after)
+
+	_, err = s.PatchEvent(ctx, conference.ID, userID, 0, func(event *model.Event) error {
+		event.End = monday.Add(12 * time.Hour)
+		return nil
+	})
+	assert.ErrorIs(t, err, model.ErrNotWholeDay)
+
+	_, err = s.PatchEvent(ctx, shift.ID, userID, 0, func(event *model.Event) error {
+		event.End = event.Date
+		return nil
+	})
+	assert.ErrorIs(t, err, model.ErrInvalidEnd)
 }
 
 func TestGetEventsForMonth(t *testing.T) {
-	s := New()
+	s := New(clock.NewFake(testNow))
 	ctx := context.Background()
 	userID := 3
 	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
@@ -153,18 +286,1053 @@ func TestGetEventsForMonth(t *testing.T) {
 	assert.Equal(t, e1.Title, events[0].Title)
 }
 
-func TestGetEvents_NotFound(t *testing.T) {
-	s := New()
+func TestGetEvents_NoEvents(t *testing.T) {
+	s := New(clock.NewFake(testNow))
 	ctx := context.Background()
 	userID := 3
-	date := time.Now()
+	date := testNow
+
+	events, err := s.GetEventsForDay(ctx, userID, date)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = s.GetEventsForWeek(ctx, userID, date)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = s.GetEventsForMonth(ctx, userID, date)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestSync(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	userID := 5
+
+	e1, err := s.CreateEvent(ctx, &model.Event{UserID: userID, Title: "first", Date: testNow})
+	require.NoError(t, err)
+	e2, err := s.CreateEvent(ctx, &model.Event{UserID: userID, Title: "second", Date: testNow})
+	require.NoError(t, err)
+	_, err = s.CreateEvent(ctx, &model.Event{UserID: 6, Title: "other", Date: testNow})
+	require.NoError(t, err)
+
+	full, err := s.Sync(ctx, userID, 0)
+	require.NoError(t, err)
+	assert.Len(t, full.Upserts, 2)
+	assert.Empty(t, full.Deleted)
+
+	_, err = s.UpdateEvent(ctx, &model.Event{ID: e1.ID, UserID: userID, Title: "changed", Date: e1.Date})
+	require.NoError(t, err)
+	err = s.DeleteEvent(ctx, e2.ID, userID, 0)
+	require.NoError(t, err)
+
+	delta, err := s.Sync(ctx, userID, full.Seq)
+	require.NoError(t, err)
+	require.Len(t, delta.Upserts, 1)
+	assert.Equal(t, "changed", delta.Upserts[0].Title)
+	assert.Equal(t, []string{e2.ID}, delta.Deleted)
+	assert.Equal(t, []int{e2.LegacyID}, delta.LegacyDeleted)
+	assert.Greater(t, delta.Seq, full.Seq)
 
-	_, err := s.GetEventsForDay(ctx, userID, date)
+	empty, err := s.Sync(ctx, userID, delta.Seq)
+	require.NoError(t, err)
+	assert.Empty(t, empty.Upserts)
+	assert.Empty(t, empty.Deleted)
+
+	_, err = s.Sync(ctx, userID, delta.Seq+1)
+	assert.ErrorIs(t, err, model.ErrInvalidSyncToken)
+}
+
+func TestSync_TokenExpired(t *testing.T) {
+	clk := clock.NewFake(testNow)
+	s := New(clk)
+	ctx := context.Background()
+	userID := 5
+
+	e1, err := s.CreateEvent(ctx, &model.Event{UserID: userID, Title: "old", Date: testNow})
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteEvent(ctx, e1.ID, userID, 0))
+	assert.Equal(t, testNow, s.tombstones[userID][0].DeletedAt)
+	clk.Advance(2 * tombstoneRetention)
+
+	e2, err := s.CreateEvent(ctx, &model.Event{UserID: userID, Title: "new", Date: testNow})
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteEvent(ctx, e2.ID, userID, 0))
+
+	_, err = s.Sync(ctx, userID, 1)
+	assert.ErrorIs(t, err, model.ErrSyncTokenExpired)
+
+	res, err := s.Sync(ctx, userID, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{e2.ID}, res.Deleted)
+}
+
+func TestUpdateEvent_VersionConflict(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	created, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "original", Date: testNow})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.Version)
+
+	res, err := s.UpdateEvent(ctx, &model.Event{ID: created.ID, UserID: 1, Title: "first", Date: created.Date, Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Version)
+
+	_, err = s.UpdateEvent(ctx, &model.Event{ID: created.ID, UserID: 1, Title: "stale", Date: created.Date, Version: 1})
+	assert.ErrorIs(t, err, model.ErrVersionConflict)
+
+	var conflict *model.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "first", conflict.Current.Title)
+	assert.Equal(t, 2, conflict.Current.Version)
+
+	res, err = s.UpdateEvent(ctx, &model.Event{ID: created.ID, UserID: 1, Title: "forced", Date: created.Date})
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Version)
+}
+
+func TestDeleteEvent_VersionConflict(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	created, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "test", Date: testNow})
+	require.NoError(t, err)
+
+	err = s.DeleteEvent(ctx, created.ID, 1, 2)
+	assert.ErrorIs(t, err, model.ErrVersionConflict)
+	assert.Contains(t, s.events, created.ID)
+
+	err = s.DeleteEvent(ctx, created.ID, 1, 1)
+	assert.NoError(t, err)
+}
+
+func TestPatchEvent(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	created, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "original", Date: testNow})
+	require.NoError(t, err)
+	date := created.Date
+
+	res, err := s.PatchEvent(ctx, created.ID, 1, 0, func(event *model.Event) error {
+		event.Title = "patched"
+		event.ID = "100"
+		event.LegacyID = 100
+		event.UserID = 100
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "patched", res.Title)
+	assert.Equal(t, date, res.Date)
+	assert.Equal(t, created.ID, res.ID)
+	assert.Equal(t, created.LegacyID, res.LegacyID)
+	assert.Equal(t, 1, res.UserID)
+	assert.Equal(t, 2, res.Version)
+
+	_, err = s.PatchEvent(ctx, created.ID, 1, 0, func(event *model.Event) error {
+		event.Title = ""
+		return nil
+	})
+	assert.ErrorIs(t, err, model.ErrEmptyTitle)
+	assert.Equal(t, "patched", s.events[created.ID].Title)
+
+	_, err = s.PatchEvent(ctx, created.ID, 1, 1, func(event *model.Event) error { return nil })
+	assert.ErrorIs(t, err, model.ErrVersionConflict)
+
+	_, err = s.PatchEvent(ctx, created.ID, 2, 0, func(event *model.Event) error { return nil })
 	assert.ErrorIs(t, err, model.ErrEventNotFound)
+}
+
+// TestPatchEvent_ConcurrentReads checks under -race that updates do not modify the events
+// readers have already been given.
+func TestPatchEvent_ConcurrentReads(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	created, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "original", Date: testNow})
+	require.NoError(t, err)
 
-	_, err = s.GetEventsForWeek(ctx, userID, date)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_, err := s.PatchEvent(ctx, created.ID, 1, 0, func(event *model.Event) error {
+				event.Title = "patched"
+				return nil
+			})
+			assert.NoError(t, err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_, err := s.ApplyBatch(ctx, []model.BatchOp{{
+				Type:  model.BatchUpdate,
+				Event: model.Event{ID: created.ID, UserID: 1, Title: "batched", Date: testNow},
+			}}, false)
+			assert.NoError(t, err)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		event, err := s.GetEvent(ctx, created.ID, 1)
+		require.NoError(t, err)
+		assert.NotEmpty(t, event.Title)
+		assert.Positive(t, event.Version)
+	}
+	wg.Wait()
+
+	assert.Equal(t, "original", created.Title)
+	assert.Equal(t, 1, created.Version)
+	assert.Equal(t, 201, s.events[created.ID].Version)
+}
+
+func TestEventIDs(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	first, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "first", Date: testNow})
+	require.NoError(t, err)
+	second, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "second", Date: testNow})
+	require.NoError(t, err)
+
+	id, err := ulid.ParseStrict(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ulid.Timestamp(testNow), id.Time())
+	assert.Less(t, first.ID, second.ID, "IDs made in the same millisecond keep the creation order")
+	assert.Equal(t, 2, second.LegacyID)
+
+	event, err := s.GetEvent(ctx, "2", 1)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, event.ID)
+
+	patched, err := s.PatchEvent(ctx, "1", 1, 0, func(event *model.Event) error {
+		event.Title = "patched"
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, patched.ID)
+
+	require.NoError(t, s.DeleteEvent(ctx, "1", 1, 0))
+	assert.NotContains(t, s.events, first.ID)
+	_, err = s.GetEvent(ctx, "1", 1)
 	assert.ErrorIs(t, err, model.ErrEventNotFound)
 
-	_, err = s.GetEventsForMonth(ctx, userID, date)
+	third, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "third", Date: testNow})
+	require.NoError(t, err)
+	assert.Equal(t, 3, third.LegacyID, "legacy IDs are not reused")
+}
+
+func TestGetEvent(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	created, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "test", Date: testNow})
+	require.NoError(t, err)
+
+	event, err := s.GetEvent(ctx, created.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", event.Title)
+
+	_, err = s.GetEvent(ctx, created.ID, 2)
+	assert.ErrorIs(t, err, model.ErrEventNotFound)
+
+	_, err = s.GetEvent(ctx, "100", 1)
 	assert.ErrorIs(t, err, model.ErrEventNotFound)
 }
+
+func TestValidate_AllFields(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	created, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "test", Date: testNow})
+	require.NoError(t, err)
+
+	_, err = s.PatchEvent(ctx, created.ID, 1, 0, func(event *model.Event) error {
+		event.Title = ""
+		event.Date = time.Time{}
+		return nil
+	})
+
+	var verr *model.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Fields, 2)
+	assert.ErrorIs(t, err, model.ErrEmptyTitle)
+	assert.ErrorIs(t, err, model.ErrInvalidDate)
+}
+
+func TestEventDetails(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	created, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "Conference", Date: date, Location: "Kazan Expo"})
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusConfirmed, created.Status)
+	assert.Equal(t, model.TransparencyBusy, created.Transparency)
+	assert.Equal(t, model.VisibilityPublic, created.Visibility)
+	assert.True(t, created.Blocks())
+
+	hits, err := s.SearchEvents(ctx, &model.SearchQuery{UserID: 1, Text: "kazan"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+
+	updated, err := s.PatchEvent(ctx, created.ID, 1, 0, func(event *model.Event) error {
+		event.Status = model.StatusCancelled
+		event.Visibility = model.VisibilityPrivate
+		event.Description = "Rescheduled"
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.Blocks())
+
+	redacted := updated.Redacted()
+	assert.Empty(t, redacted.Title)
+	assert.Empty(t, redacted.Location)
+	assert.Empty(t, redacted.Description)
+	assert.Equal(t, model.StatusCancelled, redacted.Status)
+	assert.Equal(t, "Conference", updated.Title)
+
+	_, err = s.PatchEvent(ctx, created.ID, 1, 0, func(event *model.Event) error {
+		event.Transparency = "opaque"
+		return nil
+	})
+	assert.ErrorIs(t, err, model.ErrInvalidTransparency)
+}
+
+func TestGetEventsInRangeForUsers(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "in", Date: day})
+	require.NoError(t, err)
+	_, err = s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "out", Date: day.AddDate(0, 1, 0)})
+	require.NoError(t, err)
+	_, err = s.CreateEvent(ctx, &model.Event{UserID: 2, Title: "other", Date: day})
+	require.NoError(t, err)
+
+	res, err := s.GetEventsInRangeForUsers(ctx, []int{1, 2, 3}, day, day.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	require.Len(t, res[1], 1)
+	assert.Equal(t, "in", res[1][0].Title)
+	require.Len(t, res[2], 1)
+	assert.Equal(t, "other", res[2][0].Title)
+	assert.Empty(t, res[3])
+	assert.NotNil(t, res[3])
+}
+
+type recordingNotifier struct {
+	changes []model.EventChange
+}
+
+func (n *recordingNotifier) Notify(change model.EventChange) {
+	n.changes = append(n.changes, change)
+}
+
+func TestApplyBatch(t *testing.T) {
+	n := &recordingNotifier{}
+	s := New(clock.NewFake(testNow), n)
+	ctx := context.Background()
+	date := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	existing, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "existing", Date: date})
+	require.NoError(t, err)
+
+	results, err := s.ApplyBatch(ctx, []model.BatchOp{
+		{Type: model.BatchCreate, Event: model.Event{UserID: 1, Title: "lecture", Date: date}},
+		{Type: model.BatchUpdate, Event: model.Event{ID: "2", UserID: 1, Title: "seminar", Date: date}},
+		{Type: model.BatchUpdate, Event: model.Event{ID: existing.ID, UserID: 1, Title: "renamed", Date: date, Version: 1}},
+		{Type: model.BatchDelete, Event: model.Event{ID: existing.ID, UserID: 1, Version: 2}},
+	}, false)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	for _, r := range results {
+		require.NoError(t, r.Err)
+	}
+
+	assert.Equal(t, 2, results[0].Event.LegacyID)
+	assert.Equal(t, results[0].Event.ID, results[1].Event.ID)
+	// Each result is the event as its operation left it, not as later operations changed it.
+	assert.Equal(t, "lecture", results[0].Event.Title)
+	assert.Equal(t, 1, results[0].Event.Version)
+	assert.Equal(t, 2, results[1].Event.Version)
+	assert.Equal(t, "renamed", results[2].Event.Title)
+	assert.Equal(t, "seminar", s.events[results[0].Event.ID].Title)
+	assert.Equal(t, results[0].Event.ID, s.legacyIDs[2])
+	assert.NotContains(t, s.events, existing.ID)
+	assert.Len(t, n.changes, 5)
+	assert.Equal(t, model.ChangeDeleted, n.changes[4].Type)
+	assert.Equal(t, testNow, n.changes[4].OccurredAt)
+}
+
+func TestApplyBatch_AllOrNothing(t *testing.T) {
+	n := &recordingNotifier{}
+	s := New(clock.NewFake(testNow), n)
+	ctx := context.Background()
+	date := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	ops := []model.BatchOp{
+		{Type: model.BatchCreate, Event: model.Event{UserID: 1, Title: "lecture", Date: date}},
+		{Type: model.BatchDelete, Event: model.Event{ID: "42", UserID: 1}},
+		{Type: model.BatchCreate, Event: model.Event{UserID: 1, Date: date}},
+		{Type: "move", Event: model.Event{ID: "1", UserID: 1}},
+	}
+
+	results, err := s.ApplyBatch(ctx, ops, false)
+	require.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, model.ErrBatchAborted)
+	assert.ErrorIs(t, results[1].Err, model.ErrEventNotFound)
+	assert.ErrorIs(t, results[2].Err, model.ErrEmptyTitle)
+	assert.ErrorIs(t, results[3].Err, model.ErrInvalidBatchOp)
+	assert.Empty(t, s.events)
+	assert.Empty(t, n.changes)
+	assert.Equal(t, 1, s.nextLegacyID)
+
+	results, err = s.ApplyBatch(ctx, ops, true)
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, 1, results[0].Event.LegacyID)
+	assert.ErrorIs(t, results[1].Err, model.ErrEventNotFound)
+	assert.Len(t, s.events, 1)
+	assert.Len(t, n.changes, 1)
+}
+
+func TestApplyBatch_VersionConflict(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	date := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	created, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "existing", Date: date})
+	require.NoError(t, err)
+
+	results, err := s.ApplyBatch(ctx, []model.BatchOp{
+		{Type: model.BatchUpdate, Event: model.Event{ID: created.ID, UserID: 1, Title: "first", Date: date, Version: 1}},
+		{Type: model.BatchUpdate, Event: model.Event{ID: created.ID, UserID: 1, Title: "second", Date: date, Version: 1}},
+	}, false)
+	require.NoError(t, err)
+
+	var conflict *model.VersionConflictError
+	require.ErrorAs(t, results[1].Err, &conflict)
+	assert.Equal(t, "first", conflict.Current.Title)
+	assert.Equal(t, "existing", s.events[created.ID].Title)
+	assert.Equal(t, 1, s.events[created.ID].Version)
+}
+
+func TestSearchEvents(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	date := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	lecture, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "Лекция по алгоритмам", Date: date})
+	require.NoError(t, err)
+	review, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "Algorithms review", Date: date.AddDate(0, 0, 1)})
+	require.NoError(t, err)
+	exam, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "Algorithms exam: algorithms and data structures", Date: date.AddDate(0, 1, 0)})
+	require.NoError(t, err)
+	_, err = s.CreateEvent(ctx, &model.Event{UserID: 2, Title: "Algorithms review", Date: date})
+	require.NoError(t, err)
+
+	search := func(q model.SearchQuery) []string {
+		t.Helper()
+		q.UserID = 1
+		hits, err := s.SearchEvents(ctx, &q)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(hits))
+		for _, hit := range hits {
+			ids = append(ids, hit.Event.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{exam.ID, review.ID}, search(model.SearchQuery{Text: "algorithms"}))
+	assert.Equal(t, []string{exam.ID, review.ID}, search(model.SearchQuery{Text: "algo"}))
+	assert.Equal(t, []string{exam.ID, review.ID}, search(model.SearchQuery{Text: "algoritms"}))
+	assert.Equal(t, []string{review.ID}, search(model.SearchQuery{Text: "ALGORITHMS Review"}))
+	assert.Equal(t, []string{lecture.ID}, search(model.SearchQuery{Text: "лекци"}))
+	assert.Equal(t, []string{review.ID}, search(model.SearchQuery{Text: "algorithms", To: date.AddDate(0, 0, 7)}))
+	assert.Equal(t, []string{exam.ID}, search(model.SearchQuery{Text: "algorithms", From: date.AddDate(0, 0, 2)}))
+	assert.Empty(t, search(model.SearchQuery{Text: "algorithms", From: date.AddDate(0, 0, 2), To: date.AddDate(0, 0, 3)}))
+	assert.Equal(t, []string{exam.ID}, search(model.SearchQuery{Text: "algorithms", Limit: 1}))
+	assert.Empty(t, search(model.SearchQuery{Text: "physics"}))
+
+	_, err = s.UpdateEvent(ctx, &model.Event{ID: review.ID, UserID: 1, Title: "Physics review", Date: review.Date})
+	require.NoError(t, err)
+	assert.Equal(t, []string{review.ID}, search(model.SearchQuery{Text: "physics"}))
+	assert.Equal(t, []string{exam.ID}, search(model.SearchQuery{Text: "algorithms"}))
+
+	require.NoError(t, s.DeleteEvent(ctx, review.ID, 1, 0))
+	assert.Empty(t, search(model.SearchQuery{Text: "physics"}))
+	assert.NotContains(t, s.indexes[1].terms, "physics")
+
+	// A multi-day event is found in a range that starts while it goes on, as in range queries.
+	trip, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "Conference trip", Date: date, End: date.AddDate(0, 0, 3)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{trip.ID}, search(model.SearchQuery{Text: "conference", From: date.AddDate(0, 0, 2), To: date.AddDate(0, 0, 5)}))
+	assert.Equal(t, []string{trip.ID}, search(model.SearchQuery{Text: "conference", From: date.AddDate(0, 0, 2)}))
+	assert.Empty(t, search(model.SearchQuery{Text: "conference", From: date.AddDate(0, 0, 3)}))
+
+	hits, err := s.SearchEvents(ctx, &model.SearchQuery{UserID: 3, Text: "conference"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	_, err = s.SearchEvents(ctx, &model.SearchQuery{UserID: 1, Text: " !? "})
+	assert.ErrorIs(t, err, model.ErrEmptySearchQuery)
+}
+
+func TestTags(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	date := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	_, err := s.CreateTag(ctx, &model.Tag{UserID: 1, Name: "algorithms", Category: "study", Color: "#ff0000"})
+	require.NoError(t, err)
+	_, err = s.CreateTag(ctx, &model.Tag{UserID: 1, Name: "algorithms"})
+	assert.ErrorIs(t, err, model.ErrTagExists)
+	_, err = s.CreateTag(ctx, &model.Tag{UserID: 1, Name: "Bad Tag", Color: "red"})
+	assert.ErrorIs(t, err, model.ErrInvalidTag)
+	assert.ErrorIs(t, err, model.ErrInvalidColor)
+
+	event, err := s.CreateEvent(ctx, &model.Event{UserID: 1, Title: "lecture", Date: date, Tags: []string{"algorithms", "urgent"}})
+	require.NoError(t, err)
+
+	tags, err := s.GetTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "urgent", tags[1].Name)
+
+	_, err = s.UpdateTag(ctx, "algorithms", &model.Tag{UserID: 1, Name: "urgent"})
+	assert.ErrorIs(t, err, model.ErrTagExists)
+	_, err = s.UpdateTag(ctx, "missing", &model.Tag{UserID: 1, Name: "missing"})
+	assert.ErrorIs(t, err, model.ErrTagNotFound)
+
+	_, err = s.UpdateTag(ctx, "algorithms", &model.Tag{UserID: 1, Name: "algo", Category: "study"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"algo", "urgent"}, s.events[event.ID].Tags)
+	assert.Equal(t, 2, s.events[event.ID].Version)
+
+	require.NoError(t, s.DeleteTag(ctx, 1, "urgent"))
+	assert.Equal(t, []string{"algo"}, s.events[event.ID].Tags)
+	assert.Equal(t, 3, s.events[event.ID].Version)
+	assert.ErrorIs(t, s.DeleteTag(ctx, 1, "urgent"), model.ErrTagNotFound)
+}
+
+func TestGetTagStats(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	monday := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	_, err := s.CreateTag(ctx, &model.Tag{UserID: 1, Name: "algorithms", Category: "study"})
+	require.NoError(t, err)
+	_, err = s.CreateTag(ctx, &model.Tag{UserID: 1, Name: "physics", Category: "study"})
+	require.NoError(t, err)
+
+	for _, e := range []*model.Event{
+		{UserID: 1, Title: "lecture", Date: monday, Tags: []string{"algorithms", "physics"}},
+		{UserID: 1, Title: "gym", Date: monday.AddDate(0, 0, 2), Tags: []string{"sport"}},
+		{UserID: 1, Title: "call", Date: monday.AddDate(0, 0, 7)},
+	} {
+		_, err = s.CreateEvent(ctx, e)
+		require.NoError(t, err)
+	}
+
+	stats, err := s.GetTagStats(ctx, &model.TagStatsQuery{
+		UserID: 1,
+		From:   time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		Period: model.PeriodWeek,
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), stats[0].Start)
+	assert.Equal(t, 1, stats[0].Total)
+	assert.Equal(t, map[string]int{"sport": 1}, stats[0].Tags)
+	assert.Equal(t, 1, stats[1].Untagged)
+
+	stats, err = s.GetTagStats(ctx, &model.TagStatsQuery{
+		UserID: 1,
+		From:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		Period: model.PeriodMonth,
+		Filter: model.TagFilter{Exclude: []string{"sport"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, 2, stats[0].Total)
+	assert.Equal(t, map[string]int{"algorithms": 1, "physics": 1}, stats[0].Tags)
+	assert.Equal(t, map[string]int{"study": 1}, stats[0].Categories)
+
+	_, err = s.GetTagStats(ctx, &model.TagStatsQuery{UserID: 1, Period: "year"})
+	assert.ErrorIs(t, err, model.ErrInvalidPeriod)
+}
+
+func TestGetTagStats_MultiDay(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	date := func(day int) time.Time {
+		return time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	for _, e := range []*model.Event{
+		{UserID: 1, Title: "holiday", Date: date(20), AllDay: true, Tags: []string{"rest"}},
+		{UserID: 1, Title: "conference", Date: date(26), End: date(29), AllDay: true, Tags: []string{"work"}},
+		{UserID: 1, Title: "trip", Date: time.Date(2026, 10, 17, 12, 0, 0, 0, newYork), End: time.Date(2026, 10, 19, 12, 0, 0, 0, newYork)},
+	} {
+		_, err = s.CreateEvent(ctx, e)
+		require.NoError(t, err)
+	}
+
+	stats, err := s.GetTagStats(ctx, &model.TagStatsQuery{
+		UserID: 1,
+		From:   time.Date(2026, 10, 18, 0, 0, 0, 0, newYork),
+		To:     time.Date(2026, 10, 29, 0, 0, 0, 0, newYork),
+		Period: model.PeriodDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 11)
+
+	totals := make(map[int]int)
+	for _, bucket := range stats {
+		totals[bucket.Start.Day()] = bucket.Total
+	}
+	assert.Equal(t, map[int]int{
+		18: 1, 19: 1, 20: 1, 21: 0, 22: 0, 23: 0, 24: 0, 25: 0, 26: 1, 27: 1, 28: 1,
+	}, totals)
+	assert.Equal(t, map[string]int{"rest": 1}, stats[2].Tags)
+	assert.Equal(t, map[string]int{"work": 1}, stats[9].Tags)
+}
+
+func TestGetMonthGrid(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	userID := 6
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	create := func(event *model.Event) *model.Event {
+		event.UserID = userID
+		created, err := s.CreateEvent(ctx, event)
+		require.NoError(t, err)
+		return created
+	}
+	late := create(&model.Event{Title: "late", Date: time.Date(2026, 10, 7, 15, 0, 0, 0, time.UTC)})
+	early := create(&model.Event{Title: "early", Date: time.Date(2026, 10, 7, 6, 0, 0, 0, time.UTC)})
+	trip := create(&model.Event{Title: "trip", Date: time.Date(2026, 10, 6, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC), AllDay: true})
+	create(&model.Event{Title: "extra", Date: time.Date(2026, 10, 7, 18, 0, 0, 0, time.UTC)})
+	// 23:30 UTC on the 31st is already November 1st in Moscow.
+	halloween := create(&model.Event{Title: "party", Date: time.Date(2026, 10, 31, 23, 30, 0, 0, time.UTC)})
+
+	grid, err := s.GetMonthGrid(ctx, &model.MonthGridQuery{
+		UserID:    userID,
+		Date:      time.Date(2026, 10, 15, 0, 0, 0, 0, moscow),
+		MaxEvents: 3,
+	})
+	require.NoError(t, err)
+	require.Len(t, grid.Weeks, model.GridWeeks)
+	assert.Equal(t, time.Monday, grid.WeekStart)
+
+	// October 2026 starts on a Thursday, so the grid starts on Monday, September 28th.
+	first := grid.Weeks[0][0]
+	assert.Equal(t, time.Date(2026, 9, 28, 0, 0, 0, 0, moscow), first.Date)
+	assert.False(t, first.InMonth)
+	assert.True(t, grid.Weeks[0][5].Weekend)
+	assert.False(t, grid.Weeks[0][4].Weekend)
+
+	wednesday := grid.Weeks[1][2]
+	assert.Equal(t, 7, wednesday.Date.Day())
+	assert.True(t, wednesday.InMonth)
+	assert.Equal(t, []*model.Event{trip, early, late}, wednesday.Events)
+	assert.Equal(t, 1, wednesday.Overflow)
+
+	sunday := grid.Weeks[4][6]
+	assert.Equal(t, 1, sunday.Date.Day())
+	assert.False(t, sunday.InMonth)
+	assert.Equal(t, []*model.Event{halloween}, sunday.Events)
+
+	for _, week := range grid.Weeks {
+		require.Len(t, week, 7)
+	}
+
+	sundayFirst := time.Sunday
+	grid, err = s.GetMonthGrid(ctx, &model.MonthGridQuery{
+		UserID:    userID,
+		Date:      time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+		WeekStart: &sundayFirst,
+		Filter:    model.TagFilter{Include: []string{"none"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 9, 27, 0, 0, 0, 0, time.UTC), grid.Weeks[0][0].Date)
+	assert.Empty(t, grid.Weeks[1][3].Events)
+}
+
+func TestGetWeekView(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	userID := 6
+
+	holidays := model.NewHolidayCalendar("ru")
+	holidays.AddYear(2025, []*model.Holiday{
+		{Date: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), Name: "working saturday", Kind: model.HolidayWorkday},
+		{Date: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), Name: "unity day", Kind: model.HolidayDayOff},
+		{Date: time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC), Name: "unity day", Kind: model.HolidayDayOff},
+	})
+
+	event, err := s.CreateEvent(ctx, &model.Event{UserID: userID, Title: "standup", Date: time.Date(2025, 11, 5, 9, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+
+	view, err := s.GetWeekView(ctx, &model.WeekViewQuery{
+		UserID:   userID,
+		Date:     time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
+		Holidays: holidays,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), view.Start)
+	assert.Equal(t, time.Monday, view.WeekStart)
+	require.Len(t, view.Days, 7)
+
+	monday := view.Days[0]
+	assert.True(t, monday.Holiday)
+	assert.False(t, monday.Workday)
+	assert.Equal(t, "unity day", monday.HolidayName)
+	assert.Equal(t, []*model.Event{event}, view.Days[2].Events)
+	assert.True(t, view.Days[2].Workday)
+	assert.True(t, view.Days[5].Weekend)
+	assert.False(t, view.Days[5].Workday)
+
+	saturday := time.Saturday
+	view, err = s.GetWeekView(ctx, &model.WeekViewQuery{
+		UserID:    userID,
+		Date:      time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
+		WeekStart: &saturday,
+		Holidays:  holidays,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), view.Start)
+	assert.True(t, view.Days[0].Weekend)
+	assert.True(t, view.Days[0].Workday)
+	assert.False(t, view.Days[0].Holiday)
+	assert.Equal(t, "working saturday", view.Days[0].HolidayName)
+
+	view, err = s.GetWeekView(ctx, &model.WeekViewQuery{UserID: userID, Date: time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.True(t, view.Days[0].Workday)
+	assert.False(t, view.Days[0].Holiday)
+}
+
+func TestSettings_WorkingHours(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	userID := 5
+
+	hours := func(weekday time.Weekday, start, end int) model.WorkingHours {
+		return model.WorkingHours{Weekday: weekday, Start: time.Duration(start) * time.Hour, End: time.Duration(end) * time.Hour}
+	}
+	update := func(settings model.UserSettings) error {
+		settings.UserID = userID
+		settings.WeekStart = time.Monday
+		_, err := s.UpdateSettings(ctx, &settings)
+		return err
+	}
+
+	assert.ErrorIs(t, update(model.UserSettings{TimeZone: "Mars/Olympus"}), model.ErrInvalidTimezone)
+	assert.ErrorIs(t, update(model.UserSettings{WorkingHours: []model.WorkingHours{hours(time.Monday, 18, 9)}}), model.ErrInvalidWorkingHours)
+	assert.ErrorIs(t, update(model.UserSettings{WorkingHours: []model.WorkingHours{hours(time.Monday, 9, 13), hours(time.Monday, 12, 18)}}),
+		model.ErrOverlappingHours)
+	assert.ErrorIs(t, update(model.UserSettings{OutOfOffice: []model.OutOfOffice{{
+		Start: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	}}}), model.ErrInvalidOutOfOffice)
+
+	working := []model.WorkingHours{hours(time.Monday, 9, 13), hours(time.Monday, 14, 18)}
+	require.NoError(t, update(model.UserSettings{TimeZone: "Europe/Moscow", WorkingHours: working}))
+
+	// The stored settings do not share the slices of the request or of earlier reads.
+	working[0].Start = 0
+	settings, err := s.GetSettings(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 9*time.Hour, settings.WorkingHours[0].Start)
+	settings.WorkingHours[0].Start = 0
+	settings, err = s.GetSettings(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 9*time.Hour, settings.WorkingHours[0].Start)
+}
+
+func TestGetWorkingHoursOverlap(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	weekdays := func(start, end int) []model.WorkingHours {
+		var result []model.WorkingHours
+		for day := time.Monday; day <= time.Friday; day++ {
+			result = append(result, model.WorkingHours{Weekday: day, Start: time.Duration(start) * time.Hour, End: time.Duration(end) * time.Hour})
+		}
+		return result
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	_, err := s.UpdateSettings(ctx, &model.UserSettings{UserID: 1, TimeZone: "Europe/Moscow", WorkingHours: weekdays(9, 18)})
+	require.NoError(t, err)
+	_, err = s.UpdateSettings(ctx, &model.UserSettings{
+		UserID:       2,
+		WeekStart:    time.Monday,
+		TimeZone:     "Europe/London",
+		WorkingHours: weekdays(9, 17),
+		OutOfOffice:  []model.OutOfOffice{{Start: at(19, 12), End: at(19, 13), Note: "dentist"}},
+	})
+	require.NoError(t, err)
+
+	// Monday, October 19th: 06:00-15:00 UTC in Moscow and 08:00-16:00 UTC in London, which is on summer time.
+	overlap, err := s.GetWorkingHoursOverlap(ctx, []int{1, 2, 3}, at(19, 0), at(20, 0))
+	require.NoError(t, err)
+	require.Len(t, overlap.Users, 3)
+	assert.Equal(t, "Europe/London", overlap.Users[1].TimeZone)
+	assert.Equal(t, []model.Interval{{Start: at(19, 8), End: at(19, 12)}, {Start: at(19, 13), End: at(19, 16)}}, overlap.Users[1].Working)
+	assert.Equal(t, []model.Interval{{Start: at(19, 0), End: at(20, 0)}}, overlap.Users[2].Working)
+	assert.Equal(t, []model.Interval{{Start: at(19, 8), End: at(19, 12)}, {Start: at(19, 13), End: at(19, 15)}}, overlap.Overlap)
+
+	// London is back on GMT from October 25th, and nobody works on the weekend.
+	overlap, err = s.GetWorkingHoursOverlap(ctx, []int{1, 2}, at(24, 0), at(27, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []model.Interval{{Start: at(26, 9), End: at(26, 15)}}, overlap.Overlap)
+
+	_, err = s.GetWorkingHoursOverlap(ctx, []int{1}, at(19, 0), at(19, 0).AddDate(0, 0, model.MaxRangePeriods))
+	require.NoError(t, err)
+	_, err = s.GetWorkingHoursOverlap(ctx, []int{1}, at(19, 0), at(19, 0).AddDate(30, 0, 0))
+	assert.ErrorIs(t, err, model.ErrRangeTooLarge)
+}
+
+func TestCheckEvent(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	userID := 4
+
+	_, err := s.UpdateSettings(ctx, &model.UserSettings{
+		UserID:    userID,
+		WeekStart: time.Monday,
+		TimeZone:  "Asia/Tokyo",
+		OutOfOffice: []model.OutOfOffice{{
+			Start: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+			Note:  "conference",
+		}},
+	})
+	require.NoError(t, err)
+
+	check := func(event *model.Event) []*model.Warning {
+		event.UserID = userID
+		warnings, err := s.CheckEvent(ctx, event)
+		require.NoError(t, err)
+		return warnings
+	}
+
+	warnings := check(&model.Event{Date: time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)})
+	require.Len(t, warnings, 1)
+	assert.Equal(t, model.WarningOutOfOffice, warnings[0].Code)
+	assert.Contains(t, warnings[0].Message, "conference")
+
+	assert.Empty(t, check(&model.Event{Date: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)}))
+	// The all-day event of the 21st starts at 15:00 UTC on the 20th in Tokyo.
+	assert.Len(t, check(&model.Event{Date: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), AllDay: true}), 1)
+	assert.Empty(t, check(&model.Event{Date: time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC), AllDay: true}))
+
+	warnings, err = s.CheckEvent(ctx, &model.Event{UserID: 9, Date: time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestTemplates(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	start := time.Date(2026, 10, 23, 15, 0, 0, 0, time.UTC)
+
+	review, err := s.CreateTemplate(ctx, &model.EventTemplate{
+		UserID: 1, Name: "Sprint review", Title: "Sprint review", Duration: time.Hour,
+		Tags: []string{"team"}, Location: "Room 4",
+	})
+	require.NoError(t, err)
+	offsite, err := s.CreateTemplate(ctx, &model.EventTemplate{UserID: 1, Name: "Offsite", AllDay: true, Duration: 48 * time.Hour})
+	require.NoError(t, err)
+
+	_, err = s.CreateTemplate(ctx, &model.EventTemplate{UserID: 1, Duration: -time.Hour, Status: "maybe", Tags: []string{"Bad Tag"}})
+	assert.ErrorIs(t, err, model.ErrEmptyName)
+	assert.ErrorIs(t, err, model.ErrInvalidDuration)
+	assert.ErrorIs(t, err, model.ErrInvalidEventStatus)
+	assert.ErrorIs(t, err, model.ErrInvalidTag)
+	_, err = s.CreateTemplate(ctx, &model.EventTemplate{UserID: 1, Name: "Half day", AllDay: true, Duration: 12 * time.Hour})
+	assert.ErrorIs(t, err, model.ErrInvalidDuration)
+
+	templates, err := s.GetTemplates(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.EventTemplate{review, offsite}, templates)
+	_, err = s.GetTemplate(ctx, review.ID, 2)
+	assert.ErrorIs(t, err, model.ErrTemplateNotFound)
+
+	event, err := s.CreateEventFromTemplate(ctx, review.ID, 1, start, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Sprint review", event.Title)
+	assert.Equal(t, start.Add(time.Hour), event.End)
+	assert.Equal(t, []string{"team"}, event.Tags)
+	assert.Equal(t, model.StatusConfirmed, event.Status)
+	assert.Equal(t, 1, event.Version)
+
+	event, err = s.CreateEventFromTemplate(ctx, review.ID, 1, start, func(event *model.Event) error {
+		event.Location = "Zoom"
+		event.UserID = 2
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Zoom", event.Location)
+	assert.Equal(t, 1, event.UserID)
+	assert.Equal(t, "Room 4", review.Location)
+
+	_, err = s.CreateEventFromTemplate(ctx, review.ID, 1, start, func(event *model.Event) error {
+		event.Title = ""
+		return nil
+	})
+	assert.ErrorIs(t, err, model.ErrEmptyTitle)
+
+	_, err = s.CreateEventFromTemplate(ctx, offsite.ID, 1, start, nil)
+	assert.ErrorIs(t, err, model.ErrNotWholeDay)
+	event, err = s.CreateEventFromTemplate(ctx, offsite.ID, 1, start.Truncate(24*time.Hour), func(event *model.Event) error {
+		event.Title = "Offsite in Kazan"
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC), event.End)
+
+	_, err = s.UpdateTemplate(ctx, &model.EventTemplate{ID: review.ID, UserID: 1, Name: "Review", Duration: 45 * time.Minute})
+	require.NoError(t, err)
+	_, err = s.CreateEventFromTemplate(ctx, review.ID, 1, start, nil)
+	assert.ErrorIs(t, err, model.ErrEmptyTitle)
+	_, err = s.UpdateTemplate(ctx, &model.EventTemplate{ID: review.ID, UserID: 2, Name: "Review"})
+	assert.ErrorIs(t, err, model.ErrTemplateNotFound)
+
+	require.NoError(t, s.DeleteTemplate(ctx, review.ID, 1))
+	assert.ErrorIs(t, s.DeleteTemplate(ctx, review.ID, 1), model.ErrTemplateNotFound)
+	_, err = s.CreateEventFromTemplate(ctx, review.ID, 1, start, nil)
+	assert.ErrorIs(t, err, model.ErrTemplateNotFound)
+
+	events, err := s.GetEventsInRange(ctx, 1, start.Add(-24*time.Hour), start.Add(72*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+}
+
+func TestParseEvent(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	// Monday, 19 October 2026, 12:00 in Moscow.
+	_, err = s.UpdateSettings(ctx, &model.UserSettings{UserID: 1, WeekStart: time.Monday, TimeZone: "Europe/Moscow"})
+	require.NoError(t, err)
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, moscow).UTC()
+	}
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		text  string
+		title string
+		date  time.Time
+		end   time.Time
+		rrule string
+	}{
+		{"Lunch with Ivan tomorrow 13:00 for 1h", "Lunch with Ivan", at(20, 13, 0), at(20, 14, 0), ""},
+		{"созвон в пятницу в 10", "созвон", at(23, 10, 0), time.Time{}, ""},
+		{"Gym at 7", "Gym", at(20, 7, 0), time.Time{}, ""},
+		{"Call Bob in 2 hours for 30 min", "Call Bob", at(19, 14, 0), at(19, 14, 30), ""},
+		{"Обед через полчаса", "Обед", at(19, 12, 30), time.Time{}, ""},
+		{"Dentist on 23 October at 4pm", "Dentist", at(23, 16, 0), time.Time{}, ""},
+		{"Review 10:00-11:30 on 2026-10-30", "Review", at(30, 10, 0), at(30, 11, 30), ""},
+		{"Встреча завтра с 15 до 16:30", "Встреча", at(20, 15, 0), at(20, 16, 30), ""},
+		{"1-3pm workshop friday", "workshop", at(23, 13, 0), at(23, 15, 0), ""},
+		{"Ужин послезавтра в 7 вечера на 2 часа", "Ужин", at(21, 19, 0), at(21, 21, 0), ""},
+		{"Conference 23 октября весь день", "Conference", date(10, 23), date(10, 24), ""},
+		{"Ремонт на 24.10", "Ремонт", date(10, 24), date(10, 25), ""},
+		{"Отпуск 2.11 на 2 недели", "Отпуск", date(11, 2), date(11, 16), ""},
+		{"Retro in 3 days", "Retro", date(10, 22), date(10, 23), ""},
+		{"Standup every weekday at 9:30 for 15m", "Standup", at(20, 9, 30), at(20, 9, 45), "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{"Планёрка каждый понедельник в 10 утра", "Планёрка", at(26, 10, 0), time.Time{}, "FREQ=WEEKLY;BYDAY=MO"},
+		{"Sync every 2 weeks on friday at 11", "Sync", at(23, 11, 0), time.Time{}, "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR"},
+		{"Review every other week on monday and thursday at 15", "Review", at(19, 15, 0), time.Time{}, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"},
+		{"Отчёт каждые 3 недели по пятницам в 18", "Отчёт", at(23, 18, 0), time.Time{}, "FREQ=WEEKLY;INTERVAL=3;BYDAY=FR"},
+		{"Бассейн по вторникам и четвергам в 19:00", "Бассейн", at(20, 19, 0), time.Time{}, "FREQ=WEEKLY;BYDAY=TU,TH"},
+		{"Pay rent monthly", "Pay rent", date(10, 19), date(10, 20), "FREQ=MONTHLY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			draft, err := s.ParseEvent(ctx, 1, tt.text, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 1, draft.Event.UserID)
+			assert.Equal(t, tt.title, draft.Event.Title)
+			assert.Equal(t, tt.date, draft.Event.Date)
+			if draft.Event.AllDay {
+				draft.Event.SetDefaults()
+			}
+			assert.Equal(t, tt.end, draft.Event.End)
+			assert.Equal(t, moscow, draft.Location)
+			if len(tt.rrule) == 0 {
+				assert.Nil(t, draft.Recurrence)
+			} else if assert.NotNil(t, draft.Recurrence) {
+				assert.Equal(t, tt.rrule, draft.Recurrence.RRule())
+			}
+		})
+	}
+
+	draft, err := s.ParseEvent(ctx, 1, "Gym at 7", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 20, 7, 0, 0, 0, time.UTC), draft.Event.Date)
+
+	_, err = s.ParseEvent(ctx, 1, "  ", nil)
+	assert.ErrorIs(t, err, model.ErrEmptyText)
+	_, err = s.ParseEvent(ctx, 1, "Read a book", nil)
+	assert.ErrorIs(t, err, model.ErrDateNotFound)
+	_, err = s.ParseEvent(ctx, 1, "Focus time for 2 hours", nil)
+	assert.ErrorIs(t, err, model.ErrTimeNotFound)
+	_, err = s.ParseEvent(ctx, 1, "tomorrow at 10", nil)
+	assert.ErrorIs(t, err, model.ErrEmptyTitle)
+}
+
+func TestParseEvent_OutOfRange(t *testing.T) {
+	s := New(clock.NewFake(testNow))
+	ctx := context.Background()
+
+	tests := []struct {
+		text string
+		err  error
+	}{
+		{"meeting in 999999999 days", model.ErrAmountTooLarge},
+		{"meeting in 99999999999999999999 minutes", model.ErrAmountTooLarge},
+		{"meeting через 1000000 часов", model.ErrAmountTooLarge},
+		{"meeting in 101 years", model.ErrAmountTooLarge},
+		{"meeting tomorrow at 10 for 99999999999 hours", model.ErrAmountTooLarge},
+		{"meeting tomorrow at 10 for 99999999999h", model.ErrAmountTooLarge},
+		{"meeting tomorrow at 10 99999999999min", model.ErrAmountTooLarge},
+		{"meeting every 99999999 years", model.ErrAmountTooLarge},
+		{"meeting on 31.12.9999 at 23:30 for 2h", model.ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			_, err := s.ParseEvent(ctx, 1, tt.text, time.UTC)
+			require.ErrorIs(t, err, tt.err)
+
+			var verr *model.ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, "text", verr.Fields[0].Field)
+		})
+	}
+
+	draft, err := s.ParseEvent(ctx, 1, "Sabbatical in 100 years", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2126, 10, 19, 0, 0, 0, 0, time.UTC), draft.Event.Date)
+}
@@ -0,0 +1,45 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// GetSettings returns the user's settings, or the defaults if the user has not saved any.
+func (s *serv) GetSettings(ctx context.Context, userID int) (*model.UserSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userSettings(userID), nil
+}
+
+// UpdateSettings replaces the user's settings.
+func (s *serv) UpdateSettings(ctx context.Context, settings *model.UserSettings) (*model.UserSettings, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[settings.UserID] = settings.Clone()
+
+	return settings, nil
+}
+
+// userSettings returns a copy of the user's settings or the defaults. The caller must hold the lock.
+func (s *serv) userSettings(userID int) *model.UserSettings {
+	settings, ok := s.settings[userID]
+	if !ok {
+		return model.DefaultUserSettings(userID)
+	}
+
+	return settings.Clone()
+}
+
+// weekStart returns the first day of the user's weeks. The caller must hold the lock.
+func (s *serv) weekStart(userID int) time.Weekday {
+	return s.userSettings(userID).WeekStart
+}
@@ -0,0 +1,221 @@
+package calendar
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// CreateTag registers a new tag for the user.
+func (s *serv) CreateTag(ctx context.Context, tag *model.Tag) (*model.Tag, error) {
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tags[tag.UserID][tag.Name]; ok {
+		return nil, model.ErrTagExists
+	}
+	s.storeTag(tag)
+
+	return tag, nil
+}
+
+// GetTags returns the user's tags ordered by name.
+func (s *serv) GetTags(ctx context.Context, userID int) ([]*model.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*model.Tag, 0, len(s.tags[userID]))
+	for _, tag := range s.tags[userID] {
+		result = append(result, tag)
+	}
+	slices.SortFunc(result, func(a, b *model.Tag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+// UpdateTag replaces the tag called name. Renaming a tag renames it on every event carrying it.
+func (s *serv) UpdateTag(ctx context.Context, name string, tag *model.Tag) (*model.Tag, error) {
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tags[tag.UserID][name]; !ok {
+		return nil, model.ErrTagNotFound
+	}
+
+	if tag.Name != name {
+		if _, ok := s.tags[tag.UserID][tag.Name]; ok {
+			return nil, model.ErrTagExists
+		}
+		delete(s.tags[tag.UserID], name)
+	}
+	s.storeTag(tag)
+
+	if tag.Name != name {
+		s.retagEvents(tag.UserID, name, func(tags []string) []string {
+			return model.NormalizeTags(append(tags, tag.Name))
+		})
+	}
+
+	return tag, nil
+}
+
+// DeleteTag removes the tag called name from the user's calendar and from every event carrying it.
+func (s *serv) DeleteTag(ctx context.Context, userID int, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tags[userID][name]; !ok {
+		return model.ErrTagNotFound
+	}
+	delete(s.tags[userID], name)
+
+	s.retagEvents(userID, name, func(tags []string) []string {
+		return tags
+	})
+
+	return nil
+}
+
+// GetTagStats counts the user's events per period, tag and category.
+// Every period of the range is present in the result, including empty ones. An event counts in
+// every period its span covers, with all-day events placed on their own dates in the query's zone.
+func (s *serv) GetTagStats(ctx context.Context, query *model.TagStatsQuery) ([]*model.TagStats, error) {
+	if !query.Period.Valid() {
+		return nil, model.NewValidationError("period", model.ErrInvalidPeriod)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	loc := query.From.Location()
+	weekStart := s.weekStart(query.UserID)
+	var stats []*model.TagStats
+	index := make(map[int64]int)
+	for start := periodStart(query.From, query.Period, weekStart); start.Before(query.To); start = nextPeriod(start, query.Period) {
+		if len(stats) == model.MaxRangePeriods {
+			return nil, model.NewValidationError("to", model.ErrRangeTooLarge)
+		}
+		index[start.Unix()] = len(stats)
+		stats = append(stats, &model.TagStats{
+			Start:      start,
+			Tags:       make(map[string]int),
+			Categories: make(map[string]int),
+		})
+	}
+
+	for _, eventID := range s.userEvents[query.UserID] {
+		event := s.events[eventID]
+		if !event.Overlaps(query.From, query.To) || !query.Filter.Match(event) {
+			continue
+		}
+
+		start, end := event.Span(loc)
+		start = start.In(loc)
+		if start.Before(query.From) {
+			start = query.From
+		}
+		first := index[periodStart(start, query.Period, weekStart).Unix()]
+		for i := first; i < len(stats) && (i == first || stats[i].Start.Before(end)); i++ {
+			s.countEvent(stats[i], event)
+		}
+	}
+
+	return stats, nil
+}
+
+// countEvent adds event to the counts of bucket. The caller must hold the read lock.
+func (s *serv) countEvent(bucket *model.TagStats, event *model.Event) {
+	bucket.Total++
+	if len(event.Tags) == 0 {
+		bucket.Untagged++
+	}
+
+	categories := make(map[string]struct{})
+	for _, name := range event.Tags {
+		bucket.Tags[name]++
+		if tag, ok := s.tags[event.UserID][name]; ok && len(tag.Category) > 0 {
+			categories[tag.Category] = struct{}{}
+		}
+	}
+	for category := range categories {
+		bucket.Categories[category]++
+	}
+}
+
+// storeTag saves a tag. The caller must hold the write lock.
+func (s *serv) storeTag(tag *model.Tag) {
+	if s.tags[tag.UserID] == nil {
+		s.tags[tag.UserID] = make(map[string]*model.Tag)
+	}
+	s.tags[tag.UserID][tag.Name] = tag
+}
+
+// ensureTags creates the tags of event the user does not have yet. The caller must hold the write lock.
+func (s *serv) ensureTags(event *model.Event) {
+	for _, name := range event.Tags {
+		if _, ok := s.tags[event.UserID][name]; !ok {
+			s.storeTag(&model.Tag{UserID: event.UserID, Name: name})
+		}
+	}
+}
+
+// retagEvents replaces the tags of every event of the user carrying name. replace receives
+// the tags without name. Each changed event gets a new version. The caller must hold the write lock.
+func (s *serv) retagEvents(userID int, name string, replace func(tags []string) []string) {
+	for _, eventID := range s.userEvents[userID] {
+		event := s.events[eventID]
+		if !slices.Contains(event.Tags, name) {
+			continue
+		}
+
+		patched, err := patchedCopy(event, func(patched *model.Event) error {
+			patched.Tags = replace(slices.DeleteFunc(slices.Clone(event.Tags), func(tag string) bool {
+				return tag == name
+			}))
+			return nil
+		})
+		if err != nil {
+			continue
+		}
+		s.replaceEvent(patched)
+	}
+}
+
+// periodStart returns the start of the period containing t in t's location. Weeks start on weekStart.
+func periodStart(t time.Time, period model.Period, weekStart time.Weekday) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case model.PeriodWeek:
+		start, _ := model.WeekRange(day, weekStart)
+		return start
+	case model.PeriodMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// nextPeriod returns the start of the period following the one starting at start.
+func nextPeriod(start time.Time, period model.Period) time.Time {
+	switch period {
+	case model.PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case model.PeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,128 @@
+package calendar
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// CreateTemplate saves a new event template of the user and assigns it a unique ID.
+func (s *serv) CreateTemplate(ctx context.Context, template *model.EventTemplate) (*model.EventTemplate, error) {
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.templates[template.UserID]) >= model.MaxTemplates {
+		return nil, model.ErrTooManyTemplates
+	}
+
+	template.ID = s.nextTemplateID
+	s.nextTemplateID++
+	s.storeTemplate(template)
+
+	return template, nil
+}
+
+// GetTemplate returns a single event template owned by the user.
+func (s *serv) GetTemplate(ctx context.Context, templateID, userID int) (*model.EventTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.template(templateID, userID)
+}
+
+// GetTemplates returns the user's event templates ordered by ID.
+func (s *serv) GetTemplates(ctx context.Context, userID int) ([]*model.EventTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*model.EventTemplate, 0, len(s.templates[userID]))
+	for _, template := range s.templates[userID] {
+		result = append(result, template)
+	}
+	slices.SortFunc(result, func(a, b *model.EventTemplate) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return result, nil
+}
+
+// UpdateTemplate replaces an existing event template. Events already made from it are left as they are.
+func (s *serv) UpdateTemplate(ctx context.Context, template *model.EventTemplate) (*model.EventTemplate, error) {
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.template(template.ID, template.UserID); err != nil {
+		return nil, err
+	}
+	s.storeTemplate(template)
+
+	return template, nil
+}
+
+// DeleteTemplate removes an event template. Events already made from it are left as they are.
+func (s *serv) DeleteTemplate(ctx context.Context, templateID, userID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.template(templateID, userID); err != nil {
+		return err
+	}
+	delete(s.templates[userID], templateID)
+
+	return nil
+}
+
+// CreateEventFromTemplate instantiates the user's template at start, applies override and creates
+// the resulting event if it passes Event.Validate.
+func (s *serv) CreateEventFromTemplate(ctx context.Context, templateID, userID int, start time.Time, override func(event *model.Event) error) (*model.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	template, err := s.template(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := template.Instantiate(start)
+	if override != nil {
+		if err = override(event); err != nil {
+			return nil, err
+		}
+		event.UserID = userID
+	}
+
+	if err = event.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.createEvent(event), nil
+}
+
+// template returns the user's template with the given ID. The caller must hold the lock.
+func (s *serv) template(templateID, userID int) (*model.EventTemplate, error) {
+	template, ok := s.templates[userID][templateID]
+	if !ok {
+		return nil, model.ErrTemplateNotFound
+	}
+
+	return template, nil
+}
+
+// storeTemplate saves a template. The caller must hold the write lock.
+func (s *serv) storeTemplate(template *model.EventTemplate) {
+	if s.templates[template.UserID] == nil {
+		s.templates[template.UserID] = make(map[int]*model.EventTemplate)
+	}
+	s.templates[template.UserID][template.ID] = template
+}
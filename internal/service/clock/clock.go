@@ -0,0 +1,158 @@
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+)
+
+var (
+	_ service.Clock = system{}
+	_ service.Clock = (*Fake)(nil)
+)
+
+// system is the clock of the machine.
+type system struct{}
+
+// New returns the clock of the machine, backed by the time package.
+func New() service.Clock {
+	return system{}
+}
+
+func (system) Now() time.Time {
+	return time.Now()
+}
+
+func (system) NewTimer(d time.Duration) service.Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// Fake is a clock that stands still until it is moved. Its timers fire when Advance or Set moves
+// the time past them.
+type Fake struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake creates a fake clock showing now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the time the clock shows.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Advance moves the clock d ahead and fires the timers that are due.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+	f.fireDue()
+}
+
+// Set moves the clock to now, which must not be before the time it shows, and fires the timers that are due.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = now
+	f.fireDue()
+}
+
+// NewTimer creates a timer that fires once the clock has been moved d ahead.
+func (f *Fake) NewTimer(d time.Duration) service.Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := &fakeTimer{clock: f, c: make(chan time.Time, 1)}
+	f.schedule(t, d)
+
+	return t
+}
+
+// schedule arms t to fire d after now. The caller must hold the lock.
+func (f *Fake) schedule(t *fakeTimer, d time.Duration) {
+	t.when = f.now.Add(d)
+	t.active = true
+	if !slices.Contains(f.timers, t) {
+		f.timers = append(f.timers, t)
+	}
+	f.fireDue()
+}
+
+// fireDue fires the active timers whose time has come and forgets them. The caller must hold the lock.
+func (f *Fake) fireDue() {
+	pending := f.timers[:0]
+	for _, t := range f.timers {
+		switch {
+		case !t.active:
+		case t.when.After(f.now):
+			pending = append(pending, t)
+		default:
+			t.active = false
+			select {
+			case t.c <- f.now:
+			default:
+			}
+		}
+	}
+	f.timers = pending
+}
+
+type fakeTimer struct {
+	clock  *Fake
+	c      chan time.Time
+	when   time.Time
+	active bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.active
+	t.active = false
+	t.drain()
+
+	return active
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.active
+	t.drain()
+	t.clock.schedule(t, d)
+
+	return active
+}
+
+// drain drops a time sent before the timer was stopped or reset, as time.Timer does since Go 1.23.
+func (t *fakeTimer) drain() {
+	select {
+	case <-t.c:
+	default:
+	}
+}
@@ -0,0 +1,53 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fired(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	clk := NewFake(start)
+	assert.Equal(t, start, clk.Now())
+
+	timer := clk.NewTimer(time.Minute)
+	clk.Advance(59 * time.Second)
+	_, ok := fired(timer.C())
+	assert.False(t, ok)
+
+	clk.Advance(time.Second)
+	at, ok := fired(timer.C())
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(time.Minute), at)
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(time.Hour))
+	assert.True(t, timer.Reset(2*time.Hour))
+	clk.Set(start.Add(2 * time.Hour))
+	_, ok = fired(timer.C())
+	assert.False(t, ok)
+	clk.Advance(time.Minute)
+	_, ok = fired(timer.C())
+	assert.True(t, ok)
+
+	timer.Reset(time.Second)
+	assert.True(t, timer.Stop())
+	clk.Advance(time.Hour)
+	_, ok = fired(timer.C())
+	assert.False(t, ok)
+
+	now := clk.NewTimer(0)
+	_, ok = fired(now.C())
+	assert.True(t, ok)
+}
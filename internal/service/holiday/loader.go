@@ -0,0 +1,198 @@
+package holiday
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+// fileNamePattern matches the calendar files, one per country and year: ru-2026.json or ru-2026.ics.
+var fileNamePattern = regexp.MustCompile(`^([a-z]{2})-(\d{4})\.(json|ics)$`)
+
+// workdayCategory marks the VEVENTs of an ICS file that are working days rather than days off.
+const workdayCategory = "WORKDAY"
+
+// jsonFile is the layout of a JSON calendar file. Kind defaults to a day off.
+type jsonFile struct {
+	Days []struct {
+		Date string            `json:"date"`
+		Name string            `json:"name"`
+		Kind model.HolidayKind `json:"kind"`
+	} `json:"days"`
+}
+
+// loadDir loads every calendar file of dir, keyed by country. Other files are ignored.
+func loadDir(dir string) (map[string]*model.HolidayCalendar, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read holiday dir: %w", err)
+	}
+
+	calendars := make(map[string]*model.HolidayCalendar)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		country, format := match[1], match[3]
+		year, _ := strconv.Atoi(match[2])
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read holiday file: %w", err)
+		}
+
+		var days []*model.Holiday
+		if format == "json" {
+			days, err = parseJSON(data)
+		} else {
+			days, err = parseICS(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", entry.Name(), err)
+		}
+
+		for _, day := range days {
+			if day.Date.Year() != year {
+				return nil, fmt.Errorf("parse %s: %s is not in %d", entry.Name(), day.Date.Format(time.DateOnly), year)
+			}
+		}
+
+		cal, ok := calendars[country]
+		if !ok {
+			cal = model.NewHolidayCalendar(country)
+			calendars[country] = cal
+		}
+		cal.AddYear(year, days)
+	}
+
+	return calendars, nil
+}
+
+func parseJSON(data []byte) ([]*model.Holiday, error) {
+	var file jsonFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	days := make([]*model.Holiday, 0, len(file.Days))
+	for _, d := range file.Days {
+		date, err := time.Parse(time.DateOnly, d.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", d.Date)
+		}
+
+		kind := d.Kind
+		if len(kind) == 0 {
+			kind = model.HolidayDayOff
+		}
+		if !kind.Valid() {
+			return nil, fmt.Errorf("invalid kind %q", kind)
+		}
+
+		days = append(days, &model.Holiday{Date: date, Name: d.Name, Kind: kind})
+	}
+
+	return days, nil
+}
+
+// parseICS reads the all-day VEVENTs of an iCalendar file. An event spanning several days up to its
+// exclusive DTEND yields a day each; events with the WORKDAY category are working days.
+func parseICS(data []byte) ([]*model.Holiday, error) {
+	var days []*model.Holiday
+	var event map[string]string
+	for _, line := range unfoldICS(data) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(name, ";")
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = make(map[string]string)
+		case name == "END" && value == "VEVENT":
+			parsed, err := icsEventDays(event)
+			if err != nil {
+				return nil, err
+			}
+			days = append(days, parsed...)
+			event = nil
+		case event != nil:
+			event[name] = value
+		}
+	}
+
+	return days, nil
+}
+
+func icsEventDays(event map[string]string) ([]*model.Holiday, error) {
+	start, err := parseICSDate(event["DTSTART"])
+	if err != nil {
+		return nil, err
+	}
+
+	end := start.AddDate(0, 0, 1)
+	if value, ok := event["DTEND"]; ok {
+		if end, err = parseICSDate(value); err != nil {
+			return nil, err
+		}
+	}
+
+	kind := model.HolidayDayOff
+	for _, category := range strings.Split(event["CATEGORIES"], ",") {
+		if strings.EqualFold(strings.TrimSpace(category), workdayCategory) {
+			kind = model.HolidayWorkday
+		}
+	}
+
+	name := unescapeICS(event["SUMMARY"])
+	var days []*model.Holiday
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		days = append(days, &model.Holiday{Date: day, Name: name, Kind: kind})
+	}
+
+	return days, nil
+}
+
+// parseICSDate parses a DATE value such as 20260101 as midnight UTC.
+func parseICSDate(value string) (time.Time, error) {
+	date, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	return date, nil
+}
+
+// unfoldICS splits iCalendar content into logical lines, joining the continuation lines
+// that start with a space or a tab.
+func unfoldICS(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+var icsEscapes = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeICS(value string) string {
+	return icsEscapes.Replace(value)
+}
@@ -0,0 +1,55 @@
+package holiday
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+)
+
+var _ service.HolidayService = (*serv)(nil)
+
+// serv is read-only once loaded, so it needs no locking.
+type serv struct {
+	calendars map[string]*model.HolidayCalendar
+}
+
+// New loads the holiday calendars from the files in the configured directory.
+// A file that cannot be parsed fails the whole load.
+func New(cfg *config.HolidayConfig) (*serv, error) {
+	calendars := make(map[string]*model.HolidayCalendar)
+	if len(cfg.Dir) > 0 {
+		var err error
+		if calendars, err = loadDir(cfg.Dir); err != nil {
+			return nil, err
+		}
+	}
+
+	return &serv{calendars: calendars}, nil
+}
+
+// GetCalendars returns every loaded calendar ordered by country.
+func (s *serv) GetCalendars(ctx context.Context) ([]*model.HolidayCalendar, error) {
+	result := make([]*model.HolidayCalendar, 0, len(s.calendars))
+	for _, cal := range s.calendars {
+		result = append(result, cal)
+	}
+	slices.SortFunc(result, func(a, b *model.HolidayCalendar) int {
+		return strings.Compare(a.Country, b.Country)
+	})
+
+	return result, nil
+}
+
+// GetCalendar returns the calendar of a country.
+func (s *serv) GetCalendar(ctx context.Context, country string) (*model.HolidayCalendar, error) {
+	cal, ok := s.calendars[country]
+	if !ok {
+		return nil, model.ErrHolidayCalendarNotFound
+	}
+
+	return cal, nil
+}
@@ -0,0 +1,109 @@
+package holiday
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLoad(t *testing.T) {
+	s, err := New(&config.HolidayConfig{Dir: "testdata"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	calendars, err := s.GetCalendars(ctx)
+	require.NoError(t, err)
+	require.Len(t, calendars, 1)
+	assert.Equal(t, "ru", calendars[0].Country)
+	assert.Equal(t, []int{2025, 2026}, calendars[0].Years)
+
+	_, err = s.GetCalendar(ctx, "de")
+	assert.ErrorIs(t, err, model.ErrHolidayCalendarNotFound)
+
+	cal, err := s.GetCalendar(ctx, "ru")
+	require.NoError(t, err)
+
+	// From the JSON file.
+	day, ok := cal.Holiday(date(2026, 1, 7))
+	require.True(t, ok)
+	assert.Equal(t, &model.Holiday{Date: date(2026, 1, 7), Name: "Рождество Христово", Kind: model.HolidayDayOff}, day)
+
+	// From the ICS file, with folded lines, escapes, multi-day events and a working Saturday.
+	assert.Len(t, cal.Holidays(date(2025, 5, 1), date(2025, 6, 1)), 4)
+	day, ok = cal.Holiday(date(2025, 11, 3))
+	require.True(t, ok)
+	assert.Equal(t, "День народного единства", day.Name)
+	day, ok = cal.Holiday(date(2025, 11, 1))
+	require.True(t, ok)
+	assert.Equal(t, "Рабочая суббота, перенос с 3 ноября", day.Name)
+	assert.True(t, cal.IsWorkday(date(2025, 11, 1)))
+	assert.False(t, cal.IsWorkday(date(2025, 11, 3)))
+
+	// Entries are looked up by the day in the location of the date.
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	_, ok = cal.Holiday(time.Date(2026, 2, 23, 1, 0, 0, 0, moscow))
+	assert.True(t, ok)
+}
+
+func TestAddWorkdays(t *testing.T) {
+	s, err := New(&config.HolidayConfig{Dir: "testdata"})
+	require.NoError(t, err)
+	cal, err := s.GetCalendar(context.Background(), "ru")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		cal  *model.HolidayCalendar
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{"over the new year holidays", cal, date(2025, 12, 30), 2, date(2026, 1, 12)},
+		{"backwards", cal, date(2026, 1, 12), -2, date(2025, 12, 30)},
+		{"zero on a holiday", cal, date(2026, 5, 11), 0, date(2026, 5, 12)},
+		{"zero on a workday", cal, date(2026, 5, 12), 0, date(2026, 5, 12)},
+		{"working saturday", cal, date(2025, 10, 31), 1, date(2025, 11, 1)},
+		{"no calendar", nil, date(2026, 1, 1), 1, date(2026, 1, 2)},
+		{"unloaded year", cal, date(2027, 1, 1), 1, date(2027, 1, 4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cal.AddWorkdays(tt.from, tt.n))
+		})
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"ru-2026.json": `{"days":[{"date":"2025-12-31"}]}`,
+		"de-2026.json": `{"days":[{"date":"2026-01-01","kind":"party"}]}`,
+		"fr-2026.ics":  "BEGIN:VEVENT\nDTSTART:2026\nEND:VEVENT\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+
+			_, err := New(&config.HolidayConfig{Dir: dir})
+			assert.Error(t, err)
+		})
+	}
+
+	s, err := New(&config.HolidayConfig{})
+	require.NoError(t, err)
+	calendars, err := s.GetCalendars(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, calendars)
+}
@@ -0,0 +1,98 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+)
+
+var _ service.IdempotencyService = (*serv)(nil)
+
+// entry is a reserved key. resp is nil while the request is still running.
+type entry struct {
+	fingerprint string
+	resp        *model.StoredResponse
+}
+
+type serv struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*entry
+	// completed lists the keys with a stored response in expiry order; the TTL is the same for all of them.
+	completed []string
+	clock     service.Clock
+}
+
+// New creates an in-memory idempotency key store whose responses expire by clk.
+func New(cfg *config.IdempotencyConfig, clk service.Clock) *serv {
+	return &serv{
+		ttl:     cfg.TTL,
+		entries: make(map[string]*entry),
+		clock:   clk,
+	}
+}
+
+// Begin reserves key for a request or returns the response it already has.
+func (s *serv) Begin(ctx context.Context, key, fingerprint string) (*model.StoredResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+
+	e, ok := s.entries[key]
+	if !ok {
+		s.entries[key] = &entry{fingerprint: fingerprint}
+		return nil, nil
+	}
+
+	if e.fingerprint != fingerprint {
+		return nil, model.ErrIdempotencyKeyReused
+	}
+
+	if e.resp == nil {
+		return nil, model.ErrIdempotencyKeyInUse
+	}
+
+	return e.resp, nil
+}
+
+// Complete stores the response to a reserved key.
+func (s *serv) Complete(ctx context.Context, key string, resp *model.StoredResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok || e.resp != nil {
+		return
+	}
+
+	resp.ExpiresAt = s.clock.Now().Add(s.ttl)
+	e.resp = resp
+	s.completed = append(s.completed, key)
+}
+
+// Release frees a reserved key that has no stored response.
+func (s *serv) Release(ctx context.Context, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok && e.resp == nil {
+		delete(s.entries, key)
+	}
+}
+
+// prune forgets the stored responses whose TTL has expired. The caller must hold the lock.
+func (s *serv) prune() {
+	now := s.clock.Now()
+
+	i := 0
+	for i < len(s.completed) && !s.entries[s.completed[i]].resp.ExpiresAt.After(now) {
+		delete(s.entries, s.completed[i])
+		i++
+	}
+	s.completed = s.completed[i:]
+}
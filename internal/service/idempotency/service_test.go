@@ -0,0 +1,82 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeginComplete(t *testing.T) {
+	s := New(&config.IdempotencyConfig{TTL: time.Hour}, clock.New())
+	ctx := context.Background()
+
+	stored, err := s.Begin(ctx, "key", "request")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	_, err = s.Begin(ctx, "key", "request")
+	assert.ErrorIs(t, err, model.ErrIdempotencyKeyInUse)
+
+	s.Complete(ctx, "key", &model.StoredResponse{Status: http.StatusCreated, Body: []byte(`{"id":1}`)})
+
+	stored, err = s.Begin(ctx, "key", "request")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, http.StatusCreated, stored.Status)
+	assert.Equal(t, `{"id":1}`, string(stored.Body))
+
+	_, err = s.Begin(ctx, "key", "other request")
+	assert.ErrorIs(t, err, model.ErrIdempotencyKeyReused)
+}
+
+func TestRelease(t *testing.T) {
+	s := New(&config.IdempotencyConfig{TTL: time.Hour}, clock.New())
+	ctx := context.Background()
+
+	_, err := s.Begin(ctx, "key", "request")
+	require.NoError(t, err)
+	s.Release(ctx, "key")
+
+	stored, err := s.Begin(ctx, "key", "other request")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	s.Complete(ctx, "key", &model.StoredResponse{Status: http.StatusOK})
+	s.Release(ctx, "key")
+
+	stored, err = s.Begin(ctx, "key", "other request")
+	require.NoError(t, err)
+	assert.NotNil(t, stored)
+}
+
+func TestExpiry(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+	s := New(&config.IdempotencyConfig{TTL: time.Hour}, clk)
+	ctx := context.Background()
+
+	_, err := s.Begin(ctx, "old", "request")
+	require.NoError(t, err)
+	s.Complete(ctx, "old", &model.StoredResponse{Status: http.StatusOK})
+
+	clk.Advance(30 * time.Minute)
+	_, err = s.Begin(ctx, "new", "request")
+	require.NoError(t, err)
+	s.Complete(ctx, "new", &model.StoredResponse{Status: http.StatusOK})
+
+	clk.Advance(45 * time.Minute)
+	stored, err := s.Begin(ctx, "old", "other request")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+	assert.Len(t, s.completed, 1)
+
+	stored, err = s.Begin(ctx, "new", "request")
+	require.NoError(t, err)
+	assert.NotNil(t, stored)
+}
@@ -8,11 +8,133 @@ import (
 )
 
 // CalendarService defines the business logic interface for calendar event operations.
+// Events are identified by ULIDs; wherever an event ID is taken, a legacy integer ID is accepted as well.
 type CalendarService interface {
 	CreateEvent(ctx context.Context, event *model.Event) (*model.Event, error)
+	GetEvent(ctx context.Context, eventID string, userID int) (*model.Event, error)
+	// UpdateEvent replaces an event; a non-zero event.Version must match the stored one.
 	UpdateEvent(ctx context.Context, event *model.Event) (*model.Event, error)
-	DeleteEvent(ctx context.Context, eventID, userID int) error
+	// PatchEvent applies patch to a copy of the stored event and saves the result if it is valid.
+	// A non-zero version must match the stored one. IDs, owner and version cannot be patched.
+	PatchEvent(ctx context.Context, eventID string, userID, version int, patch func(event *model.Event) error) (*model.Event, error)
+	// DeleteEvent removes an event; a non-zero version must match the stored one.
+	DeleteEvent(ctx context.Context, eventID string, userID, version int) error
 	GetEventsForDay(ctx context.Context, userID int, date time.Time) ([]*model.Event, error)
+	// GetEventsForWeek returns the events of the calendar week containing date. Weeks start on the day
+	// chosen in the user's settings.
 	GetEventsForWeek(ctx context.Context, userID int, date time.Time) ([]*model.Event, error)
 	GetEventsForMonth(ctx context.Context, userID int, date time.Time) ([]*model.Event, error)
+	// GetEventsInRange returns the user's events overlapping the half-open interval [from, to), including
+	// multi-day events that started before from. An event without duration matches if it starts in the interval.
+	GetEventsInRange(ctx context.Context, userID int, from, to time.Time) ([]*model.Event, error)
+	// GetEventsInRangeForUsers does what GetEventsInRange does for several users in a single pass,
+	// keyed by user ID, with the same overlap semantics. Every requested user is present in the result.
+	GetEventsInRangeForUsers(ctx context.Context, userIDs []int, from, to time.Time) (map[int][]*model.Event, error)
+	Sync(ctx context.Context, userID int, since int64) (*model.SyncResult, error)
+	// CreateTag registers a tag; tags used on events are also created implicitly.
+	CreateTag(ctx context.Context, tag *model.Tag) (*model.Tag, error)
+	GetTags(ctx context.Context, userID int) ([]*model.Tag, error)
+	// UpdateTag replaces the tag called name with tag. A rename is applied to every event carrying
+	// the tag, and so is DeleteTag; each changed event gets a new version.
+	UpdateTag(ctx context.Context, name string, tag *model.Tag) (*model.Tag, error)
+	DeleteTag(ctx context.Context, userID int, name string) error
+	// GetTagStats counts the user's events per period, tag and category.
+	// An event counts in every period it overlaps, like the range queries.
+	GetTagStats(ctx context.Context, query *model.TagStatsQuery) ([]*model.TagStats, error)
+	// SearchEvents returns the user's events matching every word of the query, best matches first.
+	// Words match indexed terms exactly, by prefix or with a typo or two in longer words.
+	SearchEvents(ctx context.Context, query *model.SearchQuery) ([]*model.SearchHit, error)
+	// GetMonthGrid lays out the user's events of a month as the six-week grid calendar UIs show.
+	GetMonthGrid(ctx context.Context, query *model.MonthGridQuery) (*model.MonthGrid, error)
+	// GetWeekView lays out the user's events of a calendar week day by day.
+	GetWeekView(ctx context.Context, query *model.WeekViewQuery) (*model.WeekView, error)
+	// GetSettings returns the user's settings, or the defaults if the user has not saved any.
+	GetSettings(ctx context.Context, userID int) (*model.UserSettings, error)
+	UpdateSettings(ctx context.Context, settings *model.UserSettings) (*model.UserSettings, error)
+	// GetWorkingHoursOverlap returns the working time of each user in [from, to) and the part all of them share.
+	// The range may span at most model.MaxRangePeriods days.
+	GetWorkingHoursOverlap(ctx context.Context, userIDs []int, from, to time.Time) (*model.WorkingHoursOverlap, error)
+	// CheckEvent returns the warnings about an event that do not stop it from being stored,
+	// such as its owner being out of office at its time.
+	CheckEvent(ctx context.Context, event *model.Event) ([]*model.Warning, error)
+	CreateTemplate(ctx context.Context, template *model.EventTemplate) (*model.EventTemplate, error)
+	GetTemplate(ctx context.Context, templateID, userID int) (*model.EventTemplate, error)
+	GetTemplates(ctx context.Context, userID int) ([]*model.EventTemplate, error)
+	// UpdateTemplate replaces every field of the template with the ID and owner of template.
+	UpdateTemplate(ctx context.Context, template *model.EventTemplate) (*model.EventTemplate, error)
+	DeleteTemplate(ctx context.Context, templateID, userID int) error
+	// CreateEventFromTemplate creates an event from the user's template starting at start. A non-nil
+	// override changes the event made from the template before it is validated and stored.
+	CreateEventFromTemplate(ctx context.Context, templateID, userID int, start time.Time, override func(event *model.Event) error) (*model.Event, error)
+	// ParseEvent reads a draft event of the user from free-form Russian or English text without storing it.
+	// Relative dates are read in loc, or in the user's time zone when loc is nil.
+	ParseEvent(ctx context.Context, userID int, text string, loc *time.Location) (*model.EventDraft, error)
+	// ApplyBatch applies ops in order as one unit and returns a result per operation.
+	// Without continueOnError nothing is applied if any operation fails, and the others
+	// report model.ErrBatchAborted; with it the valid operations are applied regardless.
+	ApplyBatch(ctx context.Context, ops []model.BatchOp, continueOnError bool) ([]model.BatchResult, error)
+}
+
+// HolidayService serves the read-only production calendars loaded at startup, one per country.
+type HolidayService interface {
+	// GetCalendars returns every loaded calendar ordered by country.
+	GetCalendars(ctx context.Context) ([]*model.HolidayCalendar, error)
+	// GetCalendar returns the calendar of a country or model.ErrHolidayCalendarNotFound.
+	GetCalendar(ctx context.Context, country string) (*model.HolidayCalendar, error)
+}
+
+// EventNotifier receives event changes produced by CalendarService mutations.
+// Notify is called while the calendar is locked, so implementations must not block.
+type EventNotifier interface {
+	Notify(change model.EventChange)
+}
+
+// WebhookService defines the business logic interface for webhook subscriptions and deliveries.
+type WebhookService interface {
+	EventNotifier
+	CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID, userID int) error
+	GetSubscriptions(ctx context.Context, userID int) ([]*model.Subscription, error)
+	GetDeliveries(ctx context.Context, userID int, status model.DeliveryStatus) ([]*model.Delivery, error)
+	Run(ctx context.Context)
+}
+
+// StreamService defines the in-process pub/sub hub that pushes event changes to live subscribers.
+type StreamService interface {
+	EventNotifier
+	// Subscribe streams a user's changes published after lastID until ctx is done.
+	// The channel is closed when the subscription ends, including when the consumer falls behind.
+	Subscribe(ctx context.Context, userID int, lastID int64) <-chan model.ChangeMessage
+}
+
+// IdempotencyService remembers the responses to write requests by their idempotency key,
+// so that a retried request gets the original response instead of being applied again.
+// Keys are opaque to the service; callers scope them to the client or user they belong to.
+type IdempotencyService interface {
+	// Begin reserves key for the request identified by fingerprint. It returns the stored response
+	// if that request has already completed, model.ErrIdempotencyKeyInUse while it is still running
+	// and model.ErrIdempotencyKeyReused if the key belongs to a different request.
+	// A nil response and error mean the caller owns the key and must Complete or Release it.
+	Begin(ctx context.Context, key, fingerprint string) (*model.StoredResponse, error)
+	// Complete stores the response to a reserved key for replaying until the configured TTL expires.
+	Complete(ctx context.Context, key string, resp *model.StoredResponse)
+	// Release frees a reserved key without storing a response, so the request may be retried.
+	Release(ctx context.Context, key string)
+}
+
+// Clock tells the services and their background workers the time, so that tests can control it.
+type Clock interface {
+	Now() time.Time
+	// NewTimer creates a timer that fires once d has passed on the clock.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer of a Clock, like time.Timer.
+type Timer interface {
+	// C returns the channel the current time is sent on when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing and reports whether it was active.
+	Stop() bool
+	// Reset changes the timer to fire once d has passed and reports whether it was active.
+	Reset(d time.Duration) bool
 }
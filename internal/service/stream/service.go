@@ -0,0 +1,121 @@
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+)
+
+const (
+	// historySize is how many recent changes are kept for resuming subscribers.
+	historySize = 1024
+	// bufferSize is how many undelivered messages a subscriber may lag behind before it is dropped.
+	bufferSize = 64
+)
+
+var _ service.StreamService = (*serv)(nil)
+
+type subscriber struct {
+	userID int
+	ch     chan model.ChangeMessage
+}
+
+type serv struct {
+	mu          sync.Mutex
+	history     []model.ChangeMessage
+	nextID      int64
+	subscribers map[*subscriber]struct{}
+}
+
+// New creates an in-memory change stream hub.
+func New() *serv {
+	return &serv{
+		nextID:      1,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Notify numbers the change, stores it for resuming subscribers and fans it out to live ones.
+func (s *serv) Notify(change model.EventChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg := model.ChangeMessage{ID: s.nextID, Change: change}
+	s.nextID++
+
+	s.history = append(s.history, msg)
+	if len(s.history) > historySize {
+		s.history = s.history[len(s.history)-historySize:]
+	}
+
+	for sub := range s.subscribers {
+		if sub.userID != change.Event.UserID {
+			continue
+		}
+
+		select {
+		case sub.ch <- msg:
+		default:
+			s.drop(sub)
+		}
+	}
+}
+
+// Subscribe streams changes of the user published after lastID. A zero lastID starts from now.
+func (s *serv) Subscribe(ctx context.Context, userID int, lastID int64) <-chan model.ChangeMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replay := s.replay(userID, lastID)
+	sub := &subscriber{
+		userID: userID,
+		ch:     make(chan model.ChangeMessage, len(replay)+bufferSize),
+	}
+	for _, msg := range replay {
+		sub.ch <- msg
+	}
+	s.subscribers[sub] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.drop(sub)
+	}()
+
+	return sub.ch
+}
+
+// replay returns the messages a subscriber resuming after lastID has missed,
+// or a single reset message when they are no longer in the history.
+func (s *serv) replay(userID int, lastID int64) []model.ChangeMessage {
+	head := s.nextID - 1
+	if lastID <= 0 || lastID == head {
+		return nil
+	}
+
+	if lastID > head || len(s.history) == 0 || s.history[0].ID > lastID+1 {
+		return []model.ChangeMessage{{ID: head, Reset: true}}
+	}
+
+	var result []model.ChangeMessage
+	for _, msg := range s.history {
+		if msg.ID > lastID && msg.Change.Event.UserID == userID {
+			result = append(result, msg)
+		}
+	}
+
+	return result
+}
+
+func (s *serv) drop(sub *subscriber) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(s.subscribers, sub)
+	close(sub.ch)
+}
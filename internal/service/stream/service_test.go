@@ -0,0 +1,117 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func change(userID int, title string) model.EventChange {
+	return model.EventChange{
+		Type:       model.ChangeCreated,
+		Event:      model.Event{UserID: userID, Title: title, Date: time.Now()},
+		OccurredAt: time.Now(),
+	}
+}
+
+func receive(t *testing.T, ch <-chan model.ChangeMessage) model.ChangeMessage {
+	t.Helper()
+
+	select {
+	case msg, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return msg
+	case <-time.After(time.Second):
+		require.FailNow(t, "no message received")
+	}
+
+	return model.ChangeMessage{}
+}
+
+func TestSubscribeLive(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := s.Subscribe(ctx, 1, 0)
+	s.Notify(change(2, "other"))
+	s.Notify(change(1, "mine"))
+
+	msg := receive(t, ch)
+	assert.Equal(t, int64(2), msg.ID)
+	assert.Equal(t, "mine", msg.Change.Event.Title)
+	assert.Empty(t, ch)
+}
+
+func TestSubscribeResume(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Notify(change(1, "first"))
+	s.Notify(change(2, "other"))
+	s.Notify(change(1, "second"))
+	s.Notify(change(1, "third"))
+
+	ch := s.Subscribe(ctx, 1, 1)
+	assert.Equal(t, "second", receive(t, ch).Change.Event.Title)
+	assert.Equal(t, "third", receive(t, ch).Change.Event.Title)
+
+	s.Notify(change(1, "live"))
+	assert.Equal(t, int64(5), receive(t, ch).ID)
+}
+
+func TestSubscribeResumeExpired(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 0; i < historySize+10; i++ {
+		s.Notify(change(1, "event"))
+	}
+
+	msg := receive(t, s.Subscribe(ctx, 1, 3))
+	assert.True(t, msg.Reset)
+	assert.Equal(t, int64(historySize+10), msg.ID)
+
+	msg = receive(t, s.Subscribe(ctx, 1, 100000))
+	assert.True(t, msg.Reset)
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := s.Subscribe(ctx, 1, 0)
+	for i := 0; i < bufferSize+1; i++ {
+		s.Notify(change(1, "event"))
+	}
+
+	count := 0
+	for range ch {
+		count++
+	}
+	assert.Equal(t, bufferSize, count)
+}
+
+func TestUnsubscribeOnCancel(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := s.Subscribe(ctx, 1, 0)
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Empty(t, s.subscribers)
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+)
+
+type outboxState struct {
+	Subscriptions      []*model.Subscription `json:"subscriptions"`
+	Deliveries         []*model.Delivery     `json:"deliveries"`
+	NextSubscriptionID int                   `json:"next_subscription_id"`
+	NextDeliveryID     int                   `json:"next_delivery_id"`
+}
+
+// outbox persists webhook state to a JSON file so pending deliveries survive restarts.
+type outbox struct {
+	path string
+}
+
+func newOutbox(path string) *outbox {
+	return &outbox{path: path}
+}
+
+func (o *outbox) load() (*outboxState, error) {
+	if len(o.path) == 0 {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(o.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state outboxState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func (o *outbox) save(state *outboxState) error {
+	if len(o.path) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(o.path), filepath.Base(o.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), o.path)
+}
@@ -0,0 +1,446 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service"
+)
+
+// Headers attached to every webhook request.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+)
+
+// pollInterval bounds how long the worker sleeps between outbox scans.
+const pollInterval = time.Second
+
+var _ service.WebhookService = (*serv)(nil)
+
+type serv struct {
+	mu sync.Mutex
+	// saveMu orders outbox writes, which are made outside mu so that Notify never waits for the disk.
+	saveMu sync.Mutex
+	// dirty is set when the state has changed since the outbox was last written.
+	dirty         bool
+	cfg           config.WebhookConfig
+	client        *http.Client
+	outbox        *outbox
+	subscriptions map[int]*model.Subscription
+	deliveries    map[int]*model.Delivery
+	nextSubID     int
+	nextDelivID   int
+	wake          chan struct{}
+	clock         service.Clock
+}
+
+// Payload is the JSON body sent to webhook endpoints.
+type Payload struct {
+	DeliveryID     int              `json:"delivery_id"`
+	SubscriptionID int              `json:"subscription_id"`
+	Type           model.ChangeType `json:"type"`
+	OccurredAt     time.Time        `json:"occurred_at"`
+	Event          model.Event      `json:"event"`
+}
+
+// New creates a webhook service and restores its outbox from cfg.OutboxPath if set.
+// Attempts are scheduled and timestamped by clk.
+func New(cfg *config.WebhookConfig, clk service.Clock) (*serv, error) {
+	s := &serv{
+		cfg:           *cfg,
+		client:        &http.Client{Timeout: cfg.Timeout},
+		outbox:        newOutbox(cfg.OutboxPath),
+		subscriptions: make(map[int]*model.Subscription),
+		deliveries:    make(map[int]*model.Delivery),
+		nextSubID:     1,
+		nextDelivID:   1,
+		wake:          make(chan struct{}, 1),
+		clock:         clk,
+	}
+
+	state, err := s.outbox.load()
+	if err != nil {
+		return nil, fmt.Errorf("load outbox: %w", err)
+	}
+
+	if state != nil {
+		for _, sub := range state.Subscriptions {
+			s.subscriptions[sub.ID] = sub
+		}
+		for _, d := range state.Deliveries {
+			s.deliveries[d.ID] = d
+		}
+		s.nextSubID = state.NextSubscriptionID
+		s.nextDelivID = state.NextDeliveryID
+	}
+
+	return s, nil
+}
+
+// Sign returns the signature of body sent at timestamp, as placed in SignatureHeader.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// CreateSubscription registers a new webhook subscription and assigns it a unique ID.
+func (s *serv) CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	sub.ID = s.nextSubID
+	s.nextSubID++
+	sub.CreatedAt = s.clock.Now()
+	s.subscriptions[sub.ID] = sub
+	s.mu.Unlock()
+
+	if err := s.persist(); err != nil {
+		s.mu.Lock()
+		delete(s.subscriptions, sub.ID)
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// DeleteSubscription removes a webhook subscription. Pending deliveries for it are dropped.
+func (s *serv) DeleteSubscription(ctx context.Context, subscriptionID, userID int) error {
+	s.mu.Lock()
+	sub, ok := s.subscriptions[subscriptionID]
+	if !ok {
+		s.mu.Unlock()
+		return model.ErrSubscriptionNotFound
+	}
+
+	if sub.UserID != userID {
+		s.mu.Unlock()
+		return fmt.Errorf("user id not match: %w", model.ErrSubscriptionNotFound)
+	}
+
+	delete(s.subscriptions, subscriptionID)
+	for id, d := range s.deliveries {
+		if d.SubscriptionID == subscriptionID && d.Status == model.DeliveryPending {
+			delete(s.deliveries, id)
+		}
+	}
+	s.mu.Unlock()
+
+	return s.persist()
+}
+
+// GetSubscriptions returns all webhook subscriptions of a user.
+func (s *serv) GetSubscriptions(ctx context.Context, userID int) ([]*model.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*model.Subscription, 0)
+	for _, sub := range s.subscriptions {
+		if sub.UserID == userID {
+			result = append(result, sub)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+
+	return result, nil
+}
+
+// GetDeliveries returns the delivery log of a user, optionally filtered by status.
+func (s *serv) GetDeliveries(ctx context.Context, userID int, status model.DeliveryStatus) ([]*model.Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*model.Delivery, 0)
+	for _, d := range s.deliveries {
+		if d.UserID == userID && (len(status) == 0 || d.Status == status) {
+			cp := *d
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+
+	return result, nil
+}
+
+// Notify enqueues a delivery for every subscription matching the change.
+// It only queues the deliveries in memory; the worker writes them to the outbox.
+func (s *serv) Notify(change model.EventChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	queued := false
+	for _, sub := range s.subscriptions {
+		if !sub.Matches(&change) {
+			continue
+		}
+
+		d := &model.Delivery{
+			ID:             s.nextDelivID,
+			SubscriptionID: sub.ID,
+			UserID:         sub.UserID,
+			Change:         change,
+			Status:         model.DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		s.nextDelivID++
+		s.deliveries[d.ID] = d
+		queued = true
+	}
+
+	if !queued {
+		return
+	}
+	s.dirty = true
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers pending webhooks and writes the outbox until ctx is cancelled.
+// Changes queued since the last write are saved before and after every delivery round and on exit.
+func (s *serv) Run(ctx context.Context) {
+	timer := s.clock.NewTimer(0)
+	defer timer.Stop()
+	defer s.persistIfDirty()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		case <-s.wake:
+		}
+
+		s.persistIfDirty()
+		s.deliverDue(ctx)
+		s.persistIfDirty()
+		timer.Reset(s.untilNextAttempt())
+	}
+}
+
+// untilNextAttempt returns how long the worker may sleep before a pending delivery becomes due.
+func (s *serv) untilNextAttempt() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wait := pollInterval
+	now := s.clock.Now()
+	for _, d := range s.deliveries {
+		if d.Status != model.DeliveryPending {
+			continue
+		}
+		if until := d.NextAttemptAt.Sub(now); until < wait {
+			wait = max(until, 0)
+		}
+	}
+
+	return wait
+}
+
+func (s *serv) deliverDue(ctx context.Context) {
+	s.mu.Lock()
+	now := s.clock.Now()
+	var due []model.Delivery
+	for _, d := range s.deliveries {
+		if d.Status == model.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, *d)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+
+	for _, d := range due {
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.mu.Lock()
+		sub, ok := s.subscriptions[d.SubscriptionID]
+		var target model.Subscription
+		if ok {
+			target = *sub
+		}
+		s.mu.Unlock()
+		if !ok {
+			continue
+		}
+
+		code, err := s.send(ctx, &target, &d)
+		s.recordAttempt(d.ID, code, err)
+	}
+}
+
+func (s *serv) send(ctx context.Context, sub *model.Subscription, d *model.Delivery) (int, error) {
+	body, err := json.Marshal(Payload{
+		DeliveryID:     d.ID,
+		SubscriptionID: sub.ID,
+		Type:           d.Change.Type,
+		OccurredAt:     d.Change.OccurredAt,
+		Event:          d.Change.Event,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := s.clock.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, timestamp, body))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(DeliveryHeader, strconv.Itoa(d.ID))
+	req.Header.Set(EventHeader, string(d.Change.Type))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (s *serv) recordAttempt(deliveryID, code int, sendErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.deliveries[deliveryID]
+	if !ok {
+		return
+	}
+
+	now := s.clock.Now()
+	d.Attempts++
+	d.LastStatusCode = code
+	d.UpdatedAt = now
+
+	switch {
+	case sendErr == nil:
+		d.Status = model.DeliveryDelivered
+		d.LastError = ""
+		s.trimLog(model.DeliveryDelivered)
+	case d.Attempts >= s.cfg.MaxAttempts:
+		d.Status = model.DeliveryDead
+		d.LastError = sendErr.Error()
+		s.trimLog(model.DeliveryDead)
+	default:
+		d.LastError = sendErr.Error()
+		d.NextAttemptAt = now.Add(s.backoff(d.Attempts))
+	}
+	s.dirty = true
+}
+
+// backoff returns the delay before the next attempt, doubling after every failure.
+func (s *serv) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.cfg.MaxBackoff {
+			return s.cfg.MaxBackoff
+		}
+	}
+
+	return delay
+}
+
+// trimLog keeps the newest LogSize deliveries with the given final status and drops the older ones.
+func (s *serv) trimLog(status model.DeliveryStatus) {
+	var ids []int
+	for id, d := range s.deliveries {
+		if d.Status == status {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) <= s.cfg.LogSize {
+		return
+	}
+
+	sort.Ints(ids)
+	for _, id := range ids[:len(ids)-s.cfg.LogSize] {
+		delete(s.deliveries, id)
+	}
+}
+
+// persistIfDirty writes the outbox if the state has changed since the last write and logs a failure.
+func (s *serv) persistIfDirty() {
+	s.mu.Lock()
+	dirty := s.dirty
+	s.mu.Unlock()
+	if !dirty {
+		return
+	}
+
+	if err := s.persist(); err != nil {
+		log.Printf("webhook: persist outbox: %v", err)
+	}
+}
+
+// persist writes a snapshot of the state to the outbox. The snapshot is taken under mu
+// and written without it. A failed write leaves the state dirty, so the worker retries it.
+func (s *serv) persist() error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	s.mu.Lock()
+	state := &outboxState{
+		NextSubscriptionID: s.nextSubID,
+		NextDeliveryID:     s.nextDelivID,
+	}
+	for _, sub := range s.subscriptions {
+		cp := *sub
+		state.Subscriptions = append(state.Subscriptions, &cp)
+	}
+	for _, d := range s.deliveries {
+		cp := *d
+		state.Deliveries = append(state.Deliveries, &cp)
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	if err := s.outbox.save(state); err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
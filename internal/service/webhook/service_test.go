@@ -0,0 +1,258 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/model"
+	"github.com/biryanim/wb_tech_calendar/internal/service/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *config.WebhookConfig {
+	return &config.WebhookConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		Timeout:     time.Second,
+		LogSize:     10,
+	}
+}
+
+func testChange(userID int) model.EventChange {
+	return model.EventChange{
+		Type:       model.ChangeCreated,
+		Event:      model.Event{ID: "01JAQ6Y4X2ZC5W8N3V7T0MBR9K", UserID: userID, Title: "test", Date: time.Now()},
+		OccurredAt: time.Now(),
+	}
+}
+
+func waitStatus(t *testing.T, s *serv, userID int, status model.DeliveryStatus) []*model.Delivery {
+	t.Helper()
+
+	var deliveries []*model.Delivery
+	require.Eventually(t, func() bool {
+		var err error
+		deliveries, err = s.GetDeliveries(context.Background(), userID, status)
+		return err == nil && len(deliveries) > 0
+	}, 2*time.Second, 5*time.Millisecond)
+
+	return deliveries
+}
+
+func TestCreateSubscription(t *testing.T) {
+	s, err := New(testConfig(), clock.New())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = s.CreateSubscription(ctx, &model.Subscription{UserID: 1, URL: "ftp://example.com", Secret: "s"})
+	assert.ErrorIs(t, err, model.ErrInvalidURL)
+
+	_, err = s.CreateSubscription(ctx, &model.Subscription{UserID: 1, URL: "http://example.com"})
+	assert.ErrorIs(t, err, model.ErrEmptySecret)
+
+	sub, err := s.CreateSubscription(ctx, &model.Subscription{UserID: 1, URL: "http://example.com", Secret: "s"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, sub.ID)
+
+	subs, err := s.GetSubscriptions(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, subs, 1)
+
+	err = s.DeleteSubscription(ctx, sub.ID, 2)
+	assert.ErrorIs(t, err, model.ErrSubscriptionNotFound)
+	err = s.DeleteSubscription(ctx, sub.ID, 1)
+	assert.NoError(t, err)
+}
+
+func TestDeliverSigned(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer srv.Close()
+
+	s, err := New(testConfig(), clock.New())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	_, err = s.CreateSubscription(ctx, &model.Subscription{UserID: 1, URL: srv.URL, Secret: "secret"})
+	require.NoError(t, err)
+
+	s.Notify(testChange(2))
+	s.Notify(testChange(1))
+
+	r := <-received
+	body := <-bodies
+	ts, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("secret", ts, body), r.Header.Get(SignatureHeader))
+	assert.Equal(t, string(model.ChangeCreated), r.Header.Get(EventHeader))
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, 1, payload.Event.UserID)
+
+	deliveries := waitStatus(t, s, 1, model.DeliveryDelivered)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+
+	other, err := s.GetDeliveries(ctx, 2, "")
+	assert.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestRetryThenDeadLetter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	cfg := testConfig()
+	cfg.BaseBackoff, cfg.MaxBackoff = time.Minute, 5*time.Minute
+	s, err := New(cfg, clk)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err = s.CreateSubscription(ctx, &model.Subscription{UserID: 1, URL: srv.URL, Secret: "secret"})
+	require.NoError(t, err)
+	s.Notify(testChange(1))
+
+	go s.Run(ctx)
+
+	// Retries wait for the backoff on the clock, so they only come as it is moved on.
+	waitStatus(t, s, 1, model.DeliveryPending)
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, 2*time.Second, 5*time.Millisecond)
+	var dead []*model.Delivery
+	require.Eventually(t, func() bool {
+		clk.Advance(time.Minute)
+		dead, err = s.GetDeliveries(ctx, 1, model.DeliveryDead)
+		return err == nil && len(dead) > 0
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, start, dead[0].CreatedAt)
+	assert.True(t, dead[0].UpdatedAt.After(start.Add(2*time.Minute)))
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, dead[0].LastStatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestDeadLettersTrimmed(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	cfg := testConfig()
+	cfg.MaxAttempts, cfg.LogSize = 1, 2
+	s, err := New(cfg, clock.New())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err = s.CreateSubscription(ctx, &model.Subscription{UserID: 1, URL: srv.URL, Secret: "secret"})
+	require.NoError(t, err)
+	for range 4 {
+		s.Notify(testChange(1))
+	}
+	go s.Run(ctx)
+
+	require.Eventually(t, func() bool { return calls.Load() == 4 }, 2*time.Second, 5*time.Millisecond)
+	var dead []*model.Delivery
+	require.Eventually(t, func() bool {
+		dead, err = s.GetDeliveries(ctx, 1, model.DeliveryDead)
+		return err == nil && len(dead) == 2 && dead[0].ID == 3
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, 4, dead[1].ID)
+}
+
+func TestBackoff(t *testing.T) {
+	s, err := New(&config.WebhookConfig{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}, clock.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Second, s.backoff(1))
+	assert.Equal(t, 2*time.Second, s.backoff(2))
+	assert.Equal(t, 4*time.Second, s.backoff(3))
+	assert.Equal(t, 5*time.Second, s.backoff(4))
+}
+
+func TestOutboxSurvivesRestart(t *testing.T) {
+	cfg := testConfig()
+	cfg.OutboxPath = filepath.Join(t.TempDir(), "outbox.json")
+	ctx := context.Background()
+
+	// The fake clock keeps the failed first attempt from being retried before the worker stops.
+	s, err := New(cfg, clock.NewFake(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)))
+	require.NoError(t, err)
+	_, err = s.CreateSubscription(ctx, &model.Subscription{UserID: 1, URL: "http://127.0.0.1:1", Secret: "secret"})
+	require.NoError(t, err)
+
+	// Notify only queues the delivery; the worker writes it to the outbox, at the latest when it stops.
+	s.Notify(testChange(1))
+	data, err := os.ReadFile(cfg.OutboxPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"deliveries":[`)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		s.Run(runCtx)
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	restored, err := New(cfg, clock.New())
+	require.NoError(t, err)
+
+	subs, err := restored.GetSubscriptions(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, subs, 1)
+
+	pending, err := restored.GetDeliveries(ctx, 1, model.DeliveryPending)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	sub, err := restored.CreateSubscription(ctx, &model.Subscription{UserID: 1, URL: "http://example.com", Secret: "s"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, sub.ID)
+}
+
+func TestOutboxWithIntegerEventIDs(t *testing.T) {
+	cfg := testConfig()
+	cfg.OutboxPath = filepath.Join(t.TempDir(), "outbox.json")
+	state := `{"subscriptions":[],"next_subscription_id":1,"next_delivery_id":2,"deliveries":[{"id":1,"subscription_id":1,"user_id":1,"status":"pending",
+		"change":{"seq":1,"type":"event.created","event":{"id":5,"user_id":1,"date":"2026-10-19T09:00:00Z","title":"standup","version":1}}}]}`
+	require.NoError(t, os.WriteFile(cfg.OutboxPath, []byte(state), 0o600))
+
+	s, err := New(cfg, clock.New())
+	require.NoError(t, err)
+
+	pending, err := s.GetDeliveries(context.Background(), 1, model.DeliveryPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "5", pending[0].Change.Event.ID)
+	assert.Equal(t, 5, pending[0].Change.Event.LegacyID)
+	assert.Equal(t, "standup", pending[0].Change.Event.Title)
+}
@@ -0,0 +1,656 @@
+// Package client is a typed Go client for the calendar HTTP API.
+// It mirrors the OpenAPI document served at /openapi.json; every operation there has a method here.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	problemContentType    = "application/problem+json"
+	idempotencyKeyHeader  = "Idempotency-Key"
+)
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey returns a context that makes write calls send key as their Idempotency-Key.
+// Retrying a call with the same key and arguments replays the original response instead of
+// applying the write again; reusing the key for different arguments fails with 422.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// Client calls the calendar HTTP API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient makes the client send requests with httpClient instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// New creates a client for the API served at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ListEvents returns the events of a user between two dates inclusive.
+func (c *Client) ListEvents(ctx context.Context, params ListEventsParams) ([]*Event, error) {
+	var res []*Event
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/events", query: rangeQuery(params)}, &res)
+	return res, err
+}
+
+// GetEventStats counts the events of a user per period, tag and category.
+func (c *Client) GetEventStats(ctx context.Context, params StatsParams) ([]*PeriodStats, error) {
+	q := rangeQuery(params.ListEventsParams)
+	setIfNotEmpty(q, "period", params.Period)
+
+	var res []*PeriodStats
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/events/stats", query: q}, &res)
+	return res, err
+}
+
+// GetMonthGrid returns the month containing params.Date laid out as six weeks of seven days.
+func (c *Client) GetMonthGrid(ctx context.Context, params MonthGridParams) (*MonthGrid, error) {
+	q := dateQuery(params.DateParams)
+	setIfNotEmpty(q, "week_start", params.WeekStart)
+	setIfNotEmpty(q, "holidays", params.Holidays)
+	if params.MaxEvents > 0 {
+		q.Set("max_events", strconv.Itoa(params.MaxEvents))
+	}
+
+	var res MonthGrid
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/views/month", query: q}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetWeekView returns the week selected by params laid out day by day.
+func (c *Client) GetWeekView(ctx context.Context, params WeekViewParams) (*WeekView, error) {
+	q := dateQuery(params.DateParams)
+	setIfNotEmpty(q, "week", params.Week)
+	setIfNotEmpty(q, "week_start", params.WeekStart)
+	setIfNotEmpty(q, "holidays", params.Holidays)
+
+	var res WeekView
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/views/week", query: q}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetSettings returns the calendar settings of a user, or the defaults if none are saved.
+func (c *Client) GetSettings(ctx context.Context, userID int) (*Settings, error) {
+	var res Settings
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/settings", query: userQuery(userID)}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateSettings replaces the calendar settings of a user.
+func (c *Client) UpdateSettings(ctx context.Context, userID int, fields SettingsFields) (*Settings, error) {
+	var res Settings
+	err := c.do(ctx, request{method: http.MethodPut, path: "/api/v1/settings", query: userQuery(userID), body: fields}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetWorkingHoursOverlap returns the working time of several users between two dates inclusive and
+// the part of it they all share.
+func (c *Client) GetWorkingHoursOverlap(ctx context.Context, params OverlapParams) (*WorkingHoursOverlap, error) {
+	userIDs := make([]string, 0, len(params.UserIDs))
+	for _, userID := range params.UserIDs {
+		userIDs = append(userIDs, strconv.Itoa(userID))
+	}
+	q := url.Values{"user_ids": {strings.Join(userIDs, ",")}, "from": {params.From}, "to": {params.To}}
+	setIfNotEmpty(q, "tz", params.TimeZone)
+
+	var res WorkingHoursOverlap
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/working_hours/overlap", query: q}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListHolidayCalendars returns the holiday calendars loaded by the server, by country.
+func (c *Client) ListHolidayCalendars(ctx context.Context) ([]*HolidayCalendar, error) {
+	var res []*HolidayCalendar
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/holidays"}, &res)
+	return res, err
+}
+
+// ListHolidays returns the days off and worked weekend days of the country's holiday calendar
+// between two dates inclusive.
+func (c *Client) ListHolidays(ctx context.Context, country, from, to string) ([]*Holiday, error) {
+	q := url.Values{"from": {from}, "to": {to}}
+
+	var res []*Holiday
+	err := c.do(ctx, request{method: http.MethodGet, path: holidayPath(country), query: q}, &res)
+	return res, err
+}
+
+// AddWorkdays returns the day days working days after date in the country's holiday calendar,
+// or before it for a negative days. Zero days returns date, or the next working day if it is off.
+func (c *Client) AddWorkdays(ctx context.Context, country, date string, days int) (string, error) {
+	q := url.Values{"date": {date}, "days": {strconv.Itoa(days)}}
+
+	var res struct {
+		Date string `json:"date"`
+	}
+	err := c.do(ctx, request{method: http.MethodGet, path: holidayPath(country) + "/workdays", query: q}, &res)
+	return res.Date, err
+}
+
+// SearchEvents returns the user's events matching the words of params.Query, best matches first.
+func (c *Client) SearchEvents(ctx context.Context, params SearchParams) ([]*SearchHit, error) {
+	q := userQuery(params.UserID)
+	q.Set("q", params.Query)
+	setIfNotEmpty(q, "from", params.From)
+	setIfNotEmpty(q, "to", params.To)
+	setIfNotEmpty(q, "tz", params.TimeZone)
+	if params.Limit > 0 {
+		q.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	var res []*SearchHit
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/events/search", query: q}, &res)
+	return res, err
+}
+
+// CreateEvent creates an event.
+func (c *Client) CreateEvent(ctx context.Context, req CreateEventRequest) (*Event, error) {
+	var res Event
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/events", body: req}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ParseEvent reads an event from text without creating it, so that it can be confirmed first.
+func (c *Client) ParseEvent(ctx context.Context, req QuickEventRequest) (*EventDraft, error) {
+	var res EventDraft
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/events/quick", body: req}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateEventFromText reads an event from text and creates it. A recurrence in the text is reported
+// as a WarningRecurrenceIgnored warning.
+func (c *Client) CreateEventFromText(ctx context.Context, req QuickEventRequest) (*Event, error) {
+	body := struct {
+		QuickEventRequest
+		Create bool `json:"create"`
+	}{req, true}
+
+	var res Event
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/events/quick", body: body}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// BatchEvents applies a batch of creates, updates and deletes and returns a result per operation
+// in request order. Failed operations are reported in the results, not as an error.
+func (c *Client) BatchEvents(ctx context.Context, req BatchRequest) ([]*BatchResult, error) {
+	var res struct {
+		Results []*BatchResult `json:"results"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/events/batch", body: req}, &res)
+	return res.Results, err
+}
+
+// GetEvent returns an event of the user. Like the other event methods it takes the ULID of the
+// event or, until clients have moved to ULIDs, its legacy integer ID.
+func (c *Client) GetEvent(ctx context.Context, userID int, eventID string) (*Event, error) {
+	var res Event
+	err := c.do(ctx, request{method: http.MethodGet, path: eventPath(eventID), query: userQuery(userID)}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ReplaceEvent replaces every writable field of an event.
+// A non-zero version makes the call fail with 412 if the event has changed since.
+func (c *Client) ReplaceEvent(ctx context.Context, userID int, eventID string, fields EventFields, version int) (*Event, error) {
+	var res Event
+	err := c.do(ctx, request{
+		method:  http.MethodPut,
+		path:    eventPath(eventID),
+		query:   userQuery(userID),
+		body:    fields,
+		version: version,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// PatchEvent changes only the non-nil fields of patch. version works as in ReplaceEvent.
+func (c *Client) PatchEvent(ctx context.Context, userID int, eventID string, patch EventPatch, version int) (*Event, error) {
+	var res Event
+	err := c.do(ctx, request{
+		method:      http.MethodPatch,
+		path:        eventPath(eventID),
+		query:       userQuery(userID),
+		body:        patch,
+		contentType: mergePatchContentType,
+		version:     version,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteEvent deletes an event. version works as in ReplaceEvent.
+func (c *Client) DeleteEvent(ctx context.Context, userID int, eventID string, version int) error {
+	return c.do(ctx, request{
+		method:  http.MethodDelete,
+		path:    eventPath(eventID),
+		query:   userQuery(userID),
+		version: version,
+	}, nil)
+}
+
+// GetEventsForDay returns the events of the day params.Date.
+func (c *Client) GetEventsForDay(ctx context.Context, params DateParams) ([]*Event, error) {
+	return c.getEventsForDate(ctx, "/events_for_day", params)
+}
+
+// GetEventsForWeek returns the events of the calendar week containing params.Date or of the ISO week params.Week.
+func (c *Client) GetEventsForWeek(ctx context.Context, params WeekParams) ([]*Event, error) {
+	q := dateQuery(params.DateParams)
+	setIfNotEmpty(q, "week", params.Week)
+	setIfNotEmpty(q, "week_start", params.WeekStart)
+
+	var res []*Event
+	err := c.do(ctx, request{method: http.MethodGet, path: "/events_for_week", query: q}, &res)
+	return res, err
+}
+
+// GetEventsForMonth returns the events of the month containing params.Date.
+func (c *Client) GetEventsForMonth(ctx context.Context, params DateParams) ([]*Event, error) {
+	return c.getEventsForDate(ctx, "/events_for_month", params)
+}
+
+// Sync returns the events changed since token; an empty token returns every event of the user.
+// A Problem with status 410 means the token has expired and a full sync is required.
+func (c *Client) Sync(ctx context.Context, userID int, token string) (*SyncResponse, error) {
+	q := userQuery(userID)
+	setIfNotEmpty(q, "token", token)
+
+	var res SyncResponse
+	err := c.do(ctx, request{method: http.MethodGet, path: "/sync", query: q}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateWebhook subscribes a URL to the changes of a user's events.
+func (c *Client) CreateWebhook(ctx context.Context, req CreateSubscriptionRequest) (*Subscription, error) {
+	var res struct {
+		Result *Subscription `json:"result"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/create_webhook", body: req}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Result, nil
+}
+
+// DeleteWebhook removes a webhook subscription.
+func (c *Client) DeleteWebhook(ctx context.Context, userID, subscriptionID int) error {
+	body := map[string]int{"id": subscriptionID, "user_id": userID}
+	return c.do(ctx, request{method: http.MethodPost, path: "/delete_webhook", body: body}, nil)
+}
+
+// ListWebhooks returns the webhook subscriptions of a user.
+func (c *Client) ListWebhooks(ctx context.Context, userID int) ([]*Subscription, error) {
+	var res []*Subscription
+	err := c.do(ctx, request{method: http.MethodGet, path: "/webhooks", query: userQuery(userID)}, &res)
+	return res, err
+}
+
+// ListWebhookDeliveries returns the delivery log of a user, optionally limited to one status.
+func (c *Client) ListWebhookDeliveries(ctx context.Context, userID int, status string) ([]*Delivery, error) {
+	q := userQuery(userID)
+	setIfNotEmpty(q, "status", status)
+
+	var res []*Delivery
+	err := c.do(ctx, request{method: http.MethodGet, path: "/webhook_deliveries", query: q}, &res)
+	return res, err
+}
+
+// ListTags returns the tags of a user ordered by name.
+func (c *Client) ListTags(ctx context.Context, userID int) ([]*Tag, error) {
+	var res []*Tag
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/tags", query: userQuery(userID)}, &res)
+	return res, err
+}
+
+// CreateTag creates a tag. Tags are also created implicitly when an event first carries them.
+func (c *Client) CreateTag(ctx context.Context, req CreateTagRequest) (*Tag, error) {
+	var res Tag
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/tags", body: req}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateTag replaces the fields of a tag. A new name is applied to every event carrying the tag.
+func (c *Client) UpdateTag(ctx context.Context, userID int, name string, fields TagFields) (*Tag, error) {
+	var res Tag
+	err := c.do(ctx, request{method: http.MethodPut, path: tagPath(name), query: userQuery(userID), body: fields}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteTag deletes a tag and removes it from the user's events.
+func (c *Client) DeleteTag(ctx context.Context, userID int, name string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: tagPath(name), query: userQuery(userID)}, nil)
+}
+
+// ListTemplates returns the user's event templates ordered by ID.
+func (c *Client) ListTemplates(ctx context.Context, userID int) ([]*Template, error) {
+	var res []*Template
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/templates", query: userQuery(userID)}, &res)
+	return res, err
+}
+
+// CreateTemplate saves an event template.
+func (c *Client) CreateTemplate(ctx context.Context, req CreateTemplateRequest) (*Template, error) {
+	var res Template
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/templates", body: req}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetTemplate returns an event template of the user.
+func (c *Client) GetTemplate(ctx context.Context, userID, templateID int) (*Template, error) {
+	var res Template
+	err := c.do(ctx, request{method: http.MethodGet, path: templatePath(templateID), query: userQuery(userID)}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ReplaceTemplate replaces every writable field of an event template.
+func (c *Client) ReplaceTemplate(ctx context.Context, userID, templateID int, fields TemplateFields) (*Template, error) {
+	var res Template
+	err := c.do(ctx, request{method: http.MethodPut, path: templatePath(templateID), query: userQuery(userID), body: fields}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteTemplate deletes an event template; events created from it are kept.
+func (c *Client) DeleteTemplate(ctx context.Context, userID, templateID int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: templatePath(templateID), query: userQuery(userID)}, nil)
+}
+
+// CreateEventFromTemplate creates an event from a template starting at date, a date or an RFC 3339
+// date-time. The non-nil fields of overrides replace those taken from the template.
+func (c *Client) CreateEventFromTemplate(ctx context.Context, userID, templateID int, date string, overrides *EventPatch) (*Event, error) {
+	body := struct {
+		Date      string      `json:"date"`
+		Overrides *EventPatch `json:"overrides,omitempty"`
+	}{date, overrides}
+
+	var res Event
+	err := c.do(ctx, request{method: http.MethodPost, path: templatePath(templateID) + "/events", query: userQuery(userID), body: body}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GraphQL runs a GraphQL query or mutation and decodes its data into out unless out is nil.
+// Errors reported by the operation are returned as GraphQLErrors.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]any, out any) error {
+	body := map[string]any{"query": query}
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
+
+	var res struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/graphql", body: body}, &res); err != nil {
+		return err
+	}
+	if len(res.Errors) > 0 {
+		return res.Errors
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(res.Data, out); err != nil {
+		return fmt.Errorf("decode data: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) getEventsForDate(ctx context.Context, path string, params DateParams) ([]*Event, error) {
+	var res []*Event
+	err := c.do(ctx, request{method: http.MethodGet, path: path, query: dateQuery(params)}, &res)
+	return res, err
+}
+
+func dateQuery(params DateParams) url.Values {
+	q := userQuery(params.UserID)
+	setIfNotEmpty(q, "date", params.Date)
+	setIfNotEmpty(q, "tz", params.TimeZone)
+	setTags(q, params.Tags, params.ExcludeTags)
+	return q
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        any
+	contentType string
+	// version is sent as If-Match when non-zero.
+	version int
+	header  http.Header
+}
+
+// send performs the request and returns the response of a successful call.
+// Error responses are decoded into a *Problem.
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	u := c.baseURL + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+
+	var body io.Reader
+	if r.body != nil {
+		data, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+	if r.body != nil {
+		contentType := r.contentType
+		if len(contentType) == 0 {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	if r.version > 0 {
+		req.Header.Set("If-Match", `"`+strconv.Itoa(r.version)+`"`)
+	}
+	if key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string); ok && r.method != http.MethodGet {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, decodeProblem(resp)
+	}
+
+	return resp, nil
+}
+
+// do performs the request and decodes a successful JSON response into out unless it is nil.
+func (c *Client) do(ctx context.Context, r request, out any) error {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// decodeProblem reads the problem details of an error response.
+// Responses that are not problem details are reported with their status only.
+func decodeProblem(resp *http.Response) error {
+	p := &Problem{
+		Status: resp.StatusCode,
+		Title:  http.StatusText(resp.StatusCode),
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == problemContentType {
+		if err := json.NewDecoder(resp.Body).Decode(p); err != nil {
+			return fmt.Errorf("decode problem: %w", err)
+		}
+	}
+
+	return p
+}
+
+func eventPath(eventID string) string {
+	return "/api/v1/events/" + url.PathEscape(eventID)
+}
+
+func tagPath(name string) string {
+	return "/api/v1/tags/" + url.PathEscape(name)
+}
+
+func templatePath(templateID int) string {
+	return "/api/v1/templates/" + strconv.Itoa(templateID)
+}
+
+func holidayPath(country string) string {
+	return "/api/v1/holidays/" + url.PathEscape(country)
+}
+
+func rangeQuery(params ListEventsParams) url.Values {
+	q := userQuery(params.UserID)
+	q.Set("from", params.From)
+	q.Set("to", params.To)
+	setIfNotEmpty(q, "tz", params.TimeZone)
+	setTags(q, params.Tags, params.ExcludeTags)
+
+	return q
+}
+
+func setTags(q url.Values, tags, excludeTags []string) {
+	setIfNotEmpty(q, "tags", strings.Join(tags, ","))
+	setIfNotEmpty(q, "exclude_tags", strings.Join(excludeTags, ","))
+}
+
+func userQuery(userID int) url.Values {
+	return url.Values{"user_id": {strconv.Itoa(userID)}}
+}
+
+func setIfNotEmpty(q url.Values, key, value string) {
+	if len(value) > 0 {
+		q.Set(key, value)
+	}
+}
@@ -0,0 +1,545 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/biryanim/wb_tech_calendar/internal/api/openapi"
+	"github.com/biryanim/wb_tech_calendar/internal/api/router"
+	"github.com/biryanim/wb_tech_calendar/internal/config"
+	"github.com/biryanim/wb_tech_calendar/internal/service/calendar"
+	"github.com/biryanim/wb_tech_calendar/internal/service/clock"
+	"github.com/biryanim/wb_tech_calendar/internal/service/holiday"
+	"github.com/biryanim/wb_tech_calendar/internal/service/idempotency"
+	"github.com/biryanim/wb_tech_calendar/internal/service/stream"
+	"github.com/biryanim/wb_tech_calendar/internal/service/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) *Client {
+	t.Helper()
+
+	clk := clock.New()
+	webhookService, err := webhook.New(&config.WebhookConfig{MaxAttempts: 1, LogSize: 10}, clk)
+	require.NoError(t, err)
+	streamService := stream.New()
+	holidayService, err := holiday.New(&config.HolidayConfig{Dir: "../../internal/service/holiday/testdata"})
+	require.NoError(t, err)
+
+	r, err := router.New(calendar.New(clk, webhookService, streamService), holidayService, webhookService, streamService,
+		idempotency.New(&config.IdempotencyConfig{TTL: time.Hour}, clk))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL, WithHTTPClient(srv.Client()))
+}
+
+func TestClientCoversSpec(t *testing.T) {
+	var doc struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+			Deprecated  bool   `json:"deprecated"`
+			ClientSkip  bool   `json:"x-client-skip"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(openapi.Spec(), &doc))
+
+	clientType := reflect.TypeOf(&Client{})
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			if op.Deprecated || op.ClientSkip {
+				continue
+			}
+
+			name := strings.ToUpper(op.OperationID[:1]) + op.OperationID[1:]
+			_, ok := clientType.MethodByName(name)
+			assert.True(t, ok, "no client method %s for %s %s", name, method, path)
+		}
+	}
+}
+
+func TestEventLifecycle(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	created, err := c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2025-10-01", Title: "standup"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.Version)
+	assert.Equal(t, 1, created.LegacyID)
+
+	got, err := c.GetEvent(ctx, 1, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	got, err = c.GetEvent(ctx, 1, strconv.Itoa(created.LegacyID))
+	require.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	replaced, err := c.ReplaceEvent(ctx, 1, created.ID, EventFields{Date: "2025-10-02", Title: "retro", Location: "room 1"}, created.Version)
+	require.NoError(t, err)
+	assert.Equal(t, "retro", replaced.Title)
+	assert.Equal(t, "room 1", replaced.Location)
+	assert.Equal(t, StatusConfirmed, replaced.Status)
+
+	title, visibility := "demo", VisibilityPrivate
+	patched, err := c.PatchEvent(ctx, 1, created.ID, EventPatch{Title: &title, Visibility: &visibility}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "demo", patched.Title)
+	assert.Equal(t, replaced.Date, patched.Date)
+	assert.Equal(t, "room 1", patched.Location)
+	assert.Equal(t, VisibilityPrivate, patched.Visibility)
+
+	_, err = c.ReplaceEvent(ctx, 1, created.ID, EventFields{Date: "2025-10-02", Title: "stale"}, created.Version)
+	var p *Problem
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, http.StatusPreconditionFailed, p.Status)
+	require.NotNil(t, p.Current)
+	assert.Equal(t, patched.Version, p.Current.Version)
+
+	events, err := c.ListEvents(ctx, ListEventsParams{UserID: 1, From: "2025-10-01", To: "2025-10-31"})
+	require.NoError(t, err)
+	assert.Equal(t, []*Event{patched}, events)
+
+	events, err = c.GetEventsForWeek(ctx, WeekParams{DateParams: DateParams{UserID: 1, Date: "2025-10-01"}})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	synced, err := c.Sync(ctx, 1, "")
+	require.NoError(t, err)
+	assert.Len(t, synced.Events, 1)
+
+	require.NoError(t, c.DeleteEvent(ctx, 1, created.ID, patched.Version))
+
+	synced, err = c.Sync(ctx, 1, synced.Token)
+	require.NoError(t, err)
+	assert.Equal(t, []string{created.ID}, synced.Deleted)
+	assert.Equal(t, []int{created.LegacyID}, synced.LegacyDeleted)
+
+	_, err = c.GetEvent(ctx, 1, created.ID)
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, http.StatusNotFound, p.Status)
+}
+
+func TestValidationProblem(t *testing.T) {
+	c := newServer(t)
+
+	_, err := c.ListEvents(context.Background(), ListEventsParams{UserID: 1, From: "2025-10-02", To: "2025-10-01"})
+
+	var p *Problem
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, http.StatusBadRequest, p.Status)
+	assert.Equal(t, "validation_failed", p.Code)
+	require.Len(t, p.Errors, 1)
+	assert.Equal(t, "to", p.Errors[0].Field)
+}
+
+func TestBatchEvents(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	ops := []BatchOperation{
+		{Op: BatchCreate, UserID: 1, Date: "2025-10-01", Title: "lecture"},
+		{Op: BatchUpdate, ID: "1", UserID: 1, Date: "2025-10-02", Title: "seminar"},
+		{Op: BatchDelete, ID: "7", UserID: 1},
+	}
+
+	results, err := c.BatchEvents(ctx, BatchRequest{Operations: ops})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "batch_aborted", results[0].Error.Code)
+	assert.Equal(t, "batch_aborted", results[1].Error.Code)
+	assert.Equal(t, http.StatusNotFound, results[2].Status)
+
+	results, err = c.BatchEvents(ctx, BatchRequest{Operations: ops, ContinueOnError: true})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, results[0].Status)
+	assert.Equal(t, http.StatusOK, results[1].Status)
+	assert.Equal(t, "seminar", results[1].Event.Title)
+	assert.Equal(t, 2, results[1].Event.Version)
+	assert.Equal(t, "event_not_found", results[2].Error.Code)
+
+	events, err := c.ListEvents(ctx, ListEventsParams{UserID: 1, From: "2025-10-01", To: "2025-10-31"})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestSearchEvents(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	for _, title := range []string{"Weekly planning", "Planning poker", "Retro"} {
+		_, err := c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2025-10-01", Title: title})
+		require.NoError(t, err)
+	}
+
+	hits, err := c.SearchEvents(ctx, SearchParams{UserID: 1, Query: "planing"})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	assert.Positive(t, hits[0].Score)
+
+	hits, err = c.SearchEvents(ctx, SearchParams{UserID: 1, Query: "plan", From: "2025-10-02"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	c := newServer(t)
+	ctx := WithIdempotencyKey(context.Background(), "import-42")
+	req := CreateEventRequest{UserID: 1, Date: "2025-10-01", Title: "lecture"}
+
+	first, err := c.CreateEvent(ctx, req)
+	require.NoError(t, err)
+	retry, err := c.CreateEvent(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, first, retry)
+
+	req.Title = "seminar"
+	_, err = c.CreateEvent(ctx, req)
+	var p *Problem
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, http.StatusUnprocessableEntity, p.Status)
+}
+
+func TestWebhooks(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	sub, err := c.CreateWebhook(ctx, CreateSubscriptionRequest{
+		UserID: 1,
+		URL:    "http://localhost/hook",
+		Secret: "secret",
+		Events: []string{ChangeCreated},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{ChangeCreated}, sub.Events)
+
+	subs, err := c.ListWebhooks(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*Subscription{sub}, subs)
+
+	deliveries, err := c.ListWebhookDeliveries(ctx, 1, DeliveryDead)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+
+	require.NoError(t, c.DeleteWebhook(ctx, 1, sub.ID))
+
+	subs, err = c.ListWebhooks(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, subs)
+}
+
+func TestStreamEvents(t *testing.T) {
+	c := newServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s, err := c.StreamEvents(ctx, 1, 0)
+	require.NoError(t, err)
+	defer s.Close()
+
+	created, err := c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2025-10-01", Title: "standup"})
+	require.NoError(t, err)
+
+	msg, err := s.Next()
+	require.NoError(t, err)
+	assert.Equal(t, ChangeCreated, msg.Type)
+	assert.Equal(t, created, msg.Event)
+
+	cancel()
+	_, err = s.Next()
+	assert.True(t, err != nil && (errors.Is(err, io.EOF) || errors.Is(err, context.Canceled)), "unexpected error %v", err)
+}
+
+func TestTags(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	tag, err := c.CreateTag(ctx, CreateTagRequest{UserID: 1, Name: "work", Category: "job", Color: "#ff0000"})
+	require.NoError(t, err)
+	assert.Equal(t, &Tag{UserID: 1, Name: "work", Category: "job", Color: "#ff0000"}, tag)
+
+	for _, req := range []CreateEventRequest{
+		{UserID: 1, Date: "2025-10-01", Title: "standup", Tags: []string{"Work"}},
+		{UserID: 1, Date: "2025-10-02", Title: "gym", Tags: []string{"sport"}},
+		{UserID: 1, Date: "2025-10-09", Title: "dentist"},
+	} {
+		_, err = c.CreateEvent(ctx, req)
+		require.NoError(t, err)
+	}
+
+	events, err := c.ListEvents(ctx, ListEventsParams{UserID: 1, From: "2025-10-01", To: "2025-10-31", Tags: []string{"work"}})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, []string{"work"}, events[0].Tags)
+
+	events, err = c.GetEventsForMonth(ctx, DateParams{UserID: 1, Date: "2025-10-01", ExcludeTags: []string{"work", "sport"}})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Empty(t, events[0].Tags)
+
+	tag, err = c.UpdateTag(ctx, 1, "work", TagFields{Name: "office", Category: "job"})
+	require.NoError(t, err)
+	assert.Equal(t, "office", tag.Name)
+
+	stats, err := c.GetEventStats(ctx, StatsParams{
+		ListEventsParams: ListEventsParams{UserID: 1, From: "2025-10-01", To: "2025-10-31"},
+		Period:           PeriodMonth,
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, 3, stats[0].Total)
+	assert.Equal(t, 1, stats[0].Untagged)
+	assert.Equal(t, map[string]int{"office": 1, "sport": 1}, stats[0].Tags)
+	assert.Equal(t, map[string]int{"job": 1}, stats[0].Categories)
+
+	require.NoError(t, c.DeleteTag(ctx, 1, "office"))
+
+	tags, err := c.ListTags(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*Tag{{UserID: 1, Name: "sport"}}, tags)
+}
+
+func TestMultiDayEvents(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	conference, err := c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2026-10-19", End: "2026-10-22", AllDay: true, Title: "conference"})
+	require.NoError(t, err)
+	assert.True(t, conference.AllDay)
+
+	_, err = c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2026-10-20T22:00:00+03:00", End: "2026-10-21T06:00:00+03:00", Title: "night shift"})
+	require.NoError(t, err)
+
+	events, err := c.GetEventsForDay(ctx, DateParams{UserID: 1, Date: "2026-10-20", TimeZone: "Europe/Moscow"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.True(t, events[0].ContinuesBefore)
+	assert.True(t, events[0].ContinuesAfter)
+	assert.False(t, events[1].ContinuesBefore)
+	assert.True(t, events[1].ContinuesAfter)
+
+	events, err = c.ListEvents(ctx, ListEventsParams{UserID: 1, From: "2026-10-19", To: "2026-10-25"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.False(t, events[0].ContinuesBefore || events[0].ContinuesAfter)
+
+	_, err = c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2026-10-19T10:00:00Z", AllDay: true, Title: "bad"})
+	var p *Problem
+	require.ErrorAs(t, err, &p)
+	require.Len(t, p.Errors, 1)
+	assert.Equal(t, "not_whole_day", p.Errors[0].Code)
+}
+
+func TestWeeks(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	settings, err := c.GetSettings(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &Settings{UserID: 1, WeekStart: WeekdayMonday, TimeZone: "UTC",
+		WorkingHours: []*WorkingHours{}, OutOfOffice: []*OutOfOffice{}}, settings)
+
+	_, err = c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2026-10-18", AllDay: true, Title: "sunday"})
+	require.NoError(t, err)
+
+	week := WeekParams{DateParams: DateParams{UserID: 1, Date: "2026-10-21"}}
+	events, err := c.GetEventsForWeek(ctx, week)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	settings, err = c.UpdateSettings(ctx, 1, SettingsFields{WeekStart: WeekdaySunday})
+	require.NoError(t, err)
+	assert.Equal(t, WeekdaySunday, settings.WeekStart)
+
+	events, err = c.GetEventsForWeek(ctx, week)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	events, err = c.GetEventsForWeek(ctx, WeekParams{DateParams: DateParams{UserID: 1}, Week: "2026-W42"})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	_, err = c.UpdateSettings(ctx, 1, SettingsFields{WeekStart: "sun"})
+	var p *Problem
+	require.ErrorAs(t, err, &p)
+	require.Len(t, p.Errors, 1)
+	assert.Equal(t, "invalid_weekday", p.Errors[0].Code)
+}
+
+func TestMonthGrid(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	for _, title := range []string{"a", "b", "c"} {
+		_, err := c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2026-10-19T10:00:00Z", Title: title})
+		require.NoError(t, err)
+	}
+	_, err := c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2026-10-19", End: "2026-10-21", AllDay: true, Title: "trip"})
+	require.NoError(t, err)
+
+	grid, err := c.GetMonthGrid(ctx, MonthGridParams{DateParams: DateParams{UserID: 1, Date: "2026-10-01"}, WeekStart: WeekdaySunday, MaxEvents: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", grid.Month)
+	assert.Equal(t, WeekdaySunday, grid.WeekStart)
+	require.Len(t, grid.Weeks, 6)
+	assert.Equal(t, "2026-09-27", grid.Weeks[0][0].Date)
+	assert.True(t, grid.Weeks[0][0].Weekend)
+
+	monday := grid.Weeks[3][1]
+	assert.Equal(t, "2026-10-19", monday.Date)
+	require.Len(t, monday.Events, 2)
+	assert.Equal(t, "trip", monday.Events[0].Title)
+	assert.True(t, monday.Events[0].ContinuesAfter)
+	assert.Equal(t, 2, monday.Overflow)
+
+	tuesday := grid.Weeks[3][2]
+	require.Len(t, tuesday.Events, 1)
+	assert.True(t, tuesday.Events[0].ContinuesBefore)
+}
+
+func TestHolidays(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	calendars, err := c.ListHolidayCalendars(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*HolidayCalendar{{Country: "ru", Years: []int{2025, 2026}}}, calendars)
+
+	holidays, err := c.ListHolidays(ctx, "ru", "2025-10-30", "2025-11-02")
+	require.NoError(t, err)
+	assert.Equal(t, []*Holiday{
+		{Date: "2025-11-01", Name: "Рабочая суббота, перенос с 3 ноября", Kind: HolidayKindWorkday},
+		{Date: "2025-11-02", Name: "День народного единства", Kind: HolidayKindDayOff},
+	}, holidays)
+
+	date, err := c.AddWorkdays(ctx, "ru", "2025-11-05", -3)
+	require.NoError(t, err)
+	// November 1st is a worked Saturday.
+	assert.Equal(t, "2025-10-30", date)
+
+	_, err = c.UpdateSettings(ctx, 1, SettingsFields{WeekStart: WeekdayMonday, Country: "ru"})
+	require.NoError(t, err)
+
+	view, err := c.GetWeekView(ctx, WeekViewParams{WeekParams: WeekParams{DateParams: DateParams{UserID: 1}, Week: "2025-W45"}})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-11-03", view.Start)
+	require.Len(t, view.Days, 7)
+	assert.True(t, view.Days[0].Holiday)
+	assert.Equal(t, "День народного единства", view.Days[0].HolidayName)
+	assert.True(t, view.Days[2].Workday)
+
+	_, err = c.AddWorkdays(ctx, "de", "2025-11-05", 1)
+	var p *Problem
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, http.StatusNotFound, p.Status)
+}
+
+func TestWorkingHours(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	settings, err := c.UpdateSettings(ctx, 1, SettingsFields{
+		WeekStart:    WeekdayMonday,
+		TimeZone:     "Asia/Tokyo",
+		WorkingHours: []*WorkingHours{{Weekday: WeekdayMonday, Start: "10:00", End: "19:00"}},
+		OutOfOffice:  []*OutOfOffice{{Start: "2026-10-26", End: "2026-10-31", Note: "vacation"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", settings.TimeZone)
+	assert.Equal(t, []*OutOfOffice{{Start: "2026-10-26T00:00:00+09:00", End: "2026-10-31T00:00:00+09:00", Note: "vacation"}},
+		settings.OutOfOffice)
+
+	event, err := c.CreateEvent(ctx, CreateEventRequest{UserID: 1, Date: "2026-10-27", Title: "release"})
+	require.NoError(t, err)
+	require.Len(t, event.Warnings, 1)
+	assert.Equal(t, WarningOutOfOffice, event.Warnings[0].Code)
+
+	_, err = c.UpdateSettings(ctx, 2, SettingsFields{
+		WeekStart:    WeekdayMonday,
+		WorkingHours: []*WorkingHours{{Weekday: WeekdayMonday, Start: "00:00", End: "03:00"}},
+	})
+	require.NoError(t, err)
+
+	overlap, err := c.GetWorkingHoursOverlap(ctx, OverlapParams{UserIDs: []int{1, 2}, From: "2026-10-19", To: "2026-10-19"})
+	require.NoError(t, err)
+	require.Len(t, overlap.Users, 2)
+	assert.Equal(t, []*Interval{{Start: "2026-10-19T01:00:00Z", End: "2026-10-19T03:00:00Z"}}, overlap.Overlap)
+
+	overlap, err = c.GetWorkingHoursOverlap(ctx, OverlapParams{UserIDs: []int{1, 2}, From: "2026-10-26", To: "2026-10-26"})
+	require.NoError(t, err)
+	assert.Empty(t, overlap.Overlap)
+}
+
+func TestTemplates(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	template, err := c.CreateTemplate(ctx, CreateTemplateRequest{
+		UserID: 1, Name: "Sprint review", Title: "Sprint review", Duration: "1h", Tags: []string{"team"},
+	})
+	require.NoError(t, err)
+
+	location := "Room 4"
+	event, err := c.CreateEventFromTemplate(ctx, 1, template.ID, "2026-10-23T15:00:00Z", &EventPatch{Location: &location})
+	require.NoError(t, err)
+	assert.Equal(t, "Sprint review", event.Title)
+	assert.Equal(t, "Room 4", event.Location)
+	assert.Equal(t, []string{"team"}, event.Tags)
+	assert.Equal(t, "2026-10-23 16:00:00 +0000 UTC", event.End)
+
+	template, err = c.ReplaceTemplate(ctx, 1, template.ID, TemplateFields{Name: "Retro", Duration: "90m"})
+	require.NoError(t, err)
+	assert.Equal(t, "1h30m", template.Duration)
+
+	_, err = c.CreateEventFromTemplate(ctx, 1, template.ID, "2026-10-23T15:00:00Z", nil)
+	var p *Problem
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, "empty_title", p.Errors[0].Code)
+
+	templates, err := c.ListTemplates(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*Template{template}, templates)
+
+	require.NoError(t, c.DeleteTemplate(ctx, 1, template.ID))
+	_, err = c.GetTemplate(ctx, 1, template.ID)
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, http.StatusNotFound, p.Status)
+}
+
+func TestQuickEvent(t *testing.T) {
+	c := newServer(t)
+	ctx := context.Background()
+
+	draft, err := c.ParseEvent(ctx, QuickEventRequest{UserID: 1, Text: "Созвон 2030-05-10 в 10 утра на полчаса", TimeZone: "Europe/Moscow"})
+	require.NoError(t, err)
+	assert.Equal(t, "Созвон", draft.Event.Title)
+	assert.Equal(t, "2030-05-10T10:00:00+03:00", draft.Event.Date)
+	assert.Equal(t, "2030-05-10T10:30:00+03:00", draft.Event.End)
+
+	event, err := c.CreateEvent(ctx, *draft.Event)
+	require.NoError(t, err)
+	assert.Equal(t, "2030-05-10 07:00:00 +0000 UTC", event.Date)
+
+	event, err = c.CreateEventFromText(ctx, QuickEventRequest{UserID: 1, Text: "Standup 2030-05-13 at 9:30 every weekday"})
+	require.NoError(t, err)
+	assert.Equal(t, "Standup", event.Title)
+	require.Len(t, event.Warnings, 1)
+	assert.Equal(t, WarningRecurrenceIgnored, event.Warnings[0].Code)
+
+	_, err = c.ParseEvent(ctx, QuickEventRequest{UserID: 1, Text: "Read a book"})
+	var p *Problem
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, "date_not_found", p.Errors[0].Code)
+}
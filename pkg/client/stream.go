@@ -0,0 +1,72 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// EventStream reads messages from the Server-Sent Events change stream.
+type EventStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// StreamEvents subscribes to the changes of a user's events over Server-Sent Events.
+// A non-zero lastEventID resumes after that message. The stream ends when ctx is cancelled or Close is called.
+func (c *Client) StreamEvents(ctx context.Context, userID int, lastEventID int64) (*EventStream, error) {
+	r := request{
+		method: http.MethodGet,
+		path:   "/events_stream",
+		query:  userQuery(userID),
+		header: http.Header{"Accept": {"text/event-stream"}},
+	}
+	if lastEventID > 0 {
+		r.header.Set("Last-Event-ID", strconv.FormatInt(lastEventID, 10))
+	}
+
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventStream{body: resp.Body, scanner: bufio.NewScanner(resp.Body)}, nil
+}
+
+// Next blocks until the next message arrives. It returns io.EOF when the server closes the stream.
+func (s *EventStream) Next() (*Message, error) {
+	var data strings.Builder
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		switch {
+		case len(line) == 0:
+			if data.Len() == 0 {
+				continue
+			}
+
+			var msg Message
+			if err := json.Unmarshal([]byte(data.String()), &msg); err != nil {
+				return nil, fmt.Errorf("decode message: %w", err)
+			}
+			return &msg, nil
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+// Close ends the subscription.
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
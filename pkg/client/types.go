@@ -0,0 +1,606 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Event is a calendar event.
+type Event struct {
+	// ID is the ULID of the event; ULIDs sort by creation time.
+	ID string `json:"id"`
+	// LegacyID is the integer the event was identified by before ULIDs. The API accepts it in place
+	// of ID until clients have moved to ULIDs.
+	LegacyID int    `json:"legacy_id"`
+	UserID   int    `json:"user_id"`
+	Date     string `json:"date"`
+	// End is the exclusive end; for all-day events the day after the last one. Empty for an instant.
+	End    string `json:"end,omitempty"`
+	AllDay bool   `json:"all_day"`
+	// ContinuesBefore and ContinuesAfter are set by range queries on events that start before
+	// the range or end after it.
+	ContinuesBefore bool   `json:"continues_before,omitempty"`
+	ContinuesAfter  bool   `json:"continues_after,omitempty"`
+	Title           string `json:"title"`
+	// Tags are lower-case, sorted and never null.
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
+	// Version is incremented on every change; pass it back to make a write conditional.
+	Version int `json:"version"`
+	// Warnings are set on create responses about problems that did not stop the event, such as
+	// its owner being out of office.
+	Warnings []*Warning `json:"warnings,omitempty"`
+}
+
+// Warning is a problem with a created event that did not stop it.
+type Warning struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// Warning codes.
+const (
+	// WarningOutOfOffice is about an event in its owner's out-of-office period.
+	WarningOutOfOffice = "out_of_office"
+	// WarningRecurrenceIgnored is about an event created from text that asked it to repeat.
+	WarningRecurrenceIgnored = "recurrence_ignored"
+)
+
+// Event statuses.
+const (
+	StatusConfirmed = "confirmed"
+	StatusTentative = "tentative"
+	StatusCancelled = "cancelled"
+)
+
+// Event transparencies: whether an event blocks time in free/busy.
+const (
+	TransparencyBusy = "busy"
+	TransparencyFree = "free"
+)
+
+// Event visibilities: other users see every field of a public event, the title and date of a
+// confidential one and only the date of a private one.
+const (
+	VisibilityPublic       = "public"
+	VisibilityPrivate      = "private"
+	VisibilityConfidential = "confidential"
+)
+
+// CreateEventRequest is the payload for creating an event. Date and the optional exclusive End
+// are formatted as YYYY-MM-DD or as RFC 3339 date-times; all-day events take dates only.
+// Empty Status, Transparency and Visibility default to confirmed, busy and public.
+type CreateEventRequest struct {
+	UserID       int      `json:"user_id"`
+	Date         string   `json:"date"`
+	End          string   `json:"end,omitempty"`
+	AllDay       bool     `json:"all_day,omitempty"`
+	Title        string   `json:"title"`
+	Tags         []string `json:"tags,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Location     string   `json:"location,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Transparency string   `json:"transparency,omitempty"`
+	Visibility   string   `json:"visibility,omitempty"`
+}
+
+// EventFields are the client-writable fields of an event.
+type EventFields struct {
+	Date         string   `json:"date"`
+	End          string   `json:"end,omitempty"`
+	AllDay       bool     `json:"all_day,omitempty"`
+	Title        string   `json:"title"`
+	Tags         []string `json:"tags,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Location     string   `json:"location,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Transparency string   `json:"transparency,omitempty"`
+	Visibility   string   `json:"visibility,omitempty"`
+}
+
+// EventPatch is a partial update of an event; nil fields are left unchanged.
+type EventPatch struct {
+	Date   *string `json:"date,omitempty"`
+	End    *string `json:"end,omitempty"`
+	AllDay *bool   `json:"all_day,omitempty"`
+	Title  *string `json:"title,omitempty"`
+	// Tags replaces the whole tag list; point it to an empty slice to remove every tag.
+	Tags         *[]string `json:"tags,omitempty"`
+	Description  *string   `json:"description,omitempty"`
+	Location     *string   `json:"location,omitempty"`
+	URL          *string   `json:"url,omitempty"`
+	Status       *string   `json:"status,omitempty"`
+	Transparency *string   `json:"transparency,omitempty"`
+	Visibility   *string   `json:"visibility,omitempty"`
+}
+
+// Batch operation types.
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+// BatchRequest is a list of event mutations applied in order as one unit.
+type BatchRequest struct {
+	// ContinueOnError applies the valid operations even if some fail; otherwise none is applied.
+	ContinueOnError bool             `json:"continue_on_error,omitempty"`
+	Operations      []BatchOperation `json:"operations"`
+}
+
+// BatchOperation is a single create, update or delete of a batch.
+// A create sets UserID, Date and Title, an update additionally ID, and a delete ID and UserID.
+// ID may also be a legacy integer ID, including that of an event created earlier in the batch.
+type BatchOperation struct {
+	Op           string   `json:"op"`
+	ID           string   `json:"id,omitempty"`
+	UserID       int      `json:"user_id"`
+	Date         string   `json:"date,omitempty"`
+	End          string   `json:"end,omitempty"`
+	AllDay       bool     `json:"all_day,omitempty"`
+	Title        string   `json:"title,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Location     string   `json:"location,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Transparency string   `json:"transparency,omitempty"`
+	Visibility   string   `json:"visibility,omitempty"`
+	// Version makes the operation conditional like the version of ReplaceEvent.
+	Version int `json:"version,omitempty"`
+}
+
+// BatchResult is the outcome of a single batch operation.
+// Status is the status the single-event request would have answered with.
+type BatchResult struct {
+	Index  int      `json:"index"`
+	Status int      `json:"status"`
+	Event  *Event   `json:"event,omitempty"`
+	Error  *Problem `json:"error,omitempty"`
+}
+
+// ListEventsParams selects the events of a user between two dates inclusive.
+type ListEventsParams struct {
+	UserID int
+	From   string
+	To     string
+	// TimeZone is the IANA zone the dates are interpreted in; UTC when empty.
+	TimeZone string
+	// Tags keeps only events with any of the tags; ExcludeTags drops events with any of them.
+	Tags        []string
+	ExcludeTags []string
+}
+
+// Statistics periods.
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// StatsParams selects the events counted by GetEventStats.
+type StatsParams struct {
+	ListEventsParams
+	// Period is the length of a counted period; the server default of a week when empty.
+	Period string
+}
+
+// PeriodStats holds the event counts of the period starting on the day Start.
+type PeriodStats struct {
+	Start      string         `json:"start"`
+	Total      int            `json:"total"`
+	Untagged   int            `json:"untagged"`
+	Tags       map[string]int `json:"tags"`
+	Categories map[string]int `json:"categories"`
+}
+
+// Tag is a tag of a user's events with its optional category and #rrggbb color.
+type Tag struct {
+	UserID   int    `json:"user_id"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color,omitempty"`
+}
+
+// CreateTagRequest is the payload for creating a tag.
+type CreateTagRequest struct {
+	UserID   int    `json:"user_id"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color,omitempty"`
+}
+
+// TagFields are the client-writable fields of a tag.
+type TagFields struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color,omitempty"`
+}
+
+// QuickEventRequest is the text an event is read from, in Russian or English, such as
+// "lunch with Ivan tomorrow 13:00 for 1h". TimeZone defaults to the user's time zone.
+type QuickEventRequest struct {
+	UserID   int    `json:"user_id"`
+	Text     string `json:"text"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// EventDraft is an event read from text that has not been created. Event can be passed to CreateEvent
+// as is. Recurrence is the RRULE the text asked for, which events do not support yet.
+type EventDraft struct {
+	Event      *CreateEventRequest `json:"event"`
+	TimeZone   string              `json:"time_zone"`
+	Recurrence string              `json:"recurrence,omitempty"`
+	Warnings   []*Warning          `json:"warnings,omitempty"`
+}
+
+// Template holds the default fields of events created from it. Duration is a Go duration such as
+// "1h30m", whole days for all-day templates; empty for instants and one-day all-day events.
+type Template struct {
+	ID           int      `json:"id"`
+	UserID       int      `json:"user_id"`
+	Name         string   `json:"name"`
+	Title        string   `json:"title"`
+	Duration     string   `json:"duration"`
+	AllDay       bool     `json:"all_day"`
+	Tags         []string `json:"tags"`
+	Description  string   `json:"description"`
+	Location     string   `json:"location"`
+	URL          string   `json:"url"`
+	Status       string   `json:"status"`
+	Transparency string   `json:"transparency"`
+	Visibility   string   `json:"visibility"`
+}
+
+// CreateTemplateRequest is the payload for saving an event template.
+type CreateTemplateRequest struct {
+	UserID       int      `json:"user_id"`
+	Name         string   `json:"name"`
+	Title        string   `json:"title,omitempty"`
+	Duration     string   `json:"duration,omitempty"`
+	AllDay       bool     `json:"all_day,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Location     string   `json:"location,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Transparency string   `json:"transparency,omitempty"`
+	Visibility   string   `json:"visibility,omitempty"`
+}
+
+// TemplateFields are the client-writable fields of an event template.
+type TemplateFields struct {
+	Name         string   `json:"name"`
+	Title        string   `json:"title,omitempty"`
+	Duration     string   `json:"duration,omitempty"`
+	AllDay       bool     `json:"all_day,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Location     string   `json:"location,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Transparency string   `json:"transparency,omitempty"`
+	Visibility   string   `json:"visibility,omitempty"`
+}
+
+// SearchParams selects a user's events by words in their text.
+type SearchParams struct {
+	UserID int
+	Query  string
+	// From and To optionally limit the search to a range of days, both inclusive.
+	From     string
+	To       string
+	TimeZone string
+	// Limit caps the number of hits; the server default when zero.
+	Limit int
+}
+
+// SearchHit is an event found by a search and its relevance; higher is better.
+type SearchHit struct {
+	Event *Event  `json:"event"`
+	Score float64 `json:"score"`
+}
+
+// DateParams selects the events of the day, week or month containing Date.
+type DateParams struct {
+	UserID   int
+	Date     string
+	TimeZone string
+	// Tags and ExcludeTags filter the events as in ListEventsParams.
+	Tags        []string
+	ExcludeTags []string
+}
+
+// WeekParams selects the events of a week: the calendar week containing Date, or the ISO week Week
+// such as 2026-W42, which runs from Monday to Sunday. Set exactly one of them.
+type WeekParams struct {
+	DateParams
+	Week string
+	// WeekStart is the first day of the week of Date, e.g. WeekdaySunday; the user's settings when empty.
+	WeekStart string
+}
+
+// MonthGridParams selects the month grid of the month containing Date.
+type MonthGridParams struct {
+	DateParams
+	// WeekStart is the first column of the grid; the user's settings when empty.
+	WeekStart string
+	// MaxEvents is how many events a day lists; the server default when zero.
+	MaxEvents int
+	// Holidays is the country of the holiday calendar marked on the days; the user's settings when empty.
+	Holidays string
+}
+
+// WeekViewParams selects the week view of a week chosen as in WeekParams.
+type WeekViewParams struct {
+	WeekParams
+	// Holidays is the country of the holiday calendar marked on the days; the user's settings when empty.
+	Holidays string
+}
+
+// MonthGrid is a month laid out as six weeks of seven days.
+type MonthGrid struct {
+	// Month is formatted as YYYY-MM.
+	Month     string       `json:"month"`
+	WeekStart string       `json:"week_start"`
+	Weeks     [][]*GridDay `json:"weeks"`
+}
+
+// GridDay is a single day of a MonthGrid.
+type GridDay struct {
+	Date    string `json:"date"`
+	InMonth bool   `json:"in_month"`
+	Weekend bool   `json:"weekend"`
+	// Holiday marks a day off in the holiday calendar. HolidayName is its calendar entry, also set
+	// for a weekend day that is worked; Workday is false on weekends and days off.
+	Holiday     bool   `json:"holiday"`
+	HolidayName string `json:"holiday_name,omitempty"`
+	Workday     bool   `json:"workday"`
+	// Events are the first events of the day, all-day ones first; Overflow counts the others.
+	Events   []*Event `json:"events"`
+	Overflow int      `json:"overflow"`
+}
+
+// WeekView is a calendar week laid out day by day.
+type WeekView struct {
+	// Start is the first day of the week.
+	Start     string     `json:"start"`
+	WeekStart string     `json:"week_start"`
+	Days      []*WeekDay `json:"days"`
+}
+
+// WeekDay is a single day of a WeekView, marked as in GridDay.
+type WeekDay struct {
+	Date        string `json:"date"`
+	Weekend     bool   `json:"weekend"`
+	Holiday     bool   `json:"holiday"`
+	HolidayName string `json:"holiday_name,omitempty"`
+	Workday     bool   `json:"workday"`
+	// Events are all events of the day, all-day ones first.
+	Events []*Event `json:"events"`
+}
+
+// HolidayCalendar is a holiday calendar loaded by the server.
+type HolidayCalendar struct {
+	// Country is a lower-case ISO 3166 code such as ru.
+	Country string `json:"country"`
+	Years   []int  `json:"years"`
+}
+
+// Kinds of Holiday.
+const (
+	HolidayKindDayOff  = "holiday"
+	HolidayKindWorkday = "workday"
+)
+
+// Holiday is a day off or a worked weekend day of a holiday calendar.
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// Days of the week, as used by WeekParams and Settings.
+const (
+	WeekdayMonday    = "monday"
+	WeekdayTuesday   = "tuesday"
+	WeekdayWednesday = "wednesday"
+	WeekdayThursday  = "thursday"
+	WeekdayFriday    = "friday"
+	WeekdaySaturday  = "saturday"
+	WeekdaySunday    = "sunday"
+)
+
+// Settings are the calendar settings of a user.
+type Settings struct {
+	UserID int `json:"user_id"`
+	// WeekStart is the first day of the user's weeks; monday by default.
+	WeekStart string `json:"week_start"`
+	// Country selects the holiday calendar marked in the user's views; none when empty.
+	Country string `json:"country,omitempty"`
+	// TimeZone is the IANA time zone of the working hours and out-of-office dates; UTC by default.
+	TimeZone string `json:"time_zone"`
+	// WorkingHours are the weekly hours the user works; a user without any works at any time.
+	WorkingHours []*WorkingHours `json:"working_hours"`
+	OutOfOffice  []*OutOfOffice  `json:"out_of_office"`
+}
+
+// SettingsFields are the client-writable settings of a user.
+type SettingsFields struct {
+	WeekStart    string          `json:"week_start"`
+	Country      string          `json:"country,omitempty"`
+	TimeZone     string          `json:"time_zone,omitempty"`
+	WorkingHours []*WorkingHours `json:"working_hours,omitempty"`
+	OutOfOffice  []*OutOfOffice  `json:"out_of_office,omitempty"`
+}
+
+// WorkingHours are the hours a user works on a weekday. Start and End are HH:MM; End may be 24:00.
+type WorkingHours struct {
+	Weekday string `json:"weekday"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// OutOfOffice is a period in which a user is away. Start and End are dates, midnights in the user's
+// time zone, or RFC 3339 date-times; End is exclusive.
+type OutOfOffice struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Note  string `json:"note,omitempty"`
+}
+
+// OverlapParams select the users and dates of GetWorkingHoursOverlap.
+type OverlapParams struct {
+	UserIDs []int
+	// From and To are dates; the range covers both in full.
+	From string
+	To   string
+	// TimeZone is the IANA time zone of the dates and returned times; UTC when empty.
+	TimeZone string
+}
+
+// Interval is the time from Start to the exclusive End, both RFC 3339.
+type Interval struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// UserWorkingTime is the time a user works within a range.
+type UserWorkingTime struct {
+	UserID   int         `json:"user_id"`
+	TimeZone string      `json:"time_zone"`
+	Working  []*Interval `json:"working"`
+}
+
+// WorkingHoursOverlap holds the working time of several users and the part of it they all share.
+type WorkingHoursOverlap struct {
+	Users   []*UserWorkingTime `json:"users"`
+	Overlap []*Interval        `json:"overlap"`
+}
+
+// SyncResponse holds the changes since a sync token.
+type SyncResponse struct {
+	Events  []*Event `json:"events"`
+	Deleted []string `json:"deleted"`
+	// LegacyDeleted holds the legacy integer IDs of the deleted events in the order of Deleted.
+	LegacyDeleted []int `json:"legacy_deleted"`
+	// Token is passed to the next Sync call.
+	Token string `json:"token"`
+}
+
+// Change types a webhook subscription can be limited to.
+const (
+	ChangeCreated = "event.created"
+	ChangeUpdated = "event.updated"
+	ChangeDeleted = "event.deleted"
+)
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Subscription is a webhook subscription.
+type Subscription struct {
+	ID        int      `json:"id"`
+	UserID    int      `json:"user_id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	CreatedAt string   `json:"created_at"`
+}
+
+// CreateSubscriptionRequest is the payload for registering a webhook.
+type CreateSubscriptionRequest struct {
+	UserID int      `json:"user_id"`
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events,omitempty"`
+}
+
+// Delivery is a webhook delivery log entry.
+type Delivery struct {
+	ID             int    `json:"id"`
+	SubscriptionID int    `json:"subscription_id"`
+	EventID        string `json:"event_id"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+// ResetType is the Message type telling a client to reload its data.
+const ResetType = "reset"
+
+// Message is a single change received from the change stream.
+type Message struct {
+	ID         int64  `json:"id"`
+	Type       string `json:"type"`
+	OccurredAt string `json:"occurred_at,omitempty"`
+	Event      *Event `json:"event,omitempty"`
+}
+
+// Problem is the RFC 7807 error returned by the API. It implements error.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	Errors   []FieldProblem `json:"errors,omitempty"`
+	// Current is the current state of the event when a conditional write fails.
+	Current *Event `json:"current,omitempty"`
+}
+
+// FieldProblem describes a single invalid request field.
+type FieldProblem struct {
+	Field  string `json:"field"`
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// Error implements the error interface.
+func (p *Problem) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s", p.Status, p.Code)
+	if len(p.Detail) > 0 {
+		b.WriteString(": ")
+		b.WriteString(p.Detail)
+	}
+
+	return b.String()
+}
+
+// GraphQLError is a single error reported by a GraphQL operation.
+type GraphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+	// Extensions carries the stable error "code" and, for validation errors, the invalid fields in "errors".
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors are the errors of a GraphQL operation. It implements error.
+type GraphQLErrors []GraphQLError
+
+// Error implements the error interface.
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+
+	return "graphql: " + strings.Join(messages, "; ")
+}
@@ -0,0 +1,1749 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: calendar/v1/calendar.proto
+
+package calendarv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	ChangeType_CHANGE_TYPE_CREATED     ChangeType = 1
+	ChangeType_CHANGE_TYPE_UPDATED     ChangeType = 2
+	ChangeType_CHANGE_TYPE_DELETED     ChangeType = 3
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "CHANGE_TYPE_CREATED",
+		2: "CHANGE_TYPE_UPDATED",
+		3: "CHANGE_TYPE_DELETED",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"CHANGE_TYPE_CREATED":     1,
+		"CHANGE_TYPE_UPDATED":     2,
+		"CHANGE_TYPE_DELETED":     3,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_calendar_v1_calendar_proto_enumTypes[0].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_calendar_v1_calendar_proto_enumTypes[0]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{0}
+}
+
+type EventStatus int32
+
+const (
+	EventStatus_EVENT_STATUS_UNSPECIFIED EventStatus = 0
+	EventStatus_EVENT_STATUS_CONFIRMED   EventStatus = 1
+	EventStatus_EVENT_STATUS_TENTATIVE   EventStatus = 2
+	EventStatus_EVENT_STATUS_CANCELLED   EventStatus = 3
+)
+
+// Enum value maps for EventStatus.
+var (
+	EventStatus_name = map[int32]string{
+		0: "EVENT_STATUS_UNSPECIFIED",
+		1: "EVENT_STATUS_CONFIRMED",
+		2: "EVENT_STATUS_TENTATIVE",
+		3: "EVENT_STATUS_CANCELLED",
+	}
+	EventStatus_value = map[string]int32{
+		"EVENT_STATUS_UNSPECIFIED": 0,
+		"EVENT_STATUS_CONFIRMED":   1,
+		"EVENT_STATUS_TENTATIVE":   2,
+		"EVENT_STATUS_CANCELLED":   3,
+	}
+)
+
+func (x EventStatus) Enum() *EventStatus {
+	p := new(EventStatus)
+	*p = x
+	return p
+}
+
+func (x EventStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_calendar_v1_calendar_proto_enumTypes[1].Descriptor()
+}
+
+func (EventStatus) Type() protoreflect.EnumType {
+	return &file_calendar_v1_calendar_proto_enumTypes[1]
+}
+
+func (x EventStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventStatus.Descriptor instead.
+func (EventStatus) EnumDescriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{1}
+}
+
+// Transparency tells whether an event blocks time in free/busy.
+type Transparency int32
+
+const (
+	Transparency_TRANSPARENCY_UNSPECIFIED Transparency = 0
+	Transparency_TRANSPARENCY_BUSY        Transparency = 1
+	Transparency_TRANSPARENCY_FREE        Transparency = 2
+)
+
+// Enum value maps for Transparency.
+var (
+	Transparency_name = map[int32]string{
+		0: "TRANSPARENCY_UNSPECIFIED",
+		1: "TRANSPARENCY_BUSY",
+		2: "TRANSPARENCY_FREE",
+	}
+	Transparency_value = map[string]int32{
+		"TRANSPARENCY_UNSPECIFIED": 0,
+		"TRANSPARENCY_BUSY":        1,
+		"TRANSPARENCY_FREE":        2,
+	}
+)
+
+func (x Transparency) Enum() *Transparency {
+	p := new(Transparency)
+	*p = x
+	return p
+}
+
+func (x Transparency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Transparency) Descriptor() protoreflect.EnumDescriptor {
+	return file_calendar_v1_calendar_proto_enumTypes[2].Descriptor()
+}
+
+func (Transparency) Type() protoreflect.EnumType {
+	return &file_calendar_v1_calendar_proto_enumTypes[2]
+}
+
+func (x Transparency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Transparency.Descriptor instead.
+func (Transparency) EnumDescriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{2}
+}
+
+// Visibility tells how much of an event other users see: everything, the title
+// and date (confidential) or only the date (private).
+type Visibility int32
+
+const (
+	Visibility_VISIBILITY_UNSPECIFIED  Visibility = 0
+	Visibility_VISIBILITY_PUBLIC       Visibility = 1
+	Visibility_VISIBILITY_PRIVATE      Visibility = 2
+	Visibility_VISIBILITY_CONFIDENTIAL Visibility = 3
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "VISIBILITY_PUBLIC",
+		2: "VISIBILITY_PRIVATE",
+		3: "VISIBILITY_CONFIDENTIAL",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED":  0,
+		"VISIBILITY_PUBLIC":       1,
+		"VISIBILITY_PRIVATE":      2,
+		"VISIBILITY_CONFIDENTIAL": 3,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_calendar_v1_calendar_proto_enumTypes[3].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_calendar_v1_calendar_proto_enumTypes[3]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{3}
+}
+
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the ULID of the event; ULIDs sort by creation time.
+	Id string `protobuf:"bytes,17,opt,name=id,proto3" json:"id,omitempty"`
+	// legacy_id is the integer the event was identified by before ULIDs, kept until clients have moved to them.
+	LegacyId int64                  `protobuf:"varint,1,opt,name=legacy_id,json=legacyId,proto3" json:"legacy_id,omitempty"`
+	UserId   int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Date     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Title    string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	// version is incremented on every change.
+	Version      int64        `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Tags         []string     `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Description  string       `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Location     string       `protobuf:"bytes,8,opt,name=location,proto3" json:"location,omitempty"`
+	Url          string       `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	Status       EventStatus  `protobuf:"varint,10,opt,name=status,proto3,enum=calendar.v1.EventStatus" json:"status,omitempty"`
+	Transparency Transparency `protobuf:"varint,11,opt,name=transparency,proto3,enum=calendar.v1.Transparency" json:"transparency,omitempty"`
+	Visibility   Visibility   `protobuf:"varint,12,opt,name=visibility,proto3,enum=calendar.v1.Visibility" json:"visibility,omitempty"`
+	// end is the exclusive end; for all-day events the midnight UTC after the last day.
+	End *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=end,proto3" json:"end,omitempty"`
+	// all_day events cover whole dates, shown on the same dates in every time zone.
+	AllDay bool `protobuf:"varint,14,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	// continues_before and continues_after are set by range queries on events that
+	// start before the range or end after it.
+	ContinuesBefore bool `protobuf:"varint,15,opt,name=continues_before,json=continuesBefore,proto3" json:"continues_before,omitempty"`
+	ContinuesAfter  bool `protobuf:"varint,16,opt,name=continues_after,json=continuesAfter,proto3" json:"continues_after,omitempty"`
+	// warnings are set only on the event returned by CreateEvent, about problems that did not
+	// stop it from being created, such as an out-of-office period of its owner it takes up.
+	Warnings      []*Warning `protobuf:"bytes,18,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetLegacyId() int64 {
+	if x != nil {
+		return x.LegacyId
+	}
+	return 0
+}
+
+func (x *Event) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Event) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Event) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Event) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Event) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Event) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Event) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Event) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Event) GetStatus() EventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EventStatus_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *Event) GetTransparency() Transparency {
+	if x != nil {
+		return x.Transparency
+	}
+	return Transparency_TRANSPARENCY_UNSPECIFIED
+}
+
+func (x *Event) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *Event) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Event) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *Event) GetContinuesBefore() bool {
+	if x != nil {
+		return x.ContinuesBefore
+	}
+	return false
+}
+
+func (x *Event) GetContinuesAfter() bool {
+	if x != nil {
+		return x.ContinuesAfter
+	}
+	return false
+}
+
+func (x *Event) GetWarnings() []*Warning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// Warning is an advisory about a request that did not stop it.
+type Warning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Detail        string                 `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warning) Reset() {
+	*x = Warning{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warning) ProtoMessage() {}
+
+func (x *Warning) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warning.ProtoReflect.Descriptor instead.
+func (*Warning) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Warning) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Warning) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type CreateEventRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Date   string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Title  string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// tags are created for the user if they do not exist yet.
+	Tags []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Unspecified status, transparency and visibility default to confirmed, busy and public.
+	Description  string       `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Location     string       `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	Url          string       `protobuf:"bytes,7,opt,name=url,proto3" json:"url,omitempty"`
+	Status       EventStatus  `protobuf:"varint,8,opt,name=status,proto3,enum=calendar.v1.EventStatus" json:"status,omitempty"`
+	Transparency Transparency `protobuf:"varint,9,opt,name=transparency,proto3,enum=calendar.v1.Transparency" json:"transparency,omitempty"`
+	Visibility   Visibility   `protobuf:"varint,10,opt,name=visibility,proto3,enum=calendar.v1.Visibility" json:"visibility,omitempty"`
+	// date and the optional exclusive end are YYYY-MM-DD dates or RFC 3339 date-times;
+	// all-day events take dates only.
+	End           string `protobuf:"bytes,11,opt,name=end,proto3" json:"end,omitempty"`
+	AllDay        bool   `protobuf:"varint,12,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEventRequest) Reset() {
+	*x = CreateEventRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEventRequest) ProtoMessage() {}
+
+func (x *CreateEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEventRequest.ProtoReflect.Descriptor instead.
+func (*CreateEventRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateEventRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateEventRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateEventRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetStatus() EventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EventStatus_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *CreateEventRequest) GetTransparency() Transparency {
+	if x != nil {
+		return x.Transparency
+	}
+	return Transparency_TRANSPARENCY_UNSPECIFIED
+}
+
+func (x *CreateEventRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *CreateEventRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+// Requests addressing a single event take its ULID in id or, until clients have moved to ULIDs,
+// its integer ID in legacy_id.
+type GetEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	LegacyId      int64                  `protobuf:"varint,1,opt,name=legacy_id,json=legacyId,proto3" json:"legacy_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRequest) Reset() {
+	*x = GetEventRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRequest) ProtoMessage() {}
+
+func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetEventRequest) GetLegacyId() int64 {
+	if x != nil {
+		return x.LegacyId
+	}
+	return 0
+}
+
+func (x *GetEventRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UpdateEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,15,opt,name=id,proto3" json:"id,omitempty"`
+	LegacyId      int64                  `protobuf:"varint,1,opt,name=legacy_id,json=legacyId,proto3" json:"legacy_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Location      string                 `protobuf:"bytes,8,opt,name=location,proto3" json:"location,omitempty"`
+	Url           string                 `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	Status        EventStatus            `protobuf:"varint,10,opt,name=status,proto3,enum=calendar.v1.EventStatus" json:"status,omitempty"`
+	Transparency  Transparency           `protobuf:"varint,11,opt,name=transparency,proto3,enum=calendar.v1.Transparency" json:"transparency,omitempty"`
+	Visibility    Visibility             `protobuf:"varint,12,opt,name=visibility,proto3,enum=calendar.v1.Visibility" json:"visibility,omitempty"`
+	End           string                 `protobuf:"bytes,13,opt,name=end,proto3" json:"end,omitempty"`
+	AllDay        bool                   `protobuf:"varint,14,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEventRequest) Reset() {
+	*x = UpdateEventRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEventRequest) ProtoMessage() {}
+
+func (x *UpdateEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEventRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEventRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetLegacyId() int64 {
+	if x != nil {
+		return x.LegacyId
+	}
+	return 0
+}
+
+func (x *UpdateEventRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateEventRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateEventRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetStatus() EventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EventStatus_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateEventRequest) GetTransparency() Transparency {
+	if x != nil {
+		return x.Transparency
+	}
+	return Transparency_TRANSPARENCY_UNSPECIFIED
+}
+
+func (x *UpdateEventRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *UpdateEventRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+type PatchEventRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,16,opt,name=id,proto3" json:"id,omitempty"`
+	LegacyId int64                  `protobuf:"varint,1,opt,name=legacy_id,json=legacyId,proto3" json:"legacy_id,omitempty"`
+	UserId   int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Date     string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Title    string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Version  int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// update_mask lists the fields to change: "date", "end", "all_day", "title", "tags",
+	// "description", "location", "url", "status", "transparency" and "visibility".
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	Location      string                 `protobuf:"bytes,9,opt,name=location,proto3" json:"location,omitempty"`
+	Url           string                 `protobuf:"bytes,10,opt,name=url,proto3" json:"url,omitempty"`
+	Status        EventStatus            `protobuf:"varint,11,opt,name=status,proto3,enum=calendar.v1.EventStatus" json:"status,omitempty"`
+	Transparency  Transparency           `protobuf:"varint,12,opt,name=transparency,proto3,enum=calendar.v1.Transparency" json:"transparency,omitempty"`
+	Visibility    Visibility             `protobuf:"varint,13,opt,name=visibility,proto3,enum=calendar.v1.Visibility" json:"visibility,omitempty"`
+	End           string                 `protobuf:"bytes,14,opt,name=end,proto3" json:"end,omitempty"`
+	AllDay        bool                   `protobuf:"varint,15,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchEventRequest) Reset() {
+	*x = PatchEventRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchEventRequest) ProtoMessage() {}
+
+func (x *PatchEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchEventRequest.ProtoReflect.Descriptor instead.
+func (*PatchEventRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PatchEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PatchEventRequest) GetLegacyId() int64 {
+	if x != nil {
+		return x.LegacyId
+	}
+	return 0
+}
+
+func (x *PatchEventRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PatchEventRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *PatchEventRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PatchEventRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PatchEventRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *PatchEventRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PatchEventRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PatchEventRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *PatchEventRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PatchEventRequest) GetStatus() EventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EventStatus_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *PatchEventRequest) GetTransparency() Transparency {
+	if x != nil {
+		return x.Transparency
+	}
+	return Transparency_TRANSPARENCY_UNSPECIFIED
+}
+
+func (x *PatchEventRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *PatchEventRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *PatchEventRequest) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+type DeleteEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	LegacyId      int64                  `protobuf:"varint,1,opt,name=legacy_id,json=legacyId,proto3" json:"legacy_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventRequest) Reset() {
+	*x = DeleteEventRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventRequest) ProtoMessage() {}
+
+func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteEventRequest) GetLegacyId() int64 {
+	if x != nil {
+		return x.LegacyId
+	}
+	return 0
+}
+
+func (x *DeleteEventRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeleteEventRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DateRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Date     string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	TimeZone string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// tags keeps only the events with any of them; exclude_tags drops the events with any of them.
+	Tags          []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	ExcludeTags   []string `protobuf:"bytes,5,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DateRequest) Reset() {
+	*x = DateRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateRequest) ProtoMessage() {}
+
+func (x *DateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateRequest.ProtoReflect.Descriptor instead.
+func (*DateRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DateRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DateRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DateRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *DateRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DateRequest) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+type RangeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	From     string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To       string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	TimeZone string                 `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// tags and exclude_tags filter the events as in DateRequest.
+	Tags          []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	ExcludeTags   []string `protobuf:"bytes,6,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RangeRequest) Reset() {
+	*x = RangeRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeRequest) ProtoMessage() {}
+
+func (x *RangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeRequest.ProtoReflect.Descriptor instead.
+func (*RangeRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RangeRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RangeRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RangeRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *RangeRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *RangeRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RangeRequest) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+type SyncRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// token is the token of the previous sync; empty for a full sync.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncRequest) Reset() {
+	*x = SyncRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRequest) ProtoMessage() {}
+
+func (x *SyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRequest.ProtoReflect.Descriptor instead.
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SyncRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SyncRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SyncResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// deleted holds the ULIDs of the deleted events and legacy_deleted their integer IDs in the same order.
+	Deleted       []string `protobuf:"bytes,4,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	LegacyDeleted []int64  `protobuf:"varint,2,rep,packed,name=legacy_deleted,json=legacyDeleted,proto3" json:"legacy_deleted,omitempty"`
+	Token         string   `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncResponse) Reset() {
+	*x = SyncResponse{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncResponse) ProtoMessage() {}
+
+func (x *SyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncResponse.ProtoReflect.Descriptor instead.
+func (*SyncResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SyncResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetLegacyDeleted() []int64 {
+	if x != nil {
+		return x.LegacyDeleted
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type WatchChangesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// last_id resumes the feed after this message; zero starts from now.
+	LastId        int64 `protobuf:"varint,2,opt,name=last_id,json=lastId,proto3" json:"last_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchChangesRequest) Reset() {
+	*x = WatchChangesRequest{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchChangesRequest) ProtoMessage() {}
+
+func (x *WatchChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchChangesRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchChangesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WatchChangesRequest) GetLastId() int64 {
+	if x != nil {
+		return x.LastId
+	}
+	return 0
+}
+
+type ChangeMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// reset_required tells the client it missed changes and must sync again.
+	ResetRequired bool                   `protobuf:"varint,2,opt,name=reset_required,json=resetRequired,proto3" json:"reset_required,omitempty"`
+	Type          ChangeType             `protobuf:"varint,3,opt,name=type,proto3,enum=calendar.v1.ChangeType" json:"type,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Event         *Event                 `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeMessage) Reset() {
+	*x = ChangeMessage{}
+	mi := &file_calendar_v1_calendar_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeMessage) ProtoMessage() {}
+
+func (x *ChangeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_v1_calendar_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeMessage.ProtoReflect.Descriptor instead.
+func (*ChangeMessage) Descriptor() ([]byte, []int) {
+	return file_calendar_v1_calendar_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChangeMessage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChangeMessage) GetResetRequired() bool {
+	if x != nil {
+		return x.ResetRequired
+	}
+	return false
+}
+
+func (x *ChangeMessage) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ChangeMessage) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *ChangeMessage) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_calendar_v1_calendar_proto protoreflect.FileDescriptor
+
+const file_calendar_v1_calendar_proto_rawDesc = "" +
+	"\n" +
+	"\x1acalendar/v1/calendar.proto\x12\vcalendar.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x05\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x11 \x01(\tR\x02id\x12\x1b\n" +
+	"\tlegacy_id\x18\x01 \x01(\x03R\blegacyId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x1a\n" +
+	"\blocation\x18\b \x01(\tR\blocation\x12\x10\n" +
+	"\x03url\x18\t \x01(\tR\x03url\x120\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x18.calendar.v1.EventStatusR\x06status\x12=\n" +
+	"\ftransparency\x18\v \x01(\x0e2\x19.calendar.v1.TransparencyR\ftransparency\x127\n" +
+	"\n" +
+	"visibility\x18\f \x01(\x0e2\x17.calendar.v1.VisibilityR\n" +
+	"visibility\x12,\n" +
+	"\x03end\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x17\n" +
+	"\aall_day\x18\x0e \x01(\bR\x06allDay\x12)\n" +
+	"\x10continues_before\x18\x0f \x01(\bR\x0fcontinuesBefore\x12'\n" +
+	"\x0fcontinues_after\x18\x10 \x01(\bR\x0econtinuesAfter\x120\n" +
+	"\bwarnings\x18\x12 \x03(\v2\x14.calendar.v1.WarningR\bwarnings\"5\n" +
+	"\aWarning\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06detail\x18\x02 \x01(\tR\x06detail\"\x90\x03\n" +
+	"\x12CreateEventRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\x12\x10\n" +
+	"\x03url\x18\a \x01(\tR\x03url\x120\n" +
+	"\x06status\x18\b \x01(\x0e2\x18.calendar.v1.EventStatusR\x06status\x12=\n" +
+	"\ftransparency\x18\t \x01(\x0e2\x19.calendar.v1.TransparencyR\ftransparency\x127\n" +
+	"\n" +
+	"visibility\x18\n" +
+	" \x01(\x0e2\x17.calendar.v1.VisibilityR\n" +
+	"visibility\x12\x10\n" +
+	"\x03end\x18\v \x01(\tR\x03end\x12\x17\n" +
+	"\aall_day\x18\f \x01(\bR\x06allDay\"W\n" +
+	"\x0fGetEventRequest\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x1b\n" +
+	"\tlegacy_id\x18\x01 \x01(\x03R\blegacyId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xd7\x03\n" +
+	"\x12UpdateEventRequest\x12\x0e\n" +
+	"\x02id\x18\x0f \x01(\tR\x02id\x12\x1b\n" +
+	"\tlegacy_id\x18\x01 \x01(\x03R\blegacyId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x1a\n" +
+	"\blocation\x18\b \x01(\tR\blocation\x12\x10\n" +
+	"\x03url\x18\t \x01(\tR\x03url\x120\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x18.calendar.v1.EventStatusR\x06status\x12=\n" +
+	"\ftransparency\x18\v \x01(\x0e2\x19.calendar.v1.TransparencyR\ftransparency\x127\n" +
+	"\n" +
+	"visibility\x18\f \x01(\x0e2\x17.calendar.v1.VisibilityR\n" +
+	"visibility\x12\x10\n" +
+	"\x03end\x18\r \x01(\tR\x03end\x12\x17\n" +
+	"\aall_day\x18\x0e \x01(\bR\x06allDay\"\x93\x04\n" +
+	"\x11PatchEventRequest\x12\x0e\n" +
+	"\x02id\x18\x10 \x01(\tR\x02id\x12\x1b\n" +
+	"\tlegacy_id\x18\x01 \x01(\x03R\blegacyId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1a\n" +
+	"\blocation\x18\t \x01(\tR\blocation\x12\x10\n" +
+	"\x03url\x18\n" +
+	" \x01(\tR\x03url\x120\n" +
+	"\x06status\x18\v \x01(\x0e2\x18.calendar.v1.EventStatusR\x06status\x12=\n" +
+	"\ftransparency\x18\f \x01(\x0e2\x19.calendar.v1.TransparencyR\ftransparency\x127\n" +
+	"\n" +
+	"visibility\x18\r \x01(\x0e2\x17.calendar.v1.VisibilityR\n" +
+	"visibility\x12\x10\n" +
+	"\x03end\x18\x0e \x01(\tR\x03end\x12\x17\n" +
+	"\aall_day\x18\x0f \x01(\bR\x06allDay\"t\n" +
+	"\x12DeleteEventRequest\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12\x1b\n" +
+	"\tlegacy_id\x18\x01 \x01(\x03R\blegacyId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"\x8e\x01\n" +
+	"\vDateRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12!\n" +
+	"\fexclude_tags\x18\x05 \x03(\tR\vexcludeTags\"\x9f\x01\n" +
+	"\fRangeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12!\n" +
+	"\fexclude_tags\x18\x06 \x03(\tR\vexcludeTags\"<\n" +
+	"\vSyncRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x91\x01\n" +
+	"\fSyncResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.calendar.v1.EventR\x06events\x12\x18\n" +
+	"\adeleted\x18\x04 \x03(\tR\adeleted\x12%\n" +
+	"\x0elegacy_deleted\x18\x02 \x03(\x03R\rlegacyDeleted\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"G\n" +
+	"\x13WatchChangesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x17\n" +
+	"\alast_id\x18\x02 \x01(\x03R\x06lastId\"\xda\x01\n" +
+	"\rChangeMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12%\n" +
+	"\x0ereset_required\x18\x02 \x01(\bR\rresetRequired\x12+\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x17.calendar.v1.ChangeTypeR\x04type\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12(\n" +
+	"\x05event\x18\x05 \x01(\v2\x12.calendar.v1.EventR\x05event*t\n" +
+	"\n" +
+	"ChangeType\x12\x1b\n" +
+	"\x17CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CHANGE_TYPE_CREATED\x10\x01\x12\x17\n" +
+	"\x13CHANGE_TYPE_UPDATED\x10\x02\x12\x17\n" +
+	"\x13CHANGE_TYPE_DELETED\x10\x03*\x7f\n" +
+	"\vEventStatus\x12\x1c\n" +
+	"\x18EVENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16EVENT_STATUS_CONFIRMED\x10\x01\x12\x1a\n" +
+	"\x16EVENT_STATUS_TENTATIVE\x10\x02\x12\x1a\n" +
+	"\x16EVENT_STATUS_CANCELLED\x10\x03*Z\n" +
+	"\fTransparency\x12\x1c\n" +
+	"\x18TRANSPARENCY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TRANSPARENCY_BUSY\x10\x01\x12\x15\n" +
+	"\x11TRANSPARENCY_FREE\x10\x02*t\n" +
+	"\n" +
+	"Visibility\x12\x1a\n" +
+	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11VISIBILITY_PUBLIC\x10\x01\x12\x16\n" +
+	"\x12VISIBILITY_PRIVATE\x10\x02\x12\x1b\n" +
+	"\x17VISIBILITY_CONFIDENTIAL\x10\x032\x83\x06\n" +
+	"\x0fCalendarService\x12B\n" +
+	"\vCreateEvent\x12\x1f.calendar.v1.CreateEventRequest\x1a\x12.calendar.v1.Event\x12<\n" +
+	"\bGetEvent\x12\x1c.calendar.v1.GetEventRequest\x1a\x12.calendar.v1.Event\x12B\n" +
+	"\vUpdateEvent\x12\x1f.calendar.v1.UpdateEventRequest\x1a\x12.calendar.v1.Event\x12@\n" +
+	"\n" +
+	"PatchEvent\x12\x1e.calendar.v1.PatchEventRequest\x1a\x12.calendar.v1.Event\x12F\n" +
+	"\vDeleteEvent\x12\x1f.calendar.v1.DeleteEventRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\x10ListEventsForDay\x12\x18.calendar.v1.DateRequest\x1a\x12.calendar.v1.Event0\x01\x12C\n" +
+	"\x11ListEventsForWeek\x12\x18.calendar.v1.DateRequest\x1a\x12.calendar.v1.Event0\x01\x12D\n" +
+	"\x12ListEventsForMonth\x12\x18.calendar.v1.DateRequest\x1a\x12.calendar.v1.Event0\x01\x12D\n" +
+	"\x11ListEventsInRange\x12\x19.calendar.v1.RangeRequest\x1a\x12.calendar.v1.Event0\x01\x12;\n" +
+	"\x04Sync\x12\x18.calendar.v1.SyncRequest\x1a\x19.calendar.v1.SyncResponse\x12N\n" +
+	"\fWatchChanges\x12 .calendar.v1.WatchChangesRequest\x1a\x1a.calendar.v1.ChangeMessage0\x01BDZBgithub.com/biryanim/wb_tech_calendar/pkg/pb/calendar/v1;calendarv1b\x06proto3"
+
+var (
+	file_calendar_v1_calendar_proto_rawDescOnce sync.Once
+	file_calendar_v1_calendar_proto_rawDescData []byte
+)
+
+func file_calendar_v1_calendar_proto_rawDescGZIP() []byte {
+	file_calendar_v1_calendar_proto_rawDescOnce.Do(func() {
+		file_calendar_v1_calendar_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_calendar_v1_calendar_proto_rawDesc), len(file_calendar_v1_calendar_proto_rawDesc)))
+	})
+	return file_calendar_v1_calendar_proto_rawDescData
+}
+
+var file_calendar_v1_calendar_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_calendar_v1_calendar_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_calendar_v1_calendar_proto_goTypes = []any{
+	(ChangeType)(0),               // 0: calendar.v1.ChangeType
+	(EventStatus)(0),              // 1: calendar.v1.EventStatus
+	(Transparency)(0),             // 2: calendar.v1.Transparency
+	(Visibility)(0),               // 3: calendar.v1.Visibility
+	(*Event)(nil),                 // 4: calendar.v1.Event
+	(*Warning)(nil),               // 5: calendar.v1.Warning
+	(*CreateEventRequest)(nil),    // 6: calendar.v1.CreateEventRequest
+	(*GetEventRequest)(nil),       // 7: calendar.v1.GetEventRequest
+	(*UpdateEventRequest)(nil),    // 8: calendar.v1.UpdateEventRequest
+	(*PatchEventRequest)(nil),     // 9: calendar.v1.PatchEventRequest
+	(*DeleteEventRequest)(nil),    // 10: calendar.v1.DeleteEventRequest
+	(*DateRequest)(nil),           // 11: calendar.v1.DateRequest
+	(*RangeRequest)(nil),          // 12: calendar.v1.RangeRequest
+	(*SyncRequest)(nil),           // 13: calendar.v1.SyncRequest
+	(*SyncResponse)(nil),          // 14: calendar.v1.SyncResponse
+	(*WatchChangesRequest)(nil),   // 15: calendar.v1.WatchChangesRequest
+	(*ChangeMessage)(nil),         // 16: calendar.v1.ChangeMessage
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 18: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),         // 19: google.protobuf.Empty
+}
+var file_calendar_v1_calendar_proto_depIdxs = []int32{
+	17, // 0: calendar.v1.Event.date:type_name -> google.protobuf.Timestamp
+	1,  // 1: calendar.v1.Event.status:type_name -> calendar.v1.EventStatus
+	2,  // 2: calendar.v1.Event.transparency:type_name -> calendar.v1.Transparency
+	3,  // 3: calendar.v1.Event.visibility:type_name -> calendar.v1.Visibility
+	17, // 4: calendar.v1.Event.end:type_name -> google.protobuf.Timestamp
+	5,  // 5: calendar.v1.Event.warnings:type_name -> calendar.v1.Warning
+	1,  // 6: calendar.v1.CreateEventRequest.status:type_name -> calendar.v1.EventStatus
+	2,  // 7: calendar.v1.CreateEventRequest.transparency:type_name -> calendar.v1.Transparency
+	3,  // 8: calendar.v1.CreateEventRequest.visibility:type_name -> calendar.v1.Visibility
+	1,  // 9: calendar.v1.UpdateEventRequest.status:type_name -> calendar.v1.EventStatus
+	2,  // 10: calendar.v1.UpdateEventRequest.transparency:type_name -> calendar.v1.Transparency
+	3,  // 11: calendar.v1.UpdateEventRequest.visibility:type_name -> calendar.v1.Visibility
+	18, // 12: calendar.v1.PatchEventRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 13: calendar.v1.PatchEventRequest.status:type_name -> calendar.v1.EventStatus
+	2,  // 14: calendar.v1.PatchEventRequest.transparency:type_name -> calendar.v1.Transparency
+	3,  // 15: calendar.v1.PatchEventRequest.visibility:type_name -> calendar.v1.Visibility
+	4,  // 16: calendar.v1.SyncResponse.events:type_name -> calendar.v1.Event
+	0,  // 17: calendar.v1.ChangeMessage.type:type_name -> calendar.v1.ChangeType
+	17, // 18: calendar.v1.ChangeMessage.occurred_at:type_name -> google.protobuf.Timestamp
+	4,  // 19: calendar.v1.ChangeMessage.event:type_name -> calendar.v1.Event
+	6,  // 20: calendar.v1.CalendarService.CreateEvent:input_type -> calendar.v1.CreateEventRequest
+	7,  // 21: calendar.v1.CalendarService.GetEvent:input_type -> calendar.v1.GetEventRequest
+	8,  // 22: calendar.v1.CalendarService.UpdateEvent:input_type -> calendar.v1.UpdateEventRequest
+	9,  // 23: calendar.v1.CalendarService.PatchEvent:input_type -> calendar.v1.PatchEventRequest
+	10, // 24: calendar.v1.CalendarService.DeleteEvent:input_type -> calendar.v1.DeleteEventRequest
+	11, // 25: calendar.v1.CalendarService.ListEventsForDay:input_type -> calendar.v1.DateRequest
+	11, // 26: calendar.v1.CalendarService.ListEventsForWeek:input_type -> calendar.v1.DateRequest
+	11, // 27: calendar.v1.CalendarService.ListEventsForMonth:input_type -> calendar.v1.DateRequest
+	12, // 28: calendar.v1.CalendarService.ListEventsInRange:input_type -> calendar.v1.RangeRequest
+	13, // 29: calendar.v1.CalendarService.Sync:input_type -> calendar.v1.SyncRequest
+	15, // 30: calendar.v1.CalendarService.WatchChanges:input_type -> calendar.v1.WatchChangesRequest
+	4,  // 31: calendar.v1.CalendarService.CreateEvent:output_type -> calendar.v1.Event
+	4,  // 32: calendar.v1.CalendarService.GetEvent:output_type -> calendar.v1.Event
+	4,  // 33: calendar.v1.CalendarService.UpdateEvent:output_type -> calendar.v1.Event
+	4,  // 34: calendar.v1.CalendarService.PatchEvent:output_type -> calendar.v1.Event
+	19, // 35: calendar.v1.CalendarService.DeleteEvent:output_type -> google.protobuf.Empty
+	4,  // 36: calendar.v1.CalendarService.ListEventsForDay:output_type -> calendar.v1.Event
+	4,  // 37: calendar.v1.CalendarService.ListEventsForWeek:output_type -> calendar.v1.Event
+	4,  // 38: calendar.v1.CalendarService.ListEventsForMonth:output_type -> calendar.v1.Event
+	4,  // 39: calendar.v1.CalendarService.ListEventsInRange:output_type -> calendar.v1.Event
+	14, // 40: calendar.v1.CalendarService.Sync:output_type -> calendar.v1.SyncResponse
+	16, // 41: calendar.v1.CalendarService.WatchChanges:output_type -> calendar.v1.ChangeMessage
+	31, // [31:42] is the sub-list for method output_type
+	20, // [20:31] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_calendar_v1_calendar_proto_init() }
+func file_calendar_v1_calendar_proto_init() {
+	if File_calendar_v1_calendar_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_calendar_v1_calendar_proto_rawDesc), len(file_calendar_v1_calendar_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_calendar_v1_calendar_proto_goTypes,
+		DependencyIndexes: file_calendar_v1_calendar_proto_depIdxs,
+		EnumInfos:         file_calendar_v1_calendar_proto_enumTypes,
+		MessageInfos:      file_calendar_v1_calendar_proto_msgTypes,
+	}.Build()
+	File_calendar_v1_calendar_proto = out.File
+	file_calendar_v1_calendar_proto_goTypes = nil
+	file_calendar_v1_calendar_proto_depIdxs = nil
+}
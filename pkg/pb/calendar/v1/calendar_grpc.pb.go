@@ -0,0 +1,548 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: calendar/v1/calendar.proto
+
+package calendarv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CalendarService_CreateEvent_FullMethodName        = "/calendar.v1.CalendarService/CreateEvent"
+	CalendarService_GetEvent_FullMethodName           = "/calendar.v1.CalendarService/GetEvent"
+	CalendarService_UpdateEvent_FullMethodName        = "/calendar.v1.CalendarService/UpdateEvent"
+	CalendarService_PatchEvent_FullMethodName         = "/calendar.v1.CalendarService/PatchEvent"
+	CalendarService_DeleteEvent_FullMethodName        = "/calendar.v1.CalendarService/DeleteEvent"
+	CalendarService_ListEventsForDay_FullMethodName   = "/calendar.v1.CalendarService/ListEventsForDay"
+	CalendarService_ListEventsForWeek_FullMethodName  = "/calendar.v1.CalendarService/ListEventsForWeek"
+	CalendarService_ListEventsForMonth_FullMethodName = "/calendar.v1.CalendarService/ListEventsForMonth"
+	CalendarService_ListEventsInRange_FullMethodName  = "/calendar.v1.CalendarService/ListEventsInRange"
+	CalendarService_Sync_FullMethodName               = "/calendar.v1.CalendarService/Sync"
+	CalendarService_WatchChanges_FullMethodName       = "/calendar.v1.CalendarService/WatchChanges"
+)
+
+// CalendarServiceClient is the client API for CalendarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CalendarService mirrors service.CalendarService.
+//
+// Dates in requests are calendar days formatted as YYYY-MM-DD and interpreted in
+// the optional IANA time_zone, UTC by default. Errors carry google.rpc.BadRequest
+// details listing the invalid fields.
+type CalendarServiceClient interface {
+	CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*Event, error)
+	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error)
+	// UpdateEvent replaces every writable field of an event.
+	// A non-zero version fails with ABORTED if the event has changed since.
+	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*Event, error)
+	// PatchEvent changes only the fields listed in update_mask.
+	PatchEvent(ctx context.Context, in *PatchEventRequest, opts ...grpc.CallOption) (*Event, error)
+	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListEventsForDay(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// ListEventsForWeek streams the calendar week containing date. Weeks start
+	// on the first weekday of the user's settings, Monday by default.
+	ListEventsForWeek(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	ListEventsForMonth(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// ListEventsInRange streams the events between two days inclusive.
+	ListEventsInRange(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// Sync returns the events changed since a sync token. An expired token
+	// fails with FAILED_PRECONDITION and requires a full sync.
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	// WatchChanges streams the changes of a user's events as they happen.
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeMessage], error)
+}
+
+type calendarServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalendarServiceClient(cc grpc.ClientConnInterface) CalendarServiceClient {
+	return &calendarServiceClient{cc}
+}
+
+func (c *calendarServiceClient) CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, CalendarService_CreateEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, CalendarService_GetEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, CalendarService_UpdateEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) PatchEvent(ctx context.Context, in *PatchEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, CalendarService_PatchEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListEventsForDay(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[0], CalendarService_ListEventsForDay_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DateRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListEventsForDayClient = grpc.ServerStreamingClient[Event]
+
+func (c *calendarServiceClient) ListEventsForWeek(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[1], CalendarService_ListEventsForWeek_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DateRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListEventsForWeekClient = grpc.ServerStreamingClient[Event]
+
+func (c *calendarServiceClient) ListEventsForMonth(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[2], CalendarService_ListEventsForMonth_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DateRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListEventsForMonthClient = grpc.ServerStreamingClient[Event]
+
+func (c *calendarServiceClient) ListEventsInRange(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[3], CalendarService_ListEventsInRange_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RangeRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListEventsInRangeClient = grpc.ServerStreamingClient[Event]
+
+func (c *calendarServiceClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, CalendarService_Sync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[4], CalendarService_WatchChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchChangesRequest, ChangeMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_WatchChangesClient = grpc.ServerStreamingClient[ChangeMessage]
+
+// CalendarServiceServer is the server API for CalendarService service.
+// All implementations must embed UnimplementedCalendarServiceServer
+// for forward compatibility.
+//
+// CalendarService mirrors service.CalendarService.
+//
+// Dates in requests are calendar days formatted as YYYY-MM-DD and interpreted in
+// the optional IANA time_zone, UTC by default. Errors carry google.rpc.BadRequest
+// details listing the invalid fields.
+type CalendarServiceServer interface {
+	CreateEvent(context.Context, *CreateEventRequest) (*Event, error)
+	GetEvent(context.Context, *GetEventRequest) (*Event, error)
+	// UpdateEvent replaces every writable field of an event.
+	// A non-zero version fails with ABORTED if the event has changed since.
+	UpdateEvent(context.Context, *UpdateEventRequest) (*Event, error)
+	// PatchEvent changes only the fields listed in update_mask.
+	PatchEvent(context.Context, *PatchEventRequest) (*Event, error)
+	DeleteEvent(context.Context, *DeleteEventRequest) (*emptypb.Empty, error)
+	ListEventsForDay(*DateRequest, grpc.ServerStreamingServer[Event]) error
+	// ListEventsForWeek streams the calendar week containing date. Weeks start
+	// on the first weekday of the user's settings, Monday by default.
+	ListEventsForWeek(*DateRequest, grpc.ServerStreamingServer[Event]) error
+	ListEventsForMonth(*DateRequest, grpc.ServerStreamingServer[Event]) error
+	// ListEventsInRange streams the events between two days inclusive.
+	ListEventsInRange(*RangeRequest, grpc.ServerStreamingServer[Event]) error
+	// Sync returns the events changed since a sync token. An expired token
+	// fails with FAILED_PRECONDITION and requires a full sync.
+	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	// WatchChanges streams the changes of a user's events as they happen.
+	WatchChanges(*WatchChangesRequest, grpc.ServerStreamingServer[ChangeMessage]) error
+	mustEmbedUnimplementedCalendarServiceServer()
+}
+
+// UnimplementedCalendarServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCalendarServiceServer struct{}
+
+func (UnimplementedCalendarServiceServer) CreateEvent(context.Context, *CreateEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetEvent(context.Context, *GetEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) UpdateEvent(context.Context, *UpdateEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) PatchEvent(context.Context, *PatchEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteEvent(context.Context, *DeleteEventRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListEventsForDay(*DateRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method ListEventsForDay not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListEventsForWeek(*DateRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method ListEventsForWeek not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListEventsForMonth(*DateRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method ListEventsForMonth not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListEventsInRange(*RangeRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method ListEventsInRange not implemented")
+}
+func (UnimplementedCalendarServiceServer) Sync(context.Context, *SyncRequest) (*SyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
+}
+func (UnimplementedCalendarServiceServer) WatchChanges(*WatchChangesRequest, grpc.ServerStreamingServer[ChangeMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedCalendarServiceServer) mustEmbedUnimplementedCalendarServiceServer() {}
+func (UnimplementedCalendarServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeCalendarServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalendarServiceServer will
+// result in compilation errors.
+type UnsafeCalendarServiceServer interface {
+	mustEmbedUnimplementedCalendarServiceServer()
+}
+
+func RegisterCalendarServiceServer(s grpc.ServiceRegistrar, srv CalendarServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCalendarServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CalendarService_ServiceDesc, srv)
+}
+
+func _CalendarService_CreateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateEvent(ctx, req.(*CreateEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetEvent(ctx, req.(*GetEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_UpdateEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateEvent(ctx, req.(*UpdateEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_PatchEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).PatchEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_PatchEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).PatchEvent(ctx, req.(*PatchEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteEvent(ctx, req.(*DeleteEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListEventsForDay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).ListEventsForDay(m, &grpc.GenericServerStream[DateRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListEventsForDayServer = grpc.ServerStreamingServer[Event]
+
+func _CalendarService_ListEventsForWeek_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).ListEventsForWeek(m, &grpc.GenericServerStream[DateRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListEventsForWeekServer = grpc.ServerStreamingServer[Event]
+
+func _CalendarService_ListEventsForMonth_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).ListEventsForMonth(m, &grpc.GenericServerStream[DateRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListEventsForMonthServer = grpc.ServerStreamingServer[Event]
+
+func _CalendarService_ListEventsInRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).ListEventsInRange(m, &grpc.GenericServerStream[RangeRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListEventsInRangeServer = grpc.ServerStreamingServer[Event]
+
+func _CalendarService_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_Sync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).Sync(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).WatchChanges(m, &grpc.GenericServerStream[WatchChangesRequest, ChangeMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_WatchChangesServer = grpc.ServerStreamingServer[ChangeMessage]
+
+// CalendarService_ServiceDesc is the grpc.ServiceDesc for CalendarService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CalendarService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "calendar.v1.CalendarService",
+	HandlerType: (*CalendarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEvent",
+			Handler:    _CalendarService_CreateEvent_Handler,
+		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _CalendarService_GetEvent_Handler,
+		},
+		{
+			MethodName: "UpdateEvent",
+			Handler:    _CalendarService_UpdateEvent_Handler,
+		},
+		{
+			MethodName: "PatchEvent",
+			Handler:    _CalendarService_PatchEvent_Handler,
+		},
+		{
+			MethodName: "DeleteEvent",
+			Handler:    _CalendarService_DeleteEvent_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _CalendarService_Sync_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListEventsForDay",
+			Handler:       _CalendarService_ListEventsForDay_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListEventsForWeek",
+			Handler:       _CalendarService_ListEventsForWeek_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListEventsForMonth",
+			Handler:       _CalendarService_ListEventsForMonth_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListEventsInRange",
+			Handler:       _CalendarService_ListEventsInRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _CalendarService_WatchChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "calendar/v1/calendar.proto",
+}